@@ -1,10 +1,9 @@
 package main
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"strconv"
 
+	"github.com/harlow/go-micro-services/services/user"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -38,8 +37,10 @@ func initializeDatabase(url string) *mgo.Session {
 			log.Fatal().Msg(err.Error())
 		}
 		if count == 0 {
-			sum := sha256.Sum256([]byte(password))
-			pass := fmt.Sprintf("%x", sum)
+			pass, err := user.HashPassword(password)
+			if err != nil {
+				log.Fatal().Msg(err.Error())
+			}
 			err = c.Insert(&User{user_name, pass})
 			if err != nil {
 				log.Fatal().Msg(err.Error())
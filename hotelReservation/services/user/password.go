@@ -0,0 +1,82 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+// Argon2id parameters for new hashes. Stored hashes carry the parameters
+// they were made with, so these can be raised without breaking old records;
+// records hashed with other parameters are re-hashed on their next login.
+const (
+	PASSWORD_TIME      = 2
+	PASSWORD_MEMORY    = 19 * 1024 // KiB
+	PASSWORD_THREADS   = 1
+	PASSWORD_SALT_SIZE = 16
+	PASSWORD_KEY_SIZE  = 32
+	PASSWORD_PREFIX    = "$argon2id$"
+)
+
+// HashPassword hashes a password with a fresh salt in the PHC string format,
+// e.g. "$argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>".
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, PASSWORD_SALT_SIZE)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(
+		[]byte(password), salt, PASSWORD_TIME, PASSWORD_MEMORY, PASSWORD_THREADS, PASSWORD_KEY_SIZE)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", PASSWORD_PREFIX, argon2.Version,
+		PASSWORD_MEMORY, PASSWORD_TIME, PASSWORD_THREADS,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword checks a password against a stored hash in constant time.
+// Besides argon2id hashes it accepts the unsalted hex sha256 hashes of older
+// records. rehash is set when the password matches but the stored hash is
+// legacy or uses other parameters than PASSWORD_*.
+func VerifyPassword(password, stored string) (ok bool, rehash bool) {
+	if !strings.HasPrefix(stored, PASSWORD_PREFIX) {
+		legacy, err := hex.DecodeString(stored)
+		if err != nil || len(legacy) != sha256.Size {
+			return false, false
+		}
+		sum := sha256.Sum256([]byte(password))
+		return subtle.ConstantTimeCompare(sum[:], legacy) == 1, true
+	}
+	var version int
+	var memory, time uint32
+	var threads uint8
+	parts := strings.Split(strings.TrimPrefix(stored, PASSWORD_PREFIX), "$")
+	if len(parts) != 4 {
+		return false, false
+	}
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false
+	}
+	_, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &memory, &time, &threads)
+	if err != nil || time == 0 || threads == 0 {
+		return false, false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return false, false
+	}
+	computed := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return false, false
+	}
+	rehash = memory != PASSWORD_MEMORY || time != PASSWORD_TIME || threads != PASSWORD_THREADS ||
+		len(salt) != PASSWORD_SALT_SIZE || len(key) != PASSWORD_KEY_SIZE
+	return true, rehash
+}
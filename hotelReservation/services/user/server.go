@@ -1,9 +1,12 @@
 package user
 
 import (
-	"crypto/sha256"
 	// "encoding/json"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
@@ -32,6 +35,12 @@ const name = "srv-user"
 // Server implements the user service
 type Server struct {
 	users map[string]string
+	mu    sync.RWMutex
+	// verified holds, per user, a MAC of the last password that matched
+	// the stored hash, so repeated checks skip the deliberately slow
+	// argon2id; verifyKey is random per process and never stored.
+	verified  map[string][]byte
+	verifyKey []byte
 
 	Tracer       opentracing.Tracer
 	Registry     *registry.Client
@@ -54,6 +63,11 @@ func (s *Server) Run() error {
 	if s.users == nil {
 		s.users = loadUsers(s.MongoSession)
 	}
+	s.verified = make(map[string][]byte)
+	s.verifyKey = make([]byte, 32)
+	if _, err := rand.Read(s.verifyKey); err != nil {
+		return fmt.Errorf("failed to make verification key: %v", err)
+	}
 
 	s.uuid = uuid.New().String()
 
@@ -115,9 +129,6 @@ func (s *Server) CheckUser(ctx context.Context, req *pb.Request) (*pb.Result, er
 
 	log.Trace().Msg("CheckUser")
 
-	sum := sha256.Sum256([]byte(req.Password))
-	pass := fmt.Sprintf("%x", sum)
-
 	// session, err := mgo.Dial("mongodb-user")
 	// if err != nil {
 	// 	panic(err)
@@ -132,8 +143,27 @@ func (s *Server) CheckUser(ctx context.Context, req *pb.Request) (*pb.Result, er
 	// 	panic(err)
 	// }
 	res.Correct = false
-	if true_pass, found := s.users[req.Username]; found {
-		res.Correct = pass == true_pass
+	s.mu.RLock()
+	true_pass, found := s.users[req.Username]
+	s.mu.RUnlock()
+	if found {
+		mac := s.verifyMAC(req.Password, true_pass)
+		s.mu.RLock()
+		cached := s.verified[req.Username]
+		s.mu.RUnlock()
+		if cached != nil && hmac.Equal(mac, cached) {
+			res.Correct = true
+		} else {
+			var rehash bool
+			res.Correct, rehash = VerifyPassword(req.Password, true_pass)
+			if res.Correct && rehash {
+				s.rehashPassword(req.Username, req.Password, true_pass)
+			} else if res.Correct {
+				s.mu.Lock()
+				s.verified[req.Username] = mac
+				s.mu.Unlock()
+			}
+		}
 	}
 
 	// res.Correct = user.Password == pass
@@ -143,6 +173,40 @@ func (s *Server) CheckUser(ctx context.Context, req *pb.Request) (*pb.Result, er
 	return res, nil
 }
 
+// verifyMAC binds a password to the stored hash it was checked against, so
+// a changed or rehashed password no longer matches what was cached.
+func (s *Server) verifyMAC(password, storedHash string) []byte {
+	mac := hmac.New(sha256.New, s.verifyKey)
+	mac.Write([]byte(storedHash))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+// rehashPassword upgrades the stored hash of a user who just logged in with
+// a legacy or outdated hash. Failures are logged; the next login retries.
+func (s *Server) rehashPassword(username, password, oldPass string) {
+	pass, err := HashPassword(password)
+	if err != nil {
+		log.Error().Msgf("Failed to rehash password of %s: %v", username, err)
+		return
+	}
+	session := s.MongoSession.Copy()
+	defer session.Close()
+	c := session.DB("user-db").C("user")
+	// only replace the hash that was verified
+	err = c.Update(bson.M{"username": username, "password": oldPass}, bson.M{"$set": bson.M{"password": pass}})
+	if err != nil && err != mgo.ErrNotFound {
+		log.Error().Msgf("Failed to rehash password of %s: %v", username, err)
+		return
+	}
+	s.mu.Lock()
+	if s.users[username] == oldPass {
+		s.users[username] = pass
+	}
+	s.mu.Unlock()
+}
+
 // loadUsers loads hotel users from mongodb.
 func loadUsers(session *mgo.Session) map[string]string {
 	// session, err := mgo.Dial("mongodb-user")
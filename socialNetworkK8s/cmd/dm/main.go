@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+	"time"
+	"socialnetworkk8/services/dm"
+	"socialnetworkk8/tune"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"runtime/debug"
+)
+
+func main() {
+	debug.SetGCPercent(-1)
+	tune.Init()
+	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}).With().Timestamp().Caller().Logger()
+	log.Info().Msg("Creating Dm server...")
+	srv := dm.MakeDmSrv()
+	log.Info().Msg("Starting Dm server...")
+	log.Fatal().Msg(srv.Run().Error())
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+	"time"
+	"socialnetworkk8/services/idgen"
+	"socialnetworkk8/tune"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"runtime/debug"
+)
+
+func main() {
+	debug.SetGCPercent(-1)
+	tune.Init()
+	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}).With().Timestamp().Caller().Logger()
+	log.Info().Msg("Creating Idgen server...")
+	srv := idgen.MakeIdgenSrv()
+	log.Info().Msg("Starting Idgen server...")
+	log.Fatal().Msg(srv.Run().Error())
+}
@@ -0,0 +1,153 @@
+// Command loadgen puts load on the frontend. It logs in the test users
+// user_0 ... user_<users-1> (with passwords p_user_<i>, as the tests create
+// them) and runs a mix of timeline reads, composes and follow changes
+// against the query string routes until the duration is up. Reads go as
+// GET, and state changes as POST with a url-encoded form.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type op struct {
+	name    string
+	ratio   float64
+	// state-changing requests go as POST, the others as GET
+	post    bool
+	request func(userid int) (string, url.Values)
+	counter *tracing.Counter
+	nreq    int64
+	nfail   int64
+	nlimit  int64
+}
+
+func main() {
+	addr := flag.String("addr", "http://localhost:5000", "frontend address")
+	nusers := flag.Int("users", 10, "number of test users")
+	nworkers := flag.Int("workers", 8, "number of concurrent clients")
+	duration := flag.Duration("duration", 30*time.Second, "how long to run")
+	flag.Parse()
+	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}).With().Timestamp().Caller().Logger()
+	if *nusers < 2 {
+		// follows and mentions need another user than the acting one
+		log.Fatal().Msgf("Need at least 2 users, got %v", *nusers)
+	}
+
+	log.Info().Msg("Logging in users...")
+	tokens := make([]string, *nusers)
+	for i := range tokens {
+		suffix := strconv.Itoa(i)
+		resp, err := http.PostForm(*addr+"/login", url.Values{
+			"username": {"user_" + suffix}, "password": {"p_user_" + suffix}})
+		if err != nil {
+			log.Fatal().Msgf("Cannot log in user_%v: %v", i, err)
+		}
+		reply := make(map[string]interface{})
+		json.NewDecoder(resp.Body).Decode(&reply)
+		resp.Body.Close()
+		token, ok := reply["token"].(string)
+		if !ok {
+			log.Fatal().Msgf("Cannot log in user_%v: %v", i, reply["message"])
+		}
+		tokens[i] = token
+	}
+
+	otherUser := func(userid int) string {
+		other := rand.Intn(*nusers - 1)
+		if other >= userid {
+			other++
+		}
+		return strconv.Itoa(other)
+	}
+	page := func() url.Values {
+		start := rand.Intn(20)
+		return url.Values{"start": {strconv.Itoa(start)}, "stop": {strconv.Itoa(start + 10)}}
+	}
+	ops := []*op{
+		{name: "home", ratio: 0.50, request: func(userid int) (string, url.Values) {
+			return "/home", page()
+		}},
+		{name: "timeline", ratio: 0.25, request: func(userid int) (string, url.Values) {
+			params := page()
+			params.Set("userid", otherUser(userid))
+			return "/timeline", params
+		}},
+		{name: "compose", post: true, ratio: 0.10, request: func(userid int) (string, url.Values) {
+			text := "load post " + strconv.FormatInt(rand.Int63(), 36) + " @user_" + otherUser(userid)
+			return "/compose", url.Values{"text": {text}, "posttype": {"post"}}
+		}},
+		{name: "follow", post: true, ratio: 0.075, request: func(userid int) (string, url.Values) {
+			return "/follow", url.Values{"followeeid": {otherUser(userid)}}
+		}},
+		{name: "unfollow", post: true, ratio: 0.075, request: func(userid int) (string, url.Values) {
+			return "/unfollow", url.Values{"followeeid": {otherUser(userid)}}
+		}},
+	}
+	for _, o := range ops {
+		o.counter = tracing.MakeCounter("Load-" + o.name)
+	}
+
+	log.Info().Msg("Users logged in. Starting load...")
+	deadline := time.Now().Add(*duration)
+	var wg sync.WaitGroup
+	for w := 0; w < *nworkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				coin := rand.Float64()
+				o := ops[len(ops)-1]
+				for _, candidate := range ops {
+					if coin < candidate.ratio {
+						o = candidate
+						break
+					}
+					coin -= candidate.ratio
+				}
+				userid := rand.Intn(*nusers)
+				path, params := o.request(userid)
+				var req *http.Request
+				if o.post {
+					req, _ = http.NewRequest(http.MethodPost, *addr+path, strings.NewReader(params.Encode()))
+					req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				} else {
+					req, _ = http.NewRequest(http.MethodGet, *addr+path+"?"+params.Encode(), nil)
+				}
+				req.Header.Set("Authorization", "Bearer "+tokens[userid])
+				t0 := time.Now()
+				resp, err := http.DefaultClient.Do(req)
+				atomic.AddInt64(&o.nreq, 1)
+				if err != nil {
+					atomic.AddInt64(&o.nfail, 1)
+					continue
+				}
+				resp.Body.Close()
+				o.counter.AddTimeSince(t0)
+				if resp.StatusCode == http.StatusTooManyRequests {
+					atomic.AddInt64(&o.nlimit, 1)
+				} else if resp.StatusCode != http.StatusOK {
+					atomic.AddInt64(&o.nfail, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for _, o := range ops {
+		log.Info().Msgf("%v: %v requests, %v failed, %v rate limited",
+			o.name, o.nreq, o.nfail, o.nlimit)
+	}
+	log.Info().Msg("Load finished. Exiting...")
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"time"
+	"golang.org/x/net/context"
+	"socialnetworkk8/services/cacheclnt"
+)
+
+const (
+	KEY_PREFIX = "ratelimit_"
+	BUCKET_SIZE = 16
+)
+
+// Limit is a token bucket refilling at Rate tokens per second up to Burst
+// tokens. A Rate of 0 turns the limit off.
+type Limit struct {
+	Rate  float64
+	Burst float64
+}
+
+// LoadLimit reads the limit called name from config, as name+"Rate" and
+// name+"Burst", falling back to def for whatever is missing or malformed.
+func LoadLimit(config map[string]string, name string, def Limit) Limit {
+	limit := def
+	if rate, err := strconv.ParseFloat(config[name+"Rate"], 64); err == nil {
+		limit.Rate = rate
+	}
+	if burst, err := strconv.ParseFloat(config[name+"Burst"], 64); err == nil {
+		limit.Burst = burst
+	}
+	return limit
+}
+
+// Bucket is the state of a token bucket as the cache keeps it.
+type Bucket struct {
+	Tokens  float64
+	Updated int64 // unix nanoseconds
+}
+
+func DecodeBucket(val []byte) *Bucket {
+	if len(val) != BUCKET_SIZE {
+		return nil
+	}
+	return &Bucket{
+		Tokens: math.Float64frombits(binary.LittleEndian.Uint64(val[:8])),
+		Updated: int64(binary.LittleEndian.Uint64(val[8:])),
+	}
+}
+
+func (b *Bucket) Encode() []byte {
+	val := make([]byte, BUCKET_SIZE)
+	binary.LittleEndian.PutUint64(val[:8], math.Float64bits(b.Tokens))
+	binary.LittleEndian.PutUint64(val[8:], uint64(b.Updated))
+	return val
+}
+
+// Take refills b up to now and takes n tokens if it holds that many.
+// Otherwise it takes none and returns how long until it will hold n. A
+// missing bucket (nil) starts out full.
+func Take(b *Bucket, now int64, rate, burst, n float64) (*Bucket, bool, time.Duration) {
+	if b == nil {
+		b = &Bucket{Tokens: burst, Updated: now}
+	}
+	if now > b.Updated {
+		b.Tokens = math.Min(burst, b.Tokens+rate*float64(now-b.Updated)/float64(time.Second))
+		b.Updated = now
+	}
+	if b.Tokens >= n {
+		b.Tokens -= n
+		return b, true, 0
+	}
+	if rate <= 0 || n > burst {
+		// never enough; callers still get a finite wait to report
+		return b, false, time.Duration(math.MaxInt32) * time.Second
+	}
+	return b, false, time.Duration((n - b.Tokens) / rate * float64(time.Second))
+}
+
+// Limiter applies a Limit per user, through buckets kept in the cache so
+// that every replica of a service draws from the same ones.
+type Limiter struct {
+	cachec *cacheclnt.CacheClnt
+	name   string
+	limit  Limit
+}
+
+func MakeLimiter(cachec *cacheclnt.CacheClnt, name string, limit Limit) *Limiter {
+	return &Limiter{cachec: cachec, name: name, limit: limit}
+}
+
+// Take takes n tokens from userid's bucket. If there are not enough it
+// returns false and how long to wait before trying again.
+func (l *Limiter) Take(ctx context.Context, userid int64, n int) (bool, time.Duration, error) {
+	if l.limit.Rate <= 0 || n <= 0 {
+		return true, 0, nil
+	}
+	key := KEY_PREFIX + l.name + "_" + strconv.FormatInt(userid, 10)
+	return l.cachec.TakeTokens(ctx, key, l.limit.Rate, l.limit.Burst, float64(n))
+}
@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	consul "github.com/hashicorp/consul/api"
+	"github.com/rs/zerolog/log"
+)
+
+// Lease is an id in [0, max) held exclusively through a consul session.
+// Consul frees the id once the session stops being renewed, and keeps it
+// from being re-leased for another TTL, so a crashed holder's id is not
+// reused while its last uses may still be around.
+type Lease struct {
+	Id      int64
+	c       *Client
+	key     string
+	session string
+	held    int32
+	done    chan struct{}
+}
+
+// LeaseId leases a free id under the KV prefix, starting from a random id
+// so that replicas starting together rarely contend for the same one.
+func (c *Client) LeaseId(prefix string, max int64, ttl time.Duration) (*Lease, error) {
+	session, _, err := c.Session().Create(&consul.SessionEntry{
+		Name: prefix,
+		TTL: ttl.String(),
+		LockDelay: ttl,
+		Behavior: consul.SessionBehaviorDelete,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	holder, _ := os.Hostname()
+	start := rand.New(rand.NewSource(time.Now().UnixNano())).Int63n(max)
+	for i := int64(0); i < max; i++ {
+		id := (start + i) % max
+		key := prefix + "/" + strconv.FormatInt(id, 10)
+		acquired, _, err := c.KV().Acquire(
+			&consul.KVPair{Key: key, Value: []byte(holder), Session: session}, nil)
+		if err != nil {
+			c.Session().Destroy(session, nil)
+			return nil, err
+		}
+		if acquired {
+			lease := &Lease{Id: id, c: c, key: key, session: session, held: 1, done: make(chan struct{})}
+			go lease.renew(ttl)
+			log.Info().Msgf("Leased %v", key)
+			return lease, nil
+		}
+	}
+	c.Session().Destroy(session, nil)
+	return nil, fmt.Errorf("registry: all %v ids under %v are leased", max, prefix)
+}
+
+func (l *Lease) renew(ttl time.Duration) {
+	err := l.c.Session().RenewPeriodic(ttl.String(), l.session, nil, l.done)
+	if err != nil {
+		atomic.StoreInt32(&l.held, 0)
+		log.Error().Msgf("Lost lease %v: %v", l.key, err)
+	}
+}
+
+// Held reports whether the lease is still ours. Once lost, it stays lost.
+func (l *Lease) Held() bool {
+	return atomic.LoadInt32(&l.held) == 1
+}
+
+// Release gives the id back and stops renewing the lease.
+func (l *Lease) Release() {
+	if atomic.SwapInt32(&l.held, 0) == 0 {
+		return
+	}
+	// closing done makes RenewPeriodic destroy the session, which deletes the key
+	close(l.done)
+}
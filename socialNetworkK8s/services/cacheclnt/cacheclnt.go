@@ -10,6 +10,7 @@ import (
 	"net/rpc"
 	"sync"
 	"sync/atomic"
+	"time"
 	"socialnetworkk8/dialer"
 	cached "socialnetworkk8/services/cached/proto"
 	"github.com/bradfitz/gomemcache/memcache"
@@ -83,6 +84,10 @@ func (c *CacheClnt) Set(ctx context.Context, item *memcache.Item) bool {
 }
 
 func (c *CacheClnt) Delete(ctx context.Context, key string) bool {
+	if c.ncs == 0 {
+		log.Printf("Error cacheclnt delete: no caches registered")
+		return false
+	}
 	n := c.key2shard(key)
 	req := cached.DeleteRequest{Key: key}
 	res, err := c.ccs[n][c.selector.Next()].Delete(ctx, &req)
@@ -92,6 +97,39 @@ func (c *CacheClnt) Delete(ctx context.Context, key string) bool {
 	return res.Ok
 }
 
+// TakeTokens takes n tokens from the token bucket at key; see the cached
+// service. Without enough tokens, it returns false and the time to wait.
+func (c *CacheClnt) TakeTokens(ctx context.Context, key string, rate, burst, n float64) (bool, time.Duration, error) {
+	if c.ncs == 0 {
+		return false, 0, fmt.Errorf("No caches registered")
+	}
+	shard := c.key2shard(key)
+	req := cached.TakeTokensRequest{Key: key, Rate: rate, Burst: burst, N: n}
+	res, err := c.ccs[shard][c.selector.Next()].TakeTokens(ctx, &req)
+	if err != nil {
+		log.Printf("Error cacheclnt take tokens: %v", err)
+		return false, 0, err
+	}
+	return res.Ok, time.Duration(res.Wait * float64(time.Second)), nil
+}
+
+// Claim stores val at key for ttl unless an earlier claim holds the key;
+// see the cached service. If it does, Claim returns false, the holder's
+// value and the time until the claim expires.
+func (c *CacheClnt) Claim(ctx context.Context, key string, val []byte, ttl time.Duration) (bool, []byte, time.Duration, error) {
+	if c.ncs == 0 {
+		return false, nil, 0, fmt.Errorf("No caches registered")
+	}
+	shard := c.key2shard(key)
+	req := cached.ClaimRequest{Key: key, Val: val, Ttl: ttl.Seconds()}
+	res, err := c.ccs[shard][c.selector.Next()].Claim(ctx, &req)
+	if err != nil {
+		log.Printf("Error cacheclnt claim: %v", err)
+		return false, nil, 0, err
+	}
+	return res.Ok, res.Val, time.Duration(res.Wait * float64(time.Second)), nil
+}
+
 type RegisterCacheRequest struct {
 	Addr string
 }
@@ -323,6 +323,268 @@ func (x *DeleteResult) GetOk() bool {
 	return false
 }
 
+// TakeTokens takes n tokens from the token bucket at key, which refills at
+// rate tokens per second up to burst tokens. The bucket is updated
+// atomically, so clients sharing a key share its limit.
+type TakeTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Rate  float64 `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	Burst float64 `protobuf:"fixed64,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	N     float64 `protobuf:"fixed64,4,opt,name=n,proto3" json:"n,omitempty"`
+}
+
+func (x *TakeTokensRequest) Reset() {
+	*x = TakeTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_cached_proto_cached_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeTokensRequest) ProtoMessage() {}
+
+func (x *TakeTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_cached_proto_cached_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeTokensRequest.ProtoReflect.Descriptor instead.
+func (*TakeTokensRequest) Descriptor() ([]byte, []int) {
+	return file_services_cached_proto_cached_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TakeTokensRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TakeTokensRequest) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TakeTokensRequest) GetBurst() float64 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *TakeTokensRequest) GetN() float64 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+// Without enough tokens, none are taken and wait says how many seconds
+// until there will be.
+type TakeTokensResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok   bool    `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Wait float64 `protobuf:"fixed64,2,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (x *TakeTokensResult) Reset() {
+	*x = TakeTokensResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_cached_proto_cached_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeTokensResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeTokensResult) ProtoMessage() {}
+
+func (x *TakeTokensResult) ProtoReflect() protoreflect.Message {
+	mi := &file_services_cached_proto_cached_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeTokensResult.ProtoReflect.Descriptor instead.
+func (*TakeTokensResult) Descriptor() ([]byte, []int) {
+	return file_services_cached_proto_cached_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TakeTokensResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *TakeTokensResult) GetWait() float64 {
+	if x != nil {
+		return x.Wait
+	}
+	return 0
+}
+
+// Claim stores val at key for ttl seconds unless the key is held by an
+// earlier claim that has not expired, atomically, so that of several
+// clients claiming a key only one gets it.
+type ClaimRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Val []byte  `protobuf:"bytes,2,opt,name=val,proto3" json:"val,omitempty"`
+	Ttl float64 `protobuf:"fixed64,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *ClaimRequest) Reset() {
+	*x = ClaimRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_cached_proto_cached_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimRequest) ProtoMessage() {}
+
+func (x *ClaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_cached_proto_cached_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimRequest.ProtoReflect.Descriptor instead.
+func (*ClaimRequest) Descriptor() ([]byte, []int) {
+	return file_services_cached_proto_cached_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ClaimRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ClaimRequest) GetVal() []byte {
+	if x != nil {
+		return x.Val
+	}
+	return nil
+}
+
+func (x *ClaimRequest) GetTtl() float64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+// If the key is held, val is what its holder stored and wait says how many
+// seconds until the claim expires.
+type ClaimResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok   bool    `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Val  []byte  `protobuf:"bytes,2,opt,name=val,proto3" json:"val,omitempty"`
+	Wait float64 `protobuf:"fixed64,3,opt,name=wait,proto3" json:"wait,omitempty"`
+}
+
+func (x *ClaimResult) Reset() {
+	*x = ClaimResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_cached_proto_cached_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimResult) ProtoMessage() {}
+
+func (x *ClaimResult) ProtoReflect() protoreflect.Message {
+	mi := &file_services_cached_proto_cached_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimResult.ProtoReflect.Descriptor instead.
+func (*ClaimResult) Descriptor() ([]byte, []int) {
+	return file_services_cached_proto_cached_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ClaimResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ClaimResult) GetVal() []byte {
+	if x != nil {
+		return x.Val
+	}
+	return nil
+}
+
+func (x *ClaimResult) GetWait() float64 {
+	if x != nil {
+		return x.Wait
+	}
+	return 0
+}
+
 var File_services_cached_proto_cached_proto protoreflect.FileDescriptor
 
 var file_services_cached_proto_cached_proto_rawDesc = []byte{
@@ -342,16 +604,40 @@ var file_services_cached_proto_cached_proto_rawDesc = []byte{
 	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x1e, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
 	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x32, 0x71, 0x0a, 0x06, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12,
-	0x1e, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x0b, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x1e, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x0b, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x27, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x19, 0x5a, 0x17, 0x2e, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0x5d, 0x0a, 0x11, 0x54, 0x61, 0x6b, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x01, 0x6e, 0x22, 0x36, 0x0a, 0x10, 0x54, 0x61, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x22, 0x44, 0x0a, 0x0c,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x76, 0x61, 0x6c,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x74,
+	0x74, 0x6c, 0x22, 0x43, 0x0a, 0x0b, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f,
+	0x6b, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x76, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x32, 0xcc, 0x01, 0x0a, 0x06, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x12, 0x1e, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x0b, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x0b, 0x2e, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0e, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x33, 0x0a, 0x0a, 0x54,
+	0x61, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x12, 0x2e, 0x54, 0x61, 0x6b, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x54, 0x61, 0x6b, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x24, 0x0a, 0x05, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x0d, 0x2e, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x19, 0x5a, 0x17, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -366,24 +652,32 @@ func file_services_cached_proto_cached_proto_rawDescGZIP() []byte {
 	return file_services_cached_proto_cached_proto_rawDescData
 }
 
-var file_services_cached_proto_cached_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_services_cached_proto_cached_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_services_cached_proto_cached_proto_goTypes = []interface{}{
-	(*GetRequest)(nil),    // 0: GetRequest
-	(*GetResult)(nil),     // 1: GetResult
-	(*SetRequest)(nil),    // 2: SetRequest
-	(*SetResult)(nil),     // 3: SetResult
-	(*DeleteRequest)(nil), // 4: DeleteRequest
-	(*DeleteResult)(nil),  // 5: DeleteResult
+	(*GetRequest)(nil),        // 0: GetRequest
+	(*GetResult)(nil),         // 1: GetResult
+	(*SetRequest)(nil),        // 2: SetRequest
+	(*SetResult)(nil),         // 3: SetResult
+	(*DeleteRequest)(nil),     // 4: DeleteRequest
+	(*DeleteResult)(nil),      // 5: DeleteResult
+	(*TakeTokensRequest)(nil), // 6: TakeTokensRequest
+	(*TakeTokensResult)(nil),  // 7: TakeTokensResult
+	(*ClaimRequest)(nil),      // 8: ClaimRequest
+	(*ClaimResult)(nil),       // 9: ClaimResult
 }
 var file_services_cached_proto_cached_proto_depIdxs = []int32{
 	0, // 0: Cached.Get:input_type -> GetRequest
 	2, // 1: Cached.Set:input_type -> SetRequest
 	4, // 2: Cached.Delete:input_type -> DeleteRequest
-	1, // 3: Cached.Get:output_type -> GetResult
-	3, // 4: Cached.Set:output_type -> SetResult
-	5, // 5: Cached.Delete:output_type -> DeleteResult
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
+	6, // 3: Cached.TakeTokens:input_type -> TakeTokensRequest
+	8, // 4: Cached.Claim:input_type -> ClaimRequest
+	1, // 5: Cached.Get:output_type -> GetResult
+	3, // 6: Cached.Set:output_type -> SetResult
+	5, // 7: Cached.Delete:output_type -> DeleteResult
+	7, // 8: Cached.TakeTokens:output_type -> TakeTokensResult
+	9, // 9: Cached.Claim:output_type -> ClaimResult
+	5, // [5:10] is the sub-list for method output_type
+	0, // [0:5] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -467,6 +761,54 @@ func file_services_cached_proto_cached_proto_init() {
 				return nil
 			}
 		}
+		file_services_cached_proto_cached_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_cached_proto_cached_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeTokensResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_cached_proto_cached_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_cached_proto_cached_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -474,7 +816,7 @@ func file_services_cached_proto_cached_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_cached_proto_cached_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Cached_Get_FullMethodName    = "/Cached/Get"
-	Cached_Set_FullMethodName    = "/Cached/Set"
-	Cached_Delete_FullMethodName = "/Cached/Delete"
+	Cached_Get_FullMethodName        = "/Cached/Get"
+	Cached_Set_FullMethodName        = "/Cached/Set"
+	Cached_Delete_FullMethodName     = "/Cached/Delete"
+	Cached_TakeTokens_FullMethodName = "/Cached/TakeTokens"
+	Cached_Claim_FullMethodName      = "/Cached/Claim"
 )
 
 // CachedClient is the client API for Cached service.
@@ -31,6 +33,8 @@ type CachedClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResult, error)
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResult, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResult, error)
+	TakeTokens(ctx context.Context, in *TakeTokensRequest, opts ...grpc.CallOption) (*TakeTokensResult, error)
+	Claim(ctx context.Context, in *ClaimRequest, opts ...grpc.CallOption) (*ClaimResult, error)
 }
 
 type cachedClient struct {
@@ -68,6 +72,24 @@ func (c *cachedClient) Delete(ctx context.Context, in *DeleteRequest, opts ...gr
 	return out, nil
 }
 
+func (c *cachedClient) TakeTokens(ctx context.Context, in *TakeTokensRequest, opts ...grpc.CallOption) (*TakeTokensResult, error) {
+	out := new(TakeTokensResult)
+	err := c.cc.Invoke(ctx, Cached_TakeTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cachedClient) Claim(ctx context.Context, in *ClaimRequest, opts ...grpc.CallOption) (*ClaimResult, error) {
+	out := new(ClaimResult)
+	err := c.cc.Invoke(ctx, Cached_Claim_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CachedServer is the server API for Cached service.
 // All implementations must embed UnimplementedCachedServer
 // for forward compatibility
@@ -75,6 +97,8 @@ type CachedServer interface {
 	Get(context.Context, *GetRequest) (*GetResult, error)
 	Set(context.Context, *SetRequest) (*SetResult, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResult, error)
+	TakeTokens(context.Context, *TakeTokensRequest) (*TakeTokensResult, error)
+	Claim(context.Context, *ClaimRequest) (*ClaimResult, error)
 	mustEmbedUnimplementedCachedServer()
 }
 
@@ -91,6 +115,12 @@ func (UnimplementedCachedServer) Set(context.Context, *SetRequest) (*SetResult,
 func (UnimplementedCachedServer) Delete(context.Context, *DeleteRequest) (*DeleteResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedCachedServer) TakeTokens(context.Context, *TakeTokensRequest) (*TakeTokensResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeTokens not implemented")
+}
+func (UnimplementedCachedServer) Claim(context.Context, *ClaimRequest) (*ClaimResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Claim not implemented")
+}
 func (UnimplementedCachedServer) mustEmbedUnimplementedCachedServer() {}
 
 // UnsafeCachedServer may be embedded to opt out of forward compatibility for this service.
@@ -158,6 +188,42 @@ func _Cached_Delete_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cached_TakeTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CachedServer).TakeTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cached_TakeTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CachedServer).TakeTokens(ctx, req.(*TakeTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cached_Claim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CachedServer).Claim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cached_Claim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CachedServer).Claim(ctx, req.(*ClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Cached_ServiceDesc is the grpc.ServiceDesc for Cached service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,6 +243,14 @@ var Cached_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Cached_Delete_Handler,
 		},
+		{
+			MethodName: "TakeTokens",
+			Handler:    _Cached_TakeTokens_Handler,
+		},
+		{
+			MethodName: "Claim",
+			Handler:    _Cached_Claim_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/cached/proto/cached.proto",
@@ -18,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	//	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	cacheclnt "socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/ratelimit"
 	"socialnetworkk8/registry"
 	pb "socialnetworkk8/services/cached/proto"
 	"socialnetworkk8/tls"
@@ -31,9 +32,11 @@ import (
 const (
 	NBIN = 1009
 	name = "srv-cached"
+	// how often entries that expire are looked for and dropped
+	SWEEP_PERIOD = time.Minute
 )
 
-var CACHE_SERVICES = []string{"user", "graph", "url", "media", "post", "timeline", "home"}
+var CACHE_SERVICES = []string{"user", "graph", "url", "media", "post", "timeline", "home", "dm", "reaction", "hashtag", "notification", "profile", "compose"}
 //var CACHE_SERVICES = []string{"user"}
 
 
@@ -47,6 +50,21 @@ func key2bin(key string) uint32 {
 type cache struct {
 	sync.Mutex
 	cache map[string][]byte
+	// expires holds, in unix nanoseconds, when the entries that are not
+	// plain values may be dropped: token buckets once full again, and claims
+	expires map[string]int64
+}
+
+// sweep drops the entries of the bin that have expired by now.
+func (c *cache) sweep(now int64) {
+	c.Lock()
+	defer c.Unlock()
+	for key, expires := range c.expires {
+		if expires <= now {
+			delete(c.cache, key)
+			delete(c.expires, key)
+		}
+	}
 }
 
 // Server implements the cached service
@@ -73,7 +91,9 @@ func (s *Server) Run() error {
 	s.bins = make([]cache, NBIN)
 	for i := 0; i < NBIN; i++ {
 		s.bins[i].cache = make(map[string][]byte)
+		s.bins[i].expires = make(map[string]int64)
 	}
+	go s.runSweep()
 
 	s.uuid = uuid.New().String()
 	opts := []grpc.ServerOption{
@@ -114,6 +134,16 @@ func (s *Server) Shutdown() {
 	s.Registry.Deregister(s.uuid)
 }
 
+func (s *Server) runSweep() {
+	for {
+		time.Sleep(SWEEP_PERIOD)
+		now := time.Now().UnixNano()
+		for i := range s.bins {
+			s.bins[i].sweep(now)
+		}
+	}
+}
+
 func (s *Server) registerWithServers() {
 	for _, svc := range CACHE_SERVICES {
 		for {
@@ -142,6 +172,7 @@ func (s *Server) Set(ctx context.Context, req *pb.SetRequest) (*pb.SetResult, er
 	defer s.bins[b].Unlock()
 
 	s.bins[b].cache[req.Key] = req.Val
+	delete(s.bins[b].expires, req.Key)
 
 	res := &pb.SetResult{}
 	res.Ok = true
@@ -179,9 +210,50 @@ func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteR
 		log2.Printf("Long lock acquisition get %v", time.Since(s2))
 	}
 	delete(s.bins[b].cache, req.Key)
+	delete(s.bins[b].expires, req.Key)
 	res.Ok = true
 	if time.Since(st) > 2*time.Millisecond {
 		log2.Printf("Long cache get %v", time.Since(st))
 	}
 	return res, nil
 }
+
+func (s *Server) TakeTokens(ctx context.Context, req *pb.TakeTokensRequest) (*pb.TakeTokensResult, error) {
+	res := &pb.TakeTokensResult{}
+	b := key2bin(req.Key)
+	s.bins[b].Lock()
+	defer s.bins[b].Unlock()
+	bucket := ratelimit.DecodeBucket(s.bins[b].cache[req.Key])
+	bucket, ok, wait := ratelimit.Take(bucket, time.Now().UnixNano(), req.Rate, req.Burst, req.N)
+	s.bins[b].cache[req.Key] = bucket.Encode()
+	// a full bucket is the same as a missing one, so it can go once refilled
+	if req.Rate > 0 {
+		s.bins[b].expires[req.Key] = bucket.Updated +
+			int64((req.Burst-bucket.Tokens)/req.Rate*float64(time.Second))
+	}
+	res.Ok = ok
+	res.Wait = wait.Seconds()
+	return res, nil
+}
+
+func (s *Server) Claim(ctx context.Context, req *pb.ClaimRequest) (*pb.ClaimResult, error) {
+	res := &pb.ClaimResult{}
+	b := key2bin(req.Key)
+	s.bins[b].Lock()
+	defer s.bins[b].Unlock()
+	now := time.Now().UnixNano()
+	if val, ok := s.bins[b].cache[req.Key]; ok {
+		expires, expiring := s.bins[b].expires[req.Key]
+		if !expiring || expires > now {
+			res.Val = val
+			if expiring {
+				res.Wait = time.Duration(expires - now).Seconds()
+			}
+			return res, nil
+		}
+	}
+	s.bins[b].cache[req.Key] = req.Val
+	s.bins[b].expires[req.Key] = now + int64(req.Ttl*float64(time.Second))
+	res.Ok = true
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package compose
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/net/context"
+	"socialnetworkk8/services/compose/proto"
+)
+
+const (
+	IDEMPOTENCY_TTL = 24 * time.Hour
+	IDEMPOTENCY_MAX_KEY = 128
+	// a request still pending after this long is taken to have died, and a
+	// retry may take it over
+	IDEMPOTENCY_PENDING_TIMEOUT = time.Minute
+	REQUEST_PENDING = "pending"
+	REQUEST_DONE = "done"
+)
+
+// ComposeRequestBson records a compose request made with an idempotency key.
+// Mongo drops records IDEMPOTENCY_TTL after their creation time.
+type ComposeRequestBson struct {
+	Key         string    `bson:"key"`
+	Fingerprint string    `bson:"fingerprint"`
+	Status      string    `bson:"status"`
+	Postid      int64     `bson:"postid"`
+	Ok          string    `bson:"ok"`
+	Createdat   time.Time `bson:"createdat"`
+	Claimedat   time.Time `bson:"claimedat"`
+}
+
+// fingerprint identifies what a request asks for, so that a key reused for
+// another request is caught rather than answered with the wrong result.
+func fingerprint(req *proto.ComposePostRequest) string {
+	content, _ := json.Marshal([]interface{}{
+		req.Username, req.Text, req.Posttype, req.Mediaids, req.Parentid, req.Rootid})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// composeOnce composes a post at most once per user and idempotency key.
+// The post id is fixed when the key is first claimed, so a retry taking
+// over a dead or failed request composes under the same id.
+func (csrv *ComposeSrv) composeOnce(
+		ctx context.Context, req *proto.ComposePostRequest) (*proto.ComposePostResponse, error) {
+	res := &proto.ComposePostResponse{Ok: "No"}
+	if len(req.Idempotencykey) > IDEMPOTENCY_MAX_KEY {
+		res.Ok += " Idempotency key longer than " + strconv.Itoa(IDEMPOTENCY_MAX_KEY) + " bytes."
+		return res, nil
+	}
+	key := strconv.FormatInt(req.Userid, 10) + ":" + req.Idempotencykey
+	postid, err := csrv.getNextPostId()
+	if err != nil {
+		return res, err
+	}
+	// mongo keeps milliseconds; claimedat must compare equal after a round trip
+	now := time.Now().Truncate(time.Millisecond)
+	record := &ComposeRequestBson{
+		Key: key, Fingerprint: fingerprint(req), Status: REQUEST_PENDING,
+		Postid: postid, Createdat: now, Claimedat: now}
+	_, err = csrv.mongoReqCo.InsertOne(context.TODO(), record)
+	if mongo.IsDuplicateKeyError(err) {
+		claimed, replay, err := csrv.claimRequest(key, record.Fingerprint)
+		if err != nil || replay != nil {
+			return replay, err
+		}
+		record = claimed
+	} else if err != nil {
+		return nil, err
+	}
+	res, err = csrv.composePost(ctx, req, record.Postid)
+	if err != nil || res.Ok != COMPOSE_QUERY_OK {
+		// failures may be transient, so only successes are replayed. The
+		// record stays pending with its post id, released so that the
+		// client's retry takes it over at once and composes under that id.
+		if _, relErr := csrv.mongoReqCo.UpdateOne(
+				context.TODO(), &bson.M{"key": key, "claimedat": record.Claimedat},
+				&bson.M{"$set": bson.M{"claimedat": time.Unix(0, 0)}}); relErr != nil {
+			log.Error().Msgf("Cannot release idempotency key %v: %v", key, relErr)
+		}
+		return res, err
+	}
+	_, err = csrv.mongoReqCo.UpdateOne(
+		context.TODO(), &bson.M{"key": key, "claimedat": record.Claimedat},
+		&bson.M{"$set": bson.M{"status": REQUEST_DONE, "ok": res.Ok, "postid": res.Postid}})
+	if err != nil {
+		log.Error().Msgf("Cannot record result of idempotency key %v: %v", key, err)
+	}
+	return res, nil
+}
+
+// claimRequest handles a key that was used before. It returns the earlier
+// result to replay if there is one, or the record if this request took over
+// a pending one that timed out or was released after a failure.
+func (csrv *ComposeSrv) claimRequest(key, fp string) (
+		*ComposeRequestBson, *proto.ComposePostResponse, error) {
+	res := &proto.ComposePostResponse{Ok: "No"}
+	record := &ComposeRequestBson{}
+	err := csrv.mongoReqCo.FindOne(context.TODO(), &bson.M{"key": key}).Decode(record)
+	if err == mongo.ErrNoDocuments {
+		// it just expired or was released; asking the client to retry is simplest
+		res.Ok += " Request with this idempotency key is in progress."
+		return nil, res, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	if record.Fingerprint != fp {
+		res.Ok += " Idempotency key was used for a different request."
+		return nil, res, nil
+	}
+	if record.Status == REQUEST_DONE {
+		return nil, &proto.ComposePostResponse{Ok: record.Ok, Postid: record.Postid, Replayed: true}, nil
+	}
+	if time.Since(record.Claimedat) < IDEMPOTENCY_PENDING_TIMEOUT {
+		res.Ok += " Request with this idempotency key is in progress."
+		return nil, res, nil
+	}
+	// only one of several racing retries gets to take over
+	now := time.Now().Truncate(time.Millisecond)
+	upd, err := csrv.mongoReqCo.UpdateOne(
+		context.TODO(), &bson.M{"key": key, "status": REQUEST_PENDING, "claimedat": record.Claimedat},
+		&bson.M{"$set": bson.M{"claimedat": now}})
+	if err != nil {
+		return nil, nil, err
+	}
+	if upd.ModifiedCount == 0 {
+		res.Ok += " Request with this idempotency key is in progress."
+		return nil, res, nil
+	}
+	log.Info().Msgf("Taking over stale request with idempotency key %v", key)
+	record.Claimedat = now
+	return record, nil, nil
+}
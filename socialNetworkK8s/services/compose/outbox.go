@@ -0,0 +1,526 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+	"socialnetworkk8/services/compose/proto"
+	"socialnetworkk8/services/post"
+	postpb "socialnetworkk8/services/post/proto"
+	"socialnetworkk8/services/timeline"
+	tlpb "socialnetworkk8/services/timeline/proto"
+	"socialnetworkk8/services/home"
+	homepb "socialnetworkk8/services/home/proto"
+	"socialnetworkk8/services/hashtag"
+	hashtagpb "socialnetworkk8/services/hashtag/proto"
+)
+
+const (
+	OUTBOX_MAX_ATTEMPTS = 8
+	OUTBOX_MIN_BACKOFF = time.Second
+	OUTBOX_MAX_BACKOFF = 5 * time.Minute
+	// an entry claimed this long ago is taken to have been dropped by a
+	// replica that died, and is claimed again
+	OUTBOX_LEASE = time.Minute
+	OUTBOX_POLL_PERIOD = 5 * time.Second
+	// finished entries are kept this long so that reconciliation can check them
+	OUTBOX_TTL = 7 * 24 * time.Hour
+	RECONCILE_PERIOD = time.Minute
+	// completed posts are checked once they have had this long to settle
+	RECONCILE_DELAY = time.Minute
+	OUTBOX_PENDING = "pending"
+	OUTBOX_DONE = "done"
+	OUTBOX_COMPENSATING = "compensating"
+	OUTBOX_COMPENSATED = "compensated"
+	STEP_POST = "post"
+	STEP_TIMELINE = "timeline"
+	STEP_HOME = "home"
+	STEP_HASHTAG = "hashtag"
+)
+
+var composeSteps = []string{STEP_POST, STEP_TIMELINE, STEP_HOME, STEP_HASHTAG}
+
+// OutboxBson is the durable record of composing one post. It is written
+// before any step runs, and each step is marked done as it succeeds, so a
+// compose interrupted at any point is carried on by the outbox worker.
+// Nextattempt doubles as the lease of whoever works on the entry: updates
+// only apply while it is unchanged. Failed records the steps that failed
+// at least once, the only ones reconciliation repairs. Mongo drops entries
+// OUTBOX_TTL after they finish.
+type OutboxBson struct {
+	Postid        int64           `bson:"postid"`
+	Post          []byte          `bson:"post"`
+	Parentcreator int64           `bson:"parentcreator"`
+	State         string          `bson:"state"`
+	Done          map[string]bool `bson:"done"`
+	Failed        map[string]bool `bson:"failed"`
+	Attempts      int32           `bson:"attempts"`
+	Nextattempt   time.Time       `bson:"nextattempt"`
+	Lasterror     string          `bson:"lasterror"`
+	Audited       bool            `bson:"audited"`
+	Createdat     time.Time       `bson:"createdat"`
+	Finishedat    *time.Time      `bson:"finishedat,omitempty"`
+}
+
+func (entry *OutboxBson) getPost() (*postpb.Post, error) {
+	p := &postpb.Post{}
+	if err := json.Unmarshal(entry.Post, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// getParent returns what notifyPost needs to know about the parent post.
+func (entry *OutboxBson) getParent(p *postpb.Post) *postpb.Post {
+	if p.Parentid == 0 {
+		return nil
+	}
+	return &postpb.Post{Postid: p.Parentid, Creator: entry.Parentcreator}
+}
+
+// outboxNow is the current time as mongo keeps it, so that leases compare
+// equal after a round trip.
+func outboxNow() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
+
+func outboxBackoff(attempts int32) time.Duration {
+	backoff := OUTBOX_MIN_BACKOFF
+	for i := int32(1); i < attempts && backoff < OUTBOX_MAX_BACKOFF; i++ {
+		backoff *= 2
+	}
+	if backoff > OUTBOX_MAX_BACKOFF {
+		backoff = OUTBOX_MAX_BACKOFF
+	}
+	return backoff
+}
+
+// composeSaga records newPost in the outbox and runs its steps. Once the
+// entry is stored the post is accepted: steps failing here are retried by
+// the outbox worker, and the reply says the post is still pending.
+func (csrv *ComposeSrv) composeSaga(ctx context.Context, newPost *postpb.Post,
+		parent *postpb.Post) (*proto.ComposePostResponse, error) {
+	encodedPost, err := json.Marshal(newPost)
+	if err != nil {
+		return nil, err
+	}
+	now := outboxNow()
+	entry := &OutboxBson{
+		Postid: newPost.Postid,
+		Post: encodedPost,
+		State: OUTBOX_PENDING,
+		Done: map[string]bool{STEP_HASHTAG: len(newPost.Hashtags) == 0},
+		// keeps the worker off the entry while this request works on it
+		Nextattempt: now.Add(OUTBOX_LEASE),
+		Createdat: now,
+	}
+	if parent != nil {
+		entry.Parentcreator = parent.Creator
+	}
+	_, err = csrv.mongoOutboxCo.InsertOne(context.TODO(), entry)
+	if mongo.IsDuplicateKeyError(err) {
+		// a retry under the same post id; the entry already carries the post
+		return csrv.outboxStatus(newPost.Postid)
+	} else if err != nil {
+		return nil, err
+	}
+	state, err := csrv.advance(ctx, entry, newPost)
+	if err != nil {
+		return nil, err
+	}
+	res := &proto.ComposePostResponse{Ok: COMPOSE_QUERY_OK, Postid: newPost.Postid}
+	res.Pending = state != OUTBOX_DONE
+	return res, nil
+}
+
+// outboxStatus reports on the post with an outbox entry as ComposePost would.
+func (csrv *ComposeSrv) outboxStatus(postid int64) (*proto.ComposePostResponse, error) {
+	res := &proto.ComposePostResponse{Ok: "No"}
+	entry := &OutboxBson{}
+	err := csrv.mongoOutboxCo.FindOne(context.TODO(), &bson.M{"postid": postid}).Decode(entry)
+	if err != nil {
+		return nil, err
+	}
+	switch entry.State {
+	case OUTBOX_DONE, OUTBOX_PENDING:
+		res.Ok = COMPOSE_QUERY_OK
+		res.Postid = postid
+		res.Pending = entry.State == OUTBOX_PENDING
+	default:
+		res.Ok += " Compose Error: " + entry.Lasterror
+	}
+	return res, nil
+}
+
+// advance runs the steps of entry that are not done yet, then records
+// the outcome and returns the new state. An entry that runs out of attempts
+// is handed over to compensation.
+func (csrv *ComposeSrv) advance(
+		ctx context.Context, entry *OutboxBson, p *postpb.Post) (string, error) {
+	var steps []string
+	for _, step := range composeSteps {
+		if !entry.Done[step] {
+			steps = append(steps, step)
+		}
+	}
+	errs := csrv.runSteps(ctx, p, steps)
+	set := bson.M{}
+	var lastErr error
+	for idx, step := range steps {
+		if errs[idx] == nil {
+			entry.Done[step] = true
+			set["done." + step] = true
+		} else {
+			lastErr = fmt.Errorf("%v: %v", step, errs[idx])
+			set["failed." + step] = true
+		}
+	}
+	now := outboxNow()
+	if lastErr == nil {
+		set["state"] = OUTBOX_DONE
+		set["finishedat"] = now
+	} else {
+		entry.Attempts++
+		set["attempts"] = entry.Attempts
+		set["lasterror"] = lastErr.Error()
+		set["nextattempt"] = now.Add(outboxBackoff(entry.Attempts))
+		if entry.Attempts >= OUTBOX_MAX_ATTEMPTS {
+			log.Error().Msgf("Giving up composing post %v: %v", entry.Postid, lastErr)
+			set["state"] = OUTBOX_COMPENSATING
+			set["nextattempt"] = now
+		} else {
+			log.Warn().Msgf("Composing post %v failed, retrying: %v", entry.Postid, lastErr)
+		}
+	}
+	state, err := csrv.updateEntry(entry, set)
+	if err != nil || state != OUTBOX_DONE {
+		return state, err
+	}
+	csrv.notifyPost(ctx, p, entry.getParent(p))
+	return state, nil
+}
+
+// compensate takes down whatever the steps of entry may have written. A
+// failed step may still have been applied, so every step is undone.
+func (csrv *ComposeSrv) compensate(ctx context.Context, entry *OutboxBson, p *postpb.Post) (string, error) {
+	var wg sync.WaitGroup
+	errs := make([]error, len(composeSteps))
+	for idx, step := range composeSteps {
+		wg.Add(1)
+		go func(idx int, step string) {
+			defer wg.Done()
+			errs[idx] = csrv.undoStep(ctx, step, p)
+		}(idx, step)
+	}
+	wg.Wait()
+	var lastErr error
+	for idx, err := range errs {
+		if err != nil {
+			lastErr = fmt.Errorf("undo %v: %v", composeSteps[idx], err)
+		}
+	}
+	set := bson.M{}
+	now := outboxNow()
+	if lastErr == nil {
+		log.Info().Msgf("Took down post %v", entry.Postid)
+		set["state"] = OUTBOX_COMPENSATED
+		set["finishedat"] = now
+	} else {
+		// compensation has to finish, so it is retried for as long as it takes
+		entry.Attempts++
+		set["attempts"] = entry.Attempts
+		set["nextattempt"] = now.Add(outboxBackoff(entry.Attempts - OUTBOX_MAX_ATTEMPTS))
+		log.Error().Msgf("Cannot take down post %v, retrying: %v", entry.Postid, lastErr)
+	}
+	return csrv.updateEntry(entry, set)
+}
+
+// updateEntry applies set to entry as long as the entry's lease is still
+// held, and returns the state the entry ends up in.
+func (csrv *ComposeSrv) updateEntry(entry *OutboxBson, set bson.M) (string, error) {
+	upd, err := csrv.mongoOutboxCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": entry.Postid, "nextattempt": entry.Nextattempt},
+		&bson.M{"$set": set})
+	if err != nil {
+		return entry.State, err
+	}
+	if upd.MatchedCount == 0 {
+		log.Warn().Msgf("Lost outbox lease of post %v", entry.Postid)
+		return entry.State, nil
+	}
+	if state, ok := set["state"]; ok {
+		entry.State = state.(string)
+	}
+	if next, ok := set["nextattempt"]; ok {
+		entry.Nextattempt = next.(time.Time)
+	}
+	return entry.State, nil
+}
+
+// runSteps runs steps concurrently and returns their errors in order.
+func (csrv *ComposeSrv) runSteps(ctx context.Context, p *postpb.Post, steps []string) []error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(steps))
+	for idx, step := range steps {
+		wg.Add(1)
+		go func(idx int, step string) {
+			defer wg.Done()
+			errs[idx] = csrv.doStep(ctx, step, p)
+		}(idx, step)
+	}
+	wg.Wait()
+	return errs
+}
+
+// doStep writes p to the place the step is named after. Every step may be
+// repeated without writing p twice.
+func (csrv *ComposeSrv) doStep(ctx context.Context, step string, p *postpb.Post) error {
+	var ok, expected string
+	switch step {
+	case STEP_POST:
+		postRes, err := csrv.postc.StorePost(ctx, &postpb.StorePostRequest{Post: p})
+		if err != nil {
+			return err
+		}
+		ok, expected = postRes.Ok, post.POST_QUERY_OK
+	case STEP_TIMELINE:
+		tlRes, err := csrv.tlc.WriteTimeline(ctx, &tlpb.WriteTimelineRequest{
+			Userid: p.Creator, Postid: p.Postid, Timestamp: p.Timestamp})
+		if err != nil {
+			return err
+		}
+		ok, expected = tlRes.Ok, timeline.TIMELINE_QUERY_OK
+	case STEP_HOME:
+		homeRes, err := csrv.homec.WriteHomeTimeline(ctx, &homepb.WriteHomeTimelineRequest{
+			Usermentionids: p.Usermentions,
+			Userid: p.Creator,
+			Postid: p.Postid,
+			Timestamp: p.Timestamp})
+		if err != nil {
+			return err
+		}
+		ok, expected = homeRes.Ok, home.HOME_QUERY_OK
+	case STEP_HASHTAG:
+		if len(p.Hashtags) == 0 {
+			return nil
+		}
+		hashtagRes, err := csrv.hashtagc.WriteHashtags(ctx, &hashtagpb.WriteHashtagsRequest{
+			Hashtags: p.Hashtags, Postid: p.Postid, Timestamp: p.Timestamp})
+		if err != nil {
+			return err
+		}
+		ok, expected = hashtagRes.Ok, hashtag.HASHTAG_QUERY_OK
+	}
+	if ok != expected {
+		return fmt.Errorf("%v", ok)
+	}
+	return nil
+}
+
+// undoStep takes p off the place the step is named after. Finding nothing
+// to take off counts as success.
+func (csrv *ComposeSrv) undoStep(ctx context.Context, step string, p *postpb.Post) error {
+	switch step {
+	case STEP_POST:
+		postRes, err := csrv.postc.DeletePost(ctx, &postpb.DeletePostRequest{Postid: p.Postid, Userid: p.Creator})
+		if err != nil {
+			return err
+		}
+		if postRes.Ok != post.POST_QUERY_OK {
+			log.Debug().Msgf("Deleting post %v: %v", p.Postid, postRes.Ok)
+		}
+	case STEP_TIMELINE:
+		tlRes, err := csrv.tlc.RemoveTimeline(ctx, &tlpb.RemoveTimelineRequest{
+			Userid: p.Creator, Postid: p.Postid})
+		if err != nil {
+			return err
+		}
+		if tlRes.Ok != timeline.TIMELINE_QUERY_OK {
+			log.Debug().Msgf("Removing post %v from timeline: %v", p.Postid, tlRes.Ok)
+		}
+	case STEP_HOME:
+		homeRes, err := csrv.homec.RemoveHomeTimeline(ctx, &homepb.RemoveHomeTimelineRequest{
+			Userid: p.Creator, Postid: p.Postid, Usermentionids: p.Usermentions})
+		if err != nil {
+			return err
+		}
+		if homeRes.Ok != home.HOME_QUERY_OK {
+			return fmt.Errorf("%v", homeRes.Ok)
+		}
+	case STEP_HASHTAG:
+		if len(p.Hashtags) == 0 {
+			return nil
+		}
+		hashtagRes, err := csrv.hashtagc.RemoveHashtags(ctx, &hashtagpb.RemoveHashtagsRequest{
+			Hashtags: p.Hashtags, Postid: p.Postid})
+		if err != nil {
+			return err
+		}
+		if hashtagRes.Ok != hashtag.HASHTAG_QUERY_OK {
+			return fmt.Errorf("%v", hashtagRes.Ok)
+		}
+	}
+	return nil
+}
+
+// runOutbox claims due entries and moves them on, one at a time, until
+// none is due, then waits for the next poll.
+func (csrv *ComposeSrv) runOutbox() {
+	for {
+		for {
+			entry, err := csrv.claimEntry()
+			if err != nil {
+				log.Error().Msgf("Cannot claim outbox entry: %v", err)
+				break
+			}
+			if entry == nil {
+				break
+			}
+			csrv.processEntry(entry)
+		}
+		time.Sleep(OUTBOX_POLL_PERIOD)
+	}
+}
+
+// claimEntry leases the next due entry, or returns nil if none is due.
+func (csrv *ComposeSrv) claimEntry() (*OutboxBson, error) {
+	now := outboxNow()
+	entry := &OutboxBson{}
+	err := csrv.mongoOutboxCo.FindOneAndUpdate(context.TODO(),
+		&bson.M{
+			"state": bson.M{"$in": []string{OUTBOX_PENDING, OUTBOX_COMPENSATING}},
+			"nextattempt": bson.M{"$lte": now}},
+		&bson.M{"$set": bson.M{"nextattempt": now.Add(OUTBOX_LEASE)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "nextattempt", Value: 1}}).
+			SetReturnDocument(options.After)).Decode(entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (csrv *ComposeSrv) processEntry(entry *OutboxBson) {
+	p, err := entry.getPost()
+	if err != nil {
+		log.Error().Msgf("Cannot decode post of outbox entry %v: %v", entry.Postid, err)
+		return
+	}
+	ctx := context.Background()
+	if entry.State == OUTBOX_COMPENSATING {
+		_, err = csrv.compensate(ctx, entry, p)
+	} else {
+		_, err = csrv.advance(ctx, entry, p)
+	}
+	if err != nil {
+		log.Error().Msgf("Cannot update outbox entry %v: %v", entry.Postid, err)
+	}
+}
+
+// runReconcile periodically checks posts composed a while ago against what
+// their steps wrote, to catch what the outbox cannot see: posts lost from
+// storage, which leave orphaned timeline entries behind, and writes lost
+// by steps that failed and were retried.
+func (csrv *ComposeSrv) runReconcile() {
+	for {
+		time.Sleep(RECONCILE_PERIOD)
+		if n, err := csrv.reconcile(context.Background()); err != nil {
+			log.Error().Msgf("Reconciliation failed: %v", err)
+		} else if n > 0 {
+			log.Info().Msgf("Reconciled %v posts", n)
+		}
+	}
+}
+
+// reconcile checks completed entries not checked before, one claimed entry
+// at a time like the outbox worker, until none is left, and returns how
+// many it checked.
+func (csrv *ComposeSrv) reconcile(ctx context.Context) (int, error) {
+	n := 0
+	for {
+		entry, err := csrv.claimAudit()
+		if err != nil || entry == nil {
+			return n, err
+		}
+		if err := csrv.auditEntry(ctx, entry); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// claimAudit leases the next completed entry due for a check, or returns
+// nil if none is due.
+func (csrv *ComposeSrv) claimAudit() (*OutboxBson, error) {
+	now := outboxNow()
+	entry := &OutboxBson{}
+	err := csrv.mongoOutboxCo.FindOneAndUpdate(context.TODO(),
+		&bson.M{
+			"state": OUTBOX_DONE,
+			"audited": false,
+			"finishedat": bson.M{"$lte": now.Add(-RECONCILE_DELAY)},
+			"nextattempt": bson.M{"$lte": now}},
+		&bson.M{"$set": bson.M{"nextattempt": now.Add(OUTBOX_LEASE)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "finishedat", Value: 1}}).
+			SetReturnDocument(options.After)).Decode(entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// auditEntry checks one entry. A post that went missing is taken off
+// timelines and hashtags. A post that is still there is written again by
+// the steps that failed at some point, which only adds what those lost;
+// steps that succeeded at once are not run again, so home timelines do not
+// get old posts of users their owners followed since. Posts deleted by
+// their author are left alone.
+func (csrv *ComposeSrv) auditEntry(ctx context.Context, entry *OutboxBson) error {
+	readRes, err := csrv.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{entry.Postid}})
+	if err != nil {
+		return err
+	}
+	set := bson.M{"audited": true}
+	if readRes.Ok != post.POST_QUERY_OK {
+		// the post is gone and not merely deleted; hand it to compensation
+		// so that its timeline entries follow it
+		log.Warn().Msgf("Post %v is missing, removing its timeline entries", entry.Postid)
+		set["state"] = OUTBOX_COMPENSATING
+		set["lasterror"] = readRes.Ok
+		set["nextattempt"] = outboxNow()
+	} else if len(readRes.Posts) > 0 {
+		var steps []string
+		for _, step := range []string{STEP_TIMELINE, STEP_HOME, STEP_HASHTAG} {
+			if entry.Failed[step] {
+				steps = append(steps, step)
+			}
+		}
+		// the stored post may have been edited since; repair with it
+		errs := csrv.runSteps(ctx, readRes.Posts[0], steps)
+		for idx, err := range errs {
+			if err != nil {
+				log.Error().Msgf("Cannot repair %v of post %v: %v", steps[idx], entry.Postid, err)
+				// leave it for the next round, once the lease runs out
+				delete(set, "audited")
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	_, err = csrv.updateEntry(entry, set)
+	return err
+}
@@ -37,6 +37,11 @@ type ComposePostRequest struct {
 	Text     string           `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
 	Posttype proto1.POST_TYPE `protobuf:"varint,4,opt,name=posttype,proto3,enum=post.POST_TYPE" json:"posttype,omitempty"`
 	Mediaids []int64          `protobuf:"varint,5,rep,packed,name=mediaids,proto3" json:"mediaids,omitempty"`
+	Parentid int64            `protobuf:"varint,6,opt,name=parentid,proto3" json:"parentid,omitempty"`
+	Rootid   int64            `protobuf:"varint,7,opt,name=rootid,proto3" json:"rootid,omitempty"`
+	// retries of a request with the same key, made by the same user within
+	// a day, return the first result instead of composing again
+	Idempotencykey string `protobuf:"bytes,8,opt,name=idempotencykey,proto3" json:"idempotencykey,omitempty"`
 }
 
 func (x *ComposePostRequest) Reset() {
@@ -106,12 +111,44 @@ func (x *ComposePostRequest) GetMediaids() []int64 {
 	return nil
 }
 
+func (x *ComposePostRequest) GetParentid() int64 {
+	if x != nil {
+		return x.Parentid
+	}
+	return 0
+}
+
+func (x *ComposePostRequest) GetRootid() int64 {
+	if x != nil {
+		return x.Rootid
+	}
+	return 0
+}
+
+func (x *ComposePostRequest) GetIdempotencykey() string {
+	if x != nil {
+		return x.Idempotencykey
+	}
+	return ""
+}
+
+// postid is set once a post is composed. replayed marks results of an
+// earlier request with the same idempotency key. pending marks a post that
+// compose has accepted but not yet stored everywhere; compose keeps retrying
+// until it is, or takes the post down again if it never gets there.
+// ratelimited marks a post refused for coming too fast, or for repeating a
+// recent post; it may be sent again after retryafter seconds.
 type ComposePostResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Ok          string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Postid      int64  `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+	Replayed    bool   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Pending     bool   `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`
+	Ratelimited bool   `protobuf:"varint,5,opt,name=ratelimited,proto3" json:"ratelimited,omitempty"`
+	Retryafter  int64  `protobuf:"varint,6,opt,name=retryafter,proto3" json:"retryafter,omitempty"`
 }
 
 func (x *ComposePostResponse) Reset() {
@@ -153,6 +190,159 @@ func (x *ComposePostResponse) GetOk() string {
 	return ""
 }
 
+func (x *ComposePostResponse) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *ComposePostResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+func (x *ComposePostResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *ComposePostResponse) GetRatelimited() bool {
+	if x != nil {
+		return x.Ratelimited
+	}
+	return false
+}
+
+func (x *ComposePostResponse) GetRetryafter() int64 {
+	if x != nil {
+		return x.Retryafter
+	}
+	return 0
+}
+
+type DeletePostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Postid int64 `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+}
+
+func (x *DeletePostRequest) Reset() {
+	*x = DeletePostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_compose_proto_compose_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePostRequest) ProtoMessage() {}
+
+func (x *DeletePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_compose_proto_compose_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePostRequest.ProtoReflect.Descriptor instead.
+func (*DeletePostRequest) Descriptor() ([]byte, []int) {
+	return file_services_compose_proto_compose_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeletePostRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *DeletePostRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+type EditPostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid int64  `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Postid int64  `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+	Text   string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *EditPostRequest) Reset() {
+	*x = EditPostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_compose_proto_compose_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditPostRequest) ProtoMessage() {}
+
+func (x *EditPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_compose_proto_compose_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditPostRequest.ProtoReflect.Descriptor instead.
+func (*EditPostRequest) Descriptor() ([]byte, []int) {
+	return file_services_compose_proto_compose_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EditPostRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *EditPostRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *EditPostRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
 var File_services_compose_proto_compose_proto protoreflect.FileDescriptor
 
 var file_services_compose_proto_compose_proto_rawDesc = []byte{
@@ -161,7 +351,7 @@ var file_services_compose_proto_compose_proto_rawDesc = []byte{
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x1a,
 	0x1e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xa5, 0x01, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
+	0x81, 0x02, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x1a,
 	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
@@ -171,17 +361,50 @@ var file_services_compose_proto_compose_proto_rawDesc = []byte{
 	0x32, 0x0f, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x50, 0x4f, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50,
 	0x45, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
 	0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52, 0x08, 0x6d,
-	0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x22, 0x25, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f,
-	0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x32, 0x53,
-	0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x43, 0x6f, 0x6d,
-	0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
-	0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2e,
-	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x1a, 0x5a, 0x18, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x69, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x6b, 0x65, 0x79, 0x22, 0xb5, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73,
+	0x74, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x61, 0x74,
+	0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x66, 0x74, 0x65, 0x72, 0x22, 0x43, 0x0a, 0x11, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64,
+	0x22, 0x55, 0x0a, 0x0f, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73,
+	0x74, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x32, 0xdf, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f,
+	0x73, 0x74, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73,
+	0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a,
+	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73,
+	0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x08, 0x45, 0x64, 0x69, 0x74, 0x50, 0x6f, 0x73,
+	0x74, 0x12, 0x18, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2e, 0x45, 0x64, 0x69, 0x74,
+	0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1a, 0x5a, 0x18, 0x2e, 0x2f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -196,18 +419,24 @@ func file_services_compose_proto_compose_proto_rawDescGZIP() []byte {
 	return file_services_compose_proto_compose_proto_rawDescData
 }
 
-var file_services_compose_proto_compose_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_services_compose_proto_compose_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_services_compose_proto_compose_proto_goTypes = []interface{}{
 	(*ComposePostRequest)(nil),  // 0: compose.ComposePostRequest
 	(*ComposePostResponse)(nil), // 1: compose.ComposePostResponse
-	(proto1.POST_TYPE)(0),       // 2: post.POST_TYPE
+	(*DeletePostRequest)(nil),   // 2: compose.DeletePostRequest
+	(*EditPostRequest)(nil),     // 3: compose.EditPostRequest
+	(proto1.POST_TYPE)(0),       // 4: post.POST_TYPE
 }
 var file_services_compose_proto_compose_proto_depIdxs = []int32{
-	2, // 0: compose.ComposePostRequest.posttype:type_name -> post.POST_TYPE
+	4, // 0: compose.ComposePostRequest.posttype:type_name -> post.POST_TYPE
 	0, // 1: compose.Compose.ComposePost:input_type -> compose.ComposePostRequest
-	1, // 2: compose.Compose.ComposePost:output_type -> compose.ComposePostResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
+	2, // 2: compose.Compose.DeletePost:input_type -> compose.DeletePostRequest
+	3, // 3: compose.Compose.EditPost:input_type -> compose.EditPostRequest
+	1, // 4: compose.Compose.ComposePost:output_type -> compose.ComposePostResponse
+	1, // 5: compose.Compose.DeletePost:output_type -> compose.ComposePostResponse
+	1, // 6: compose.Compose.EditPost:output_type -> compose.ComposePostResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -243,6 +472,30 @@ func file_services_compose_proto_compose_proto_init() {
 				return nil
 			}
 		}
+		file_services_compose_proto_compose_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_compose_proto_compose_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditPostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -250,7 +503,7 @@ func file_services_compose_proto_compose_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_compose_proto_compose_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,8 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	Compose_ComposePost_FullMethodName = "/compose.Compose/ComposePost"
+	Compose_DeletePost_FullMethodName  = "/compose.Compose/DeletePost"
+	Compose_EditPost_FullMethodName    = "/compose.Compose/EditPost"
 )
 
 // ComposeClient is the client API for Compose service.
@@ -27,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ComposeClient interface {
 	ComposePost(ctx context.Context, in *ComposePostRequest, opts ...grpc.CallOption) (*ComposePostResponse, error)
+	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*ComposePostResponse, error)
+	EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*ComposePostResponse, error)
 }
 
 type composeClient struct {
@@ -46,11 +50,31 @@ func (c *composeClient) ComposePost(ctx context.Context, in *ComposePostRequest,
 	return out, nil
 }
 
+func (c *composeClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*ComposePostResponse, error) {
+	out := new(ComposePostResponse)
+	err := c.cc.Invoke(ctx, Compose_DeletePost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *composeClient) EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*ComposePostResponse, error) {
+	out := new(ComposePostResponse)
+	err := c.cc.Invoke(ctx, Compose_EditPost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ComposeServer is the server API for Compose service.
 // All implementations must embed UnimplementedComposeServer
 // for forward compatibility
 type ComposeServer interface {
 	ComposePost(context.Context, *ComposePostRequest) (*ComposePostResponse, error)
+	DeletePost(context.Context, *DeletePostRequest) (*ComposePostResponse, error)
+	EditPost(context.Context, *EditPostRequest) (*ComposePostResponse, error)
 	mustEmbedUnimplementedComposeServer()
 }
 
@@ -61,6 +85,12 @@ type UnimplementedComposeServer struct {
 func (UnimplementedComposeServer) ComposePost(context.Context, *ComposePostRequest) (*ComposePostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ComposePost not implemented")
 }
+func (UnimplementedComposeServer) DeletePost(context.Context, *DeletePostRequest) (*ComposePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+}
+func (UnimplementedComposeServer) EditPost(context.Context, *EditPostRequest) (*ComposePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditPost not implemented")
+}
 func (UnimplementedComposeServer) mustEmbedUnimplementedComposeServer() {}
 
 // UnsafeComposeServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +122,42 @@ func _Compose_ComposePost_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Compose_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComposeServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Compose_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComposeServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Compose_EditPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComposeServer).EditPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Compose_EditPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComposeServer).EditPost(ctx, req.(*EditPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Compose_ServiceDesc is the grpc.ServiceDesc for Compose service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +169,14 @@ var Compose_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ComposePost",
 			Handler:    _Compose_ComposePost_Handler,
 		},
+		{
+			MethodName: "DeletePost",
+			Handler:    _Compose_DeletePost_Handler,
+		},
+		{
+			MethodName: "EditPost",
+			Handler:    _Compose_EditPost_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/compose/proto/compose.proto",
@@ -6,17 +6,23 @@ import (
 	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 	"fmt"
-	"math/rand"
 	"net"
 	"sync"
 	"net/http"
 	"net/http/pprof"
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"socialnetworkk8/registry"
+	"socialnetworkk8/ratelimit"
+	"socialnetworkk8/snowflake"
 	"socialnetworkk8/tune"
+	"socialnetworkk8/services/cacheclnt"
 	"socialnetworkk8/services/compose/proto"
 	"socialnetworkk8/services/text"
 	textpb "socialnetworkk8/services/text/proto"
@@ -26,6 +32,14 @@ import (
 	tlpb "socialnetworkk8/services/timeline/proto"
 	"socialnetworkk8/services/home"
 	homepb "socialnetworkk8/services/home/proto"
+	"socialnetworkk8/services/hashtag"
+	hashtagpb "socialnetworkk8/services/hashtag/proto"
+	"socialnetworkk8/services/dm"
+	dmpb "socialnetworkk8/services/dm/proto"
+	"socialnetworkk8/services/notification"
+	notifpb "socialnetworkk8/services/notification/proto"
+	"socialnetworkk8/services/media"
+	mediapb "socialnetworkk8/services/media/proto"
 	"socialnetworkk8/tls"
 	"socialnetworkk8/dialer"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -52,11 +66,19 @@ type ComposeSrv struct {
 	postc        postpb.PostStorageClient
 	tlc          tlpb.TimelineClient
 	homec        homepb.HomeClient
+	hashtagc     hashtagpb.HashtagClient
+	dmc          dmpb.DmClient
+	notifc       notifpb.NotificationClient
+	mediac       mediapb.MediaStorageClient
 	Port         int
 	IpAddr       string
-	sid          int32 // sid is a random number between 0 and 2^30
-	pcount       int32 //This server may overflow with over 2^31 composes
-    mu           sync.Mutex
+	idgen        *snowflake.Generator
+	mongoReqCo   *mongo.Collection
+	mongoOutboxCo *mongo.Collection
+	cachec       *cacheclnt.CacheClnt
+	composeLimiter *ratelimit.Limiter
+	mentionLimiter *ratelimit.Limiter
+	dupWindow    time.Duration
 	cCounter     *tracing.Counter
 }
 
@@ -102,11 +124,51 @@ func MakeComposeSrv() *ComposeSrv {
 		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
 	}
 	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start cache and DB connections")
+	cachec := cacheclnt.MakeCacheClnt()
+	composeLimit := ratelimit.LoadLimit(result, "ComposeLimit", DEFAULT_COMPOSE_LIMIT)
+	mentionLimit := ratelimit.LoadLimit(result, "MentionLimit", DEFAULT_MENTION_LIMIT)
+	dupWindow, err := time.ParseDuration(result["DuplicateWindow"])
+	if err != nil {
+		dupWindow = DEFAULT_DUPLICATE_WINDOW
+	}
+	log.Info().Msgf("Read limits: compose %v, mention %v, duplicate window %v",
+		composeLimit, mentionLimit, dupWindow)
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	requestCo := mongoClient.Database("socialnetwork").Collection("compose-request")
+	names, _ := requestCo.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "createdat", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(IDEMPOTENCY_TTL / time.Second))},
+	})
+	log.Info().Msgf("Name of indexes created: %v", names)
+	outboxCo := mongoClient.Database("socialnetwork").Collection("compose-outbox")
+	names, _ = outboxCo.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "postid", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "nextattempt", Value: 1}}},
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "audited", Value: 1}, {Key: "finishedat", Value: 1}}},
+		{Keys: bson.D{{Key: "finishedat", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(OUTBOX_TTL / time.Second))},
+	})
+	log.Info().Msgf("Name of indexes created: %v", names)
+	log.Info().Msg("New mongo session successfull...")
 	return &ComposeSrv{
 		Port:         serv_port,
 		IpAddr:       serv_ip,
 		Tracer:       tracer,
 		Registry:     registry,
+		mongoReqCo:   requestCo,
+		mongoOutboxCo: outboxCo,
+		cachec:       cachec,
+		composeLimiter: ratelimit.MakeLimiter(cachec, "compose", composeLimit),
+		mentionLimiter: ratelimit.MakeLimiter(cachec, "mention", mentionLimit),
+		dupWindow:    dupWindow,
 		cCounter:     tracing.MakeCounter("Compose-Post"),
 	}
 }
@@ -154,8 +216,49 @@ func (csrv *ComposeSrv) Run() error {
 		return fmt.Errorf("dialer error: %v", err)
 	}
 	csrv.homec = homepb.NewHomeClient(homeConn)
+
+	hashtagConn, err := dialer.Dial(
+		hashtag.HASHTAG_SRV_NAME,
+		csrv.Registry.Client,
+		dialer.WithTracer(csrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	csrv.hashtagc = hashtagpb.NewHashtagClient(hashtagConn)
+
+	dmConn, err := dialer.Dial(
+		dm.DM_SRV_NAME,
+		csrv.Registry.Client,
+		dialer.WithTracer(csrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	csrv.dmc = dmpb.NewDmClient(dmConn)
+
+	notifConn, err := dialer.Dial(
+		notification.NOTIFICATION_SRV_NAME,
+		csrv.Registry.Client,
+		dialer.WithTracer(csrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	csrv.notifc = notifpb.NewNotificationClient(notifConn)
+
+	mediaConn, err := dialer.Dial(
+		media.MEDIA_SRV_NAME,
+		csrv.Registry.Client,
+		dialer.WithTracer(csrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	csrv.mediac = mediapb.NewMediaStorageClient(mediaConn)
 	csrv.uuid = uuid.New().String()
-	csrv.sid = rand.Int31n(536870912) // 2^29
+	csrv.idgen, err = snowflake.MakeLeasedGenerator(csrv.Registry)
+	if err != nil {
+		return fmt.Errorf("cannot lease snowflake worker id: %v", err)
+	}
+	go csrv.runOutbox()
+	go csrv.runReconcile()
 	opts := []grpc.ServerOption{
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -198,6 +301,7 @@ func (csrv *ComposeSrv) Run() error {
 // Shutdown cleans up any processes
 func (csrv *ComposeSrv) Shutdown() {
 	csrv.Registry.Deregister(csrv.uuid)
+	csrv.idgen.Close()
 }
 
 func (csrv *ComposeSrv) ComposePost(
@@ -205,26 +309,81 @@ func (csrv *ComposeSrv) ComposePost(
 	t0 := time.Now()
 	defer csrv.cCounter.AddTimeSince(t0)
 	log.Debug().Msgf("Recieved compose request: %v", req)
+	if req.Idempotencykey != "" {
+		return csrv.composeOnce(ctx, req)
+	}
+	return csrv.composePost(ctx, req, 0)
+}
+
+// composePost composes a post under postid, or under a new id if postid is 0.
+func (csrv *ComposeSrv) composePost(ctx context.Context, req *proto.ComposePostRequest,
+		postid int64) (*proto.ComposePostResponse, error) {
 	res := &proto.ComposePostResponse{Ok: "No"}
 	timestamp := time.Now().UnixNano()
 	if req.Text == "" {
 		res.Ok = "Cannot compose empty post!"
 		return res, nil
 	}
+	// replies and reposts may only point at an existing post
+	parent, rootid, errStr, err := csrv.resolveThread(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if errStr != "" {
+		res.Ok += " Thread Error: " + errStr
+		return res, nil
+	}
+	// attached media must have been uploaded first
+	if len(req.Mediaids) > 0 {
+		mediaRes, err := csrv.mediac.CheckMedia(ctx, &mediapb.CheckMediaRequest{Mediaids: req.Mediaids})
+		if err != nil {
+			return nil, err
+		}
+		if mediaRes.Ok != media.MEDIA_QUERY_OK {
+			res.Ok += " Media Error: " + mediaRes.Ok
+			return res, nil
+		}
+	}
 	// process text
-	textReq := &textpb.ProcessTextRequest{Text: req.Text}
+	textReq := &textpb.ProcessTextRequest{Text: req.Text, Userid: req.Userid}
 	textRes, err := csrv.textc.ProcessText(ctx, textReq)
 	if err != nil {
 		log.Error().Msgf("Error processing text: %v")
 		return res, err
 	}
+	if textRes.Moderation == textpb.MODERATION_ACTION_REJECT {
+		res.Ok += " Moderation: " + strings.Join(textRes.Moderationreasons, "; ")
+		return res, nil
+	}
 	if textRes.Ok != text.TEXT_QUERY_OK {
 		res.Ok += " Text Error: " + textRes.Ok
 		return res, nil
 	} 
-	// create post
+	if postid == 0 {
+		if postid, err = csrv.getNextPostId(); err != nil {
+			return res, err
+		}
+	}
+	if limitRes := csrv.checkSpam(ctx, req, postid, len(textRes.Usermentions)); limitRes != nil {
+		return limitRes, nil
+	}
+	// DMs never become posts; they go to the mentioned users' inboxes only
+	if req.Posttype == postpb.POST_TYPE_DM {
+		res, err = csrv.sendMessage(ctx, req, textRes)
+	} else {
+		res, err = csrv.composeSaga(ctx, csrv.makePost(req, textRes, postid, rootid, timestamp), parent)
+	}
+	if err != nil || res.Ok != COMPOSE_QUERY_OK {
+		csrv.releasePost(ctx, req)
+	}
+	return res, err
+}
+
+// makePost makes the post composed by req.
+func (csrv *ComposeSrv) makePost(req *proto.ComposePostRequest, textRes *textpb.ProcessTextResponse,
+		postid, rootid, timestamp int64) *postpb.Post {
 	newPost := &postpb.Post{
-		Postid: csrv.getNextPostId(),
+		Postid: postid,
 		Posttype: req.Posttype,
 		Timestamp: timestamp,
 		Creator: req.Userid,
@@ -232,66 +391,316 @@ func (csrv *ComposeSrv) ComposePost(
 		Text: textRes.Text,
 		Usermentions: textRes.Usermentions,
 		Urls: textRes.Urls,
+		Hashtags: textRes.Hashtags,
+		Flags: textRes.Moderationreasons,
 		Medias: req.Mediaids,
+		Parentid: req.Parentid,
+		Rootid: rootid,
 	}
 	log.Debug().Msgf("composing post: %v", newPost)
-	
-	// concurrently add post to storage and timelines
+	return newPost
+}
+
+// notifyPost tells mentioned users and the author of the replied-to post about
+// a new post. Notifications are best effort and never fail the compose.
+func (csrv *ComposeSrv) notifyPost(ctx context.Context, newPost *postpb.Post, parent *postpb.Post) {
+	var items []*notifpb.NotificationItem
+	makeItem := func(userid int64, ntype notifpb.NOTIFICATION_TYPE) *notifpb.NotificationItem {
+		return &notifpb.NotificationItem{
+			Userid: userid,
+			Notificationtype: ntype,
+			Actorid: newPost.Creator,
+			Actoruname: newPost.Creatoruname,
+			Postid: newPost.Postid,
+			Timestamp: newPost.Timestamp}
+	}
+	for _, mentionid := range newPost.Usermentions {
+		items = append(items, makeItem(mentionid, notifpb.NOTIFICATION_TYPE_MENTION))
+	}
+	if parent != nil && newPost.Posttype != postpb.POST_TYPE_REPOST {
+		items = append(items, makeItem(parent.Creator, notifpb.NOTIFICATION_TYPE_REPLY))
+	}
+	if len(items) == 0 {
+		return
+	}
+	notifRes, err := csrv.notifc.Notify(ctx, &notifpb.NotifyRequest{Notifications: items})
+	if err != nil {
+		log.Error().Msgf("Error notifying about post %v: %v", newPost.Postid, err)
+	} else if notifRes.Ok != notification.NOTIFICATION_QUERY_OK {
+		log.Error().Msgf("Cannot notify about post %v: %v", newPost.Postid, notifRes.Ok)
+	}
+}
+
+// sendMessage delivers a DM to the users mentioned in it.
+func (csrv *ComposeSrv) sendMessage(
+		ctx context.Context, req *proto.ComposePostRequest, 
+		textRes *textpb.ProcessTextResponse) (*proto.ComposePostResponse, error) {
+	res := &proto.ComposePostResponse{Ok: "No"}
+	if req.Parentid != 0 {
+		res.Ok += " DM Error: Messages cannot reply to posts."
+		return res, nil
+	}
+	if len(textRes.Usermentions) == 0 {
+		res.Ok += " DM Error: Messages must mention at least one recipient."
+		return res, nil
+	}
+	dmReq := &dmpb.SendMessageRequest{
+		Senderid: req.Userid,
+		Senderuname: req.Username,
+		Recipientids: textRes.Usermentions,
+		Text: textRes.Text,
+		Mediaids: req.Mediaids,
+	}
+	dmRes, err := csrv.dmc.SendMessage(ctx, dmReq)
+	if err != nil {
+		return nil, err
+	}
+	if dmRes.Ok != dm.DM_QUERY_OK {
+		res.Ok += " DM Error: " + dmRes.Ok
+		return res, nil
+	}
+	res.Ok = COMPOSE_QUERY_OK
+	return res, nil
+}
+
+// resolveThread checks the parent of a reply or repost, if any, and returns it
+// with the root of its thread. Problems with the request are reported in the
+// returned string.
+func (csrv *ComposeSrv) resolveThread(
+		ctx context.Context, req *proto.ComposePostRequest) (*postpb.Post, int64, string, error) {
+	if req.Parentid == 0 {
+		if req.Rootid != 0 {
+			return nil, 0, "Cannot set a root post without a parent.", nil
+		}
+		return nil, 0, "", nil
+	}
+	parentRes, err := csrv.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{req.Parentid}})
+	if err != nil {
+		return nil, 0, "", err
+	}
+	if parentRes.Ok != post.POST_QUERY_OK || len(parentRes.Posts) == 0 {
+		return nil, 0, fmt.Sprintf("Parent post %v does not exist.", req.Parentid), nil
+	}
+	parent := parentRes.Posts[0]
+	rootid := parent.Postid
+	if parent.Rootid != 0 {
+		rootid = parent.Rootid
+	}
+	if req.Rootid != 0 && req.Rootid != rootid {
+		return nil, 0, fmt.Sprintf("Post %v is not the root of %v.", req.Rootid, req.Parentid), nil
+	}
+	return parent, rootid, "", nil
+}
+
+func (csrv *ComposeSrv) DeletePost(
+		ctx context.Context, req *proto.DeletePostRequest) (*proto.ComposePostResponse, error) {
+	log.Debug().Msgf("Recieved delete request: %v", req)
+	res := &proto.ComposePostResponse{Ok: "No"}
+	// take the post off the timelines and tags before tombstoning it. Removal
+	// is idempotent, so if a step fails the post is left in place and a retry
+	// finishes the job, instead of a delete that went through being reported
+	// as failed.
+	readRes, err := csrv.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{req.Postid}})
+	if err != nil {
+		return nil, err
+	}
+	if readRes.Ok == post.POST_QUERY_OK && len(readRes.Posts) > 0 && readRes.Posts[0].Creator == req.Userid {
+		if ok, err := csrv.removePost(ctx, readRes.Posts[0]); err != nil || ok != "" {
+			res.Ok += ok
+			return res, err
+		}
+	}
+	// missing posts and other users' posts are refused here
+	postReq := &postpb.DeletePostRequest{Postid: req.Postid, Userid: req.Userid}
+	postRes, err := csrv.postc.DeletePost(ctx, postReq)
+	if err != nil {
+		return nil, err
+	}
+	if postRes.Ok != post.POST_QUERY_OK {
+		res.Ok += " Post Error: " + postRes.Ok
+		return res, nil
+	}
+	res.Ok = COMPOSE_QUERY_OK
+	return res, nil
+}
+
+// removePost concurrently takes p off its author's timeline, the home
+// timelines and its hashtags. Failures are returned as an error, or as the
+// reason to report.
+func (csrv *ComposeSrv) removePost(ctx context.Context, p *postpb.Post) (string, error) {
 	var wg sync.WaitGroup
-	var postErr, tlErr, homeErr error
-	postReq := &postpb.StorePostRequest{Post: newPost}
-	postRes := &postpb.StorePostResponse{}
-	tlReq := &tlpb.WriteTimelineRequest{
-		Userid: req.Userid, 
-		Postid: newPost.Postid, 
-		Timestamp: newPost.Timestamp}
+	var tlErr, homeErr, hashtagErr error
+	tlReq := &tlpb.RemoveTimelineRequest{Userid: p.Creator, Postid: p.Postid}
 	tlRes := &tlpb.WriteTimelineResponse{}
-	homeReq := &homepb.WriteHomeTimelineRequest{
-		Usermentionids: newPost.Usermentions, 
-		Userid: req.Userid, 
-		Postid: newPost.Postid, 
-		Timestamp: newPost.Timestamp}
+	homeReq := &homepb.RemoveHomeTimelineRequest{
+		Userid: p.Creator,
+		Postid: p.Postid,
+		Usermentionids: p.Usermentions}
 	homeRes := &tlpb.WriteTimelineResponse{}
-	wg.Add(3)
+	hashtagReq := &hashtagpb.RemoveHashtagsRequest{
+		Hashtags: p.Hashtags, Postid: p.Postid}
+	hashtagRes := &hashtagpb.WriteHashtagsResponse{Ok: hashtag.HASHTAG_QUERY_OK}
+	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		postRes, postErr = csrv.postc.StorePost(ctx, postReq)
-	}()
-	go func() {
-		defer wg.Done()
-		tlRes, tlErr = csrv.tlc.WriteTimeline(ctx, tlReq) 
+		tlRes, tlErr = csrv.tlc.RemoveTimeline(ctx, tlReq)
 	}()
+	if len(hashtagReq.Hashtags) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hashtagRes, hashtagErr = csrv.hashtagc.RemoveHashtags(ctx, hashtagReq)
+		}()
+	}
 	go func() {
 		defer wg.Done()
-		homeRes, homeErr = csrv.homec.WriteHomeTimeline(ctx, homeReq)
+		homeRes, homeErr = csrv.homec.RemoveHomeTimeline(ctx, homeReq)
 	}()
 	wg.Wait()
-	if postErr != nil || tlErr != nil || homeErr != nil {
-		return nil, fmt.Errorf("%w; %w; %w", postErr, tlErr, homeErr)
+	failed := make([]string, 0)
+	for _, err := range []error{tlErr, homeErr, hashtagErr} {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return "", fmt.Errorf("cannot remove post %v: %v", p.Postid, strings.Join(failed, "; "))
 	}
-	if postRes.Ok != post.POST_QUERY_OK {
-		res.Ok += " Post Error: " + postRes.Ok
-		return res, nil
-	} 
 	if tlRes.Ok != timeline.TIMELINE_QUERY_OK {
-		res.Ok += " Timeline Error: " + tlRes.Ok
-		return res, nil
+		return " Timeline Error: " + tlRes.Ok, nil
 	}
 	if homeRes.Ok != home.HOME_QUERY_OK {
-		res.Ok += " Home Error: " + homeRes.Ok
+		return " Home Error: " + homeRes.Ok, nil
+	}
+	if hashtagRes.Ok != hashtag.HASHTAG_QUERY_OK {
+		return " Hashtag Error: " + hashtagRes.Ok, nil
+	}
+	return "", nil
+}
+
+func (csrv *ComposeSrv) EditPost(
+		ctx context.Context, req *proto.EditPostRequest) (*proto.ComposePostResponse, error) {
+	log.Debug().Msgf("Recieved edit request: %v", req)
+	res := &proto.ComposePostResponse{Ok: "No"}
+	if req.Text == "" {
+		res.Ok = "Cannot edit to empty post!"
+		return res, nil
+	}
+	textReq := &textpb.ProcessTextRequest{Text: req.Text, Userid: req.Userid}
+	textRes, err := csrv.textc.ProcessText(ctx, textReq)
+	if err != nil {
+		log.Error().Msgf("Error processing text: %v", err)
+		return res, err
+	}
+	if textRes.Moderation == textpb.MODERATION_ACTION_REJECT {
+		res.Ok += " Moderation: " + strings.Join(textRes.Moderationreasons, "; ")
+		return res, nil
+	}
+	if textRes.Ok != text.TEXT_QUERY_OK {
+		res.Ok += " Text Error: " + textRes.Ok
 		return res, nil
+	} 
+	// the tags of the current version decide what to re-index after the edit
+	readRes, err := csrv.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{req.Postid}})
+	if err != nil {
+		return nil, err
+	}
+	var prevHashtags []string
+	var prevMentions []int64
+	if readRes.Ok == post.POST_QUERY_OK && len(readRes.Posts) > 0 {
+		prevHashtags = readRes.Posts[0].Hashtags
+		prevMentions = readRes.Posts[0].Usermentions
+	}
+	postReq := &postpb.EditPostRequest{
+		Postid: req.Postid,
+		Userid: req.Userid,
+		Text: textRes.Text,
+		Usermentions: textRes.Usermentions,
+		Urls: textRes.Urls,
+		Hashtags: textRes.Hashtags,
+		Flags: textRes.Moderationreasons,
+	}
+	postRes, err := csrv.postc.EditPost(ctx, postReq)
+	if err != nil {
+		return nil, err
+	}
+	if postRes.Ok != post.POST_QUERY_OK {
+		res.Ok += " Post Error: " + postRes.Ok
+		return res, nil
+	}
+	// users were told about the mentions of earlier versions already
+	if mentions := newMentions(prevMentions, textRes.Usermentions); len(mentions) > 0 {
+		edited := postRes.Post
+		csrv.notifyPost(ctx, &postpb.Post{
+			Postid: edited.Postid, Posttype: edited.Posttype, Timestamp: edited.Timestamp,
+			Creator: edited.Creator, Creatoruname: edited.Creatoruname, Usermentions: mentions}, nil)
+	}
+	removed, added := diffHashtags(prevHashtags, textRes.Hashtags)
+	if len(removed) > 0 {
+		hashtagRes, err := csrv.hashtagc.RemoveHashtags(ctx, &hashtagpb.RemoveHashtagsRequest{
+			Hashtags: removed, Postid: req.Postid})
+		if err != nil {
+			return nil, err
+		}
+		if hashtagRes.Ok != hashtag.HASHTAG_QUERY_OK {
+			res.Ok += " Hashtag Error: " + hashtagRes.Ok
+			return res, nil
+		}
+	}
+	if len(added) > 0 {
+		hashtagRes, err := csrv.hashtagc.WriteHashtags(ctx, &hashtagpb.WriteHashtagsRequest{
+			Hashtags: added, Postid: req.Postid, Timestamp: postRes.Post.Timestamp})
+		if err != nil {
+			return nil, err
+		}
+		if hashtagRes.Ok != hashtag.HASHTAG_QUERY_OK {
+			res.Ok += " Hashtag Error: " + hashtagRes.Ok
+			return res, nil
+		}
 	}
 	res.Ok = COMPOSE_QUERY_OK
 	return res, nil
 }
 
-func (csrv *ComposeSrv) incCountSafe() int32 {
-	csrv.mu.Lock()
-	defer csrv.mu.Unlock()
-	csrv.pcount++
-	return csrv.pcount
+// diffHashtags returns the tags only in prev and the tags only in next.
+func diffHashtags(prev, next []string) ([]string, []string) {
+	inPrev := make(map[string]bool)
+	for _, tag := range prev {
+		inPrev[tag] = true
+	}
+	inNext := make(map[string]bool)
+	var added []string
+	for _, tag := range next {
+		inNext[tag] = true
+		if !inPrev[tag] {
+			added = append(added, tag)
+		}
+	}
+	var removed []string
+	for _, tag := range prev {
+		if !inNext[tag] {
+			removed = append(removed, tag)
+		}
+	}
+	return removed, added
+}
+
+// newMentions returns the users mentioned in next but not in prev.
+func newMentions(prev, next []int64) []int64 {
+	inPrev := make(map[int64]bool)
+	for _, userid := range prev {
+		inPrev[userid] = true
+	}
+	var added []int64
+	for _, userid := range next {
+		if !inPrev[userid] {
+			inPrev[userid] = true
+			added = append(added, userid)
+		}
+	}
+	return added
 }
 
-func (csrv *ComposeSrv) getNextPostId() int64 {
-	return int64(csrv.sid)*1e10 + int64(csrv.incCountSafe())
+func (csrv *ComposeSrv) getNextPostId() (int64, error) {
+	return csrv.idgen.Next()
 }
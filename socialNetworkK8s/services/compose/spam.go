@@ -0,0 +1,90 @@
+package compose
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/context"
+	"socialnetworkk8/ratelimit"
+	"socialnetworkk8/services/compose/proto"
+)
+
+const (
+	DUPLICATE_CACHE_PREFIX = "recentpost_"
+	DEFAULT_DUPLICATE_WINDOW = time.Minute
+)
+
+var (
+	DEFAULT_COMPOSE_LIMIT = ratelimit.Limit{Rate: 1, Burst: 30}
+	DEFAULT_MENTION_LIMIT = ratelimit.Limit{Rate: 5, Burst: 100}
+)
+
+// contentFingerprint identifies what a post says, ignoring case and spacing.
+func contentFingerprint(req *proto.ComposePostRequest) string {
+	text := strings.Join(strings.Fields(strings.ToLower(req.Text)), " ")
+	sum := sha256.Sum256([]byte(strconv.FormatInt(req.Parentid, 10) + ":" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// duplicateKey is the key of the claim a user holds on what a post says
+// for the duplicate window after posting it.
+func duplicateKey(req *proto.ComposePostRequest) string {
+	return DUPLICATE_CACHE_PREFIX + strconv.FormatInt(req.Userid, 10) + "_" + contentFingerprint(req)
+}
+
+func rateLimited(reason string, wait time.Duration) *proto.ComposePostResponse {
+	return &proto.ComposePostResponse{
+		Ok: "No Rate limit: " + reason,
+		Ratelimited: true,
+		Retryafter: int64(math.Ceil(wait.Seconds())),
+	}
+}
+
+// checkSpam returns a rate limited response if userid may not compose the
+// post now: it repeats one of the user's posts from the duplicate window,
+// or the user has run out of mentions or posts. The duplicate check claims
+// the post's content in one atomic step, so of two identical posts racing
+// only one gets through; the claim is dropped again if the post is refused
+// here or fails later. The cache being unreachable lets the post through.
+func (csrv *ComposeSrv) checkSpam(ctx context.Context, req *proto.ComposePostRequest,
+		postid int64, nmentions int) *proto.ComposePostResponse {
+	key := duplicateKey(req)
+	claimed, holder, wait, err := csrv.cachec.Claim(
+		ctx, key, []byte(strconv.FormatInt(postid, 10)), csrv.dupWindow)
+	if err != nil {
+		log.Error().Msgf("Cannot check duplicates of %v: %v", req.Userid, err)
+	} else if !claimed {
+		// a retry taking over its own dead request is no duplicate
+		if dupid, _ := strconv.ParseInt(string(holder), 10, 64); dupid != postid {
+			return rateLimited(fmt.Sprintf("Duplicate of post %v.", dupid), wait)
+		}
+	}
+	// mentions first, so that a post refused for its mentions does not use
+	// up one of the user's posts
+	if ok, wait, err := csrv.mentionLimiter.Take(ctx, req.Userid, nmentions); err != nil {
+		log.Error().Msgf("Cannot check mention limit of %v: %v", req.Userid, err)
+	} else if !ok {
+		csrv.releasePost(ctx, req)
+		return rateLimited("Too many mentions.", wait)
+	}
+	if ok, wait, err := csrv.composeLimiter.Take(ctx, req.Userid, 1); err != nil {
+		log.Error().Msgf("Cannot check compose limit of %v: %v", req.Userid, err)
+	} else if !ok {
+		csrv.releasePost(ctx, req)
+		return rateLimited("Too many posts.", wait)
+	}
+	return nil
+}
+
+// releasePost drops the claim checkSpam took on a post that was not
+// composed, so that posting it again is no duplicate.
+func (csrv *ComposeSrv) releasePost(ctx context.Context, req *proto.ComposePostRequest) {
+	if !csrv.cachec.Delete(ctx, duplicateKey(req)) {
+		log.Error().Msgf("Cannot release duplicate check of %v", req.Userid)
+	}
+}
@@ -0,0 +1,414 @@
+package dm
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/snowflake"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/dm/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+const (
+	DM_SRV_NAME = "srv-dm"
+	DM_QUERY_OK = "OK"
+	DM_CACHE_PREFIX = "dmconvs_"
+	// characters of the latest message shown with a conversation
+	DM_PREVIEW_LENGTH = 64
+)
+
+// DmSrv keeps direct messages apart from posts: messages are stored per
+// conversation and only ever returned to the conversation's participants.
+type DmSrv struct {
+	proto.UnimplementedDmServer
+	uuid         string
+	cachec       *cacheclnt.CacheClnt
+	mongoConvCo  *mongo.Collection
+	mongoMsgCo   *mongo.Collection
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	idgen        *snowflake.Generator
+	sCounter     *tracing.Counter
+	lCounter     *tracing.Counter
+	rCounter     *tracing.Counter
+}
+
+func MakeDmSrv() *DmSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["DmPort"])
+	serv_ip := result["DmIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "dm", *jaegeraddr)
+	tracer, err := tracing.Init("dm", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start cache and DB connections")
+	cachec := cacheclnt.MakeCacheClnt()
+
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	convCo := mongoClient.Database("socialnetwork").Collection("dm-conversation")
+	msgCo := mongoClient.Database("socialnetwork").Collection("dm-message")
+	convIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "conversationid", Value: 1}}}
+	name1, _ := convCo.Indexes().CreateOne(context.TODO(), convIndexModel)
+	log.Info().Msgf("Name of index created for conversations: %v", name1)
+	userIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "participants", Value: 1}}}
+	name2, _ := convCo.Indexes().CreateOne(context.TODO(), userIndexModel)
+	log.Info().Msgf("Name of index created for participants: %v", name2)
+	msgIndexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "conversationid", Value: 1}, {Key: "timestamp", Value: -1}}}
+	name3, _ := msgCo.Indexes().CreateOne(context.TODO(), msgIndexModel)
+	log.Info().Msgf("Name of index created for messages: %v", name3)
+	log.Info().Msg("New mongo session successfull...")
+
+	return &DmSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		cachec:       cachec,
+		mongoConvCo:  convCo,
+		mongoMsgCo:   msgCo,
+		sCounter:     tracing.MakeCounter("Send-Message"),
+		lCounter:     tracing.MakeCounter("List-Conversations"),
+		rCounter:     tracing.MakeCounter("Read-Conversation"),
+	}
+}
+
+// Run starts the server
+func (dsrv *DmSrv) Run() error {
+	if dsrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC Server...")
+	dsrv.uuid = uuid.New().String()
+	var err error
+	dsrv.idgen, err = snowflake.MakeLeasedGenerator(dsrv.Registry)
+	if err != nil {
+		return fmt.Errorf("cannot lease snowflake worker id: %v", err)
+	}
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(dsrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterDmServer(grpcSrv, dsrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", dsrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = dsrv.Registry.Register(DM_SRV_NAME, dsrv.uuid, dsrv.IpAddr, dsrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+func (dsrv *DmSrv) SendMessage(
+		ctx context.Context, req *proto.SendMessageRequest) (*proto.SendMessageResponse, error) {
+	t0 := time.Now()
+	defer dsrv.sCounter.AddTimeSince(t0)
+	res := &proto.SendMessageResponse{Ok: "No."}
+	if req.Text == "" {
+		res.Ok = "Cannot send empty message."
+		return res, nil
+	}
+	participants := makeParticipants(req.Senderid, req.Recipientids)
+	if len(participants) < 2 {
+		res.Ok = "Messages need at least one recipient other than the sender."
+		return res, nil
+	}
+	convid := makeConversationId(participants)
+	msgid, err := dsrv.getNextMessageId()
+	if err != nil {
+		return nil, err
+	}
+	msg := &MessageBson{
+		Messageid: msgid,
+		Conversationid: convid,
+		Senderid: req.Senderid,
+		Senderuname: req.Senderuname,
+		Text: req.Text,
+		Medias: req.Mediaids,
+		Timestamp: time.Now().UnixNano(),
+	}
+	if _, err := dsrv.mongoMsgCo.InsertOne(context.TODO(), msg); err != nil {
+		log.Error().Msg(err.Error())
+		return nil, err
+	}
+	preview := msg.Text
+	if runes := []rune(preview); len(runes) > DM_PREVIEW_LENGTH {
+		preview = string(runes[:DM_PREVIEW_LENGTH])
+	}
+	_, err = dsrv.mongoConvCo.UpdateOne(
+		context.TODO(), &bson.M{"conversationid": convid},
+		&bson.M{
+			"$setOnInsert": bson.M{"participants": participants},
+			"$set": bson.M{"lasttimestamp": msg.Timestamp, "lastpreview": preview},
+			"$inc": bson.M{"nmessages": 1}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	for _, userid := range participants {
+		key := DM_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+		if !dsrv.cachec.Delete(ctx, key) {
+			log.Error().Msgf("cannot delete conversations of %v", key)
+		}
+	}
+	res.Ok = DM_QUERY_OK
+	res.Conversationid = convid
+	res.Messageid = msg.Messageid
+	return res, nil
+}
+
+func (dsrv *DmSrv) ListConversations(
+		ctx context.Context, req *proto.ListConversationsRequest) (
+		*proto.ListConversationsResponse, error) {
+	t0 := time.Now()
+	defer dsrv.lCounter.AddTimeSince(t0)
+	res := &proto.ListConversationsResponse{Ok: "No."}
+	convs, err := dsrv.getConversations(ctx, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	start, stop, nItems := req.Start, req.Stop, int32(len(convs))
+	if start < 0 || start >= nItems || start >= stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v for %v items", start, stop, nItems)
+		return res, nil
+	}
+	if stop > nItems {
+		stop = nItems
+	}
+	res.Conversations = make([]*proto.Conversation, stop-start)
+	for i := start; i < stop; i++ {
+		res.Conversations[i-start] = bsonToConversation(convs[i])
+	}
+	res.Ok = DM_QUERY_OK
+	return res, nil
+}
+
+func (dsrv *DmSrv) ReadConversation(
+		ctx context.Context, req *proto.ReadConversationRequest) (
+		*proto.ReadConversationResponse, error) {
+	t0 := time.Now()
+	defer dsrv.rCounter.AddTimeSince(t0)
+	res := &proto.ReadConversationResponse{Ok: "No."}
+	if !isParticipant(req.Conversationid, req.Userid) {
+		res.Ok = fmt.Sprintf("User %v is not in conversation %v.", req.Userid, req.Conversationid)
+		return res, nil
+	}
+	if req.Start < 0 || req.Start >= req.Stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v", req.Start, req.Stop)
+		return res, nil
+	}
+	// newest messages first, like timelines
+	cursor, err := dsrv.mongoMsgCo.Find(
+		context.TODO(), &bson.M{"conversationid": req.Conversationid},
+		options.Find().
+			SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+			SetSkip(int64(req.Start)).
+			SetLimit(int64(req.Stop-req.Start)))
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*MessageBson
+	if err = cursor.All(context.TODO(), &msgs); err != nil {
+		return nil, err
+	}
+	res.Messages = make([]*proto.Message, len(msgs))
+	for idx, msg := range msgs {
+		res.Messages[idx] = bsonToMessage(msg)
+	}
+	res.Ok = DM_QUERY_OK
+	return res, nil
+}
+
+func (dsrv *DmSrv) getConversations(ctx context.Context, userid int64) ([]*ConversationBson, error) {
+	key := DM_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+	convs := make([]*ConversationBson, 0)
+	if convItem, err := dsrv.cachec.Get(ctx, key); err != nil {
+		if err != memcache.ErrCacheMiss {
+			return nil, err
+		}
+		log.Debug().Msgf("Conversations %v cache miss", key)
+		cursor, err := dsrv.mongoConvCo.Find(
+			context.TODO(), &bson.M{"participants": userid},
+			options.Find().SetSort(bson.D{{Key: "lasttimestamp", Value: -1}}))
+		if err != nil {
+			return nil, err
+		}
+		if err = cursor.All(context.TODO(), &convs); err != nil {
+			return nil, err
+		}
+		log.Debug().Msgf("Found %v conversations for %v in DB", len(convs), userid)
+		encodedConvs, err := json.Marshal(convs)
+		if err != nil {
+			log.Error().Msg(err.Error())
+			return nil, err
+		}
+		dsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedConvs})
+	} else {
+		log.Debug().Msgf("Found conversations of %v in cache!", userid)
+		json.Unmarshal(convItem.Value, &convs)
+	}
+	return convs, nil
+}
+
+// makeParticipants returns the sorted, de-duplicated ids of everyone in a
+// conversation.
+func makeParticipants(senderid int64, recipientids []int64) []int64 {
+	seen := map[int64]bool{senderid: true}
+	participants := []int64{senderid}
+	for _, userid := range recipientids {
+		if !seen[userid] {
+			seen[userid] = true
+			participants = append(participants, userid)
+		}
+	}
+	sort.Slice(participants, func(i, j int) bool { return participants[i] < participants[j] })
+	return participants
+}
+
+// A conversation is identified by its participants, so the same set of users
+// always lands in the same conversation.
+func makeConversationId(participants []int64) string {
+	ids := make([]string, len(participants))
+	for idx, userid := range participants {
+		ids[idx] = strconv.FormatInt(userid, 10)
+	}
+	return strings.Join(ids, "-")
+}
+
+func isParticipant(convid string, userid int64) bool {
+	for _, idstr := range strings.Split(convid, "-") {
+		if idstr == strconv.FormatInt(userid, 10) {
+			return true
+		}
+	}
+	return false
+}
+
+func bsonToConversation(conv *ConversationBson) *proto.Conversation {
+	return &proto.Conversation{
+		Conversationid: conv.Conversationid,
+		Participants: conv.Participants,
+		Lasttimestamp: conv.Lasttimestamp,
+		Lastpreview: conv.Lastpreview,
+		Nmessages: conv.Nmessages,
+	}
+}
+
+func bsonToMessage(msg *MessageBson) *proto.Message {
+	return &proto.Message{
+		Messageid: msg.Messageid,
+		Conversationid: msg.Conversationid,
+		Senderid: msg.Senderid,
+		Senderuname: msg.Senderuname,
+		Text: msg.Text,
+		Medias: msg.Medias,
+		Timestamp: msg.Timestamp,
+	}
+}
+
+type ConversationBson struct {
+	Conversationid string `bson:"conversationid"`
+	Participants []int64  `bson:"participants"`
+	Lasttimestamp int64   `bson:"lasttimestamp"`
+	Lastpreview string    `bson:"lastpreview"`
+	Nmessages int64       `bson:"nmessages"`
+}
+
+type MessageBson struct {
+	Messageid int64       `bson:"messageid"`
+	Conversationid string `bson:"conversationid"`
+	Senderid int64        `bson:"senderid"`
+	Senderuname string    `bson:"senderuname"`
+	Text string           `bson:"text"`
+	Medias []int64        `bson:"medias"`
+	Timestamp int64       `bson:"timestamp"`
+}
+
+func (dsrv *DmSrv) getNextMessageId() (int64, error) {
+	return dsrv.idgen.Next()
+}
@@ -0,0 +1,660 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/rs/zerolog/log"
+	userpb "socialnetworkk8/services/user/proto"
+	composepb "socialnetworkk8/services/compose/proto"
+	tlpb "socialnetworkk8/services/timeline/proto"
+	postpb "socialnetworkk8/services/post/proto"
+	reactionpb "socialnetworkk8/services/reaction/proto"
+	hashtagpb "socialnetworkk8/services/hashtag/proto"
+	"socialnetworkk8/services/user"
+	"socialnetworkk8/services/compose"
+	"socialnetworkk8/services/timeline"
+	"socialnetworkk8/services/post"
+	"socialnetworkk8/services/reaction"
+	"socialnetworkk8/services/hashtag"
+	"socialnetworkk8/services/graph"
+)
+
+// The versioned JSON API lives under API_PREFIX next to the query string
+// routes, which stay for the load scripts. Writes take JSON bodies, reads
+// return JSON objects, and every failure is an apiError envelope.
+const (
+	API_PREFIX = "/api/v1"
+	API_MAX_BODY = 1 << 20
+	API_DEFAULT_PAGE = 10
+)
+
+// apiId is a 64-bit id. Snowflake ids do not fit in a JavaScript number, so
+// ids are written as strings; both strings and numbers are read.
+type apiId int64
+
+func (id apiId) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatInt(int64(id), 10) + `"`), nil
+}
+
+func (id *apiId) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad id %s", data)
+	}
+	*id = apiId(n)
+	return nil
+}
+
+func apiIds(ids []int64) []apiId {
+	out := make([]apiId, len(ids))
+	for idx, id := range ids {
+		out[idx] = apiId(id)
+	}
+	return out
+}
+
+type apiUser struct {
+	Userid      apiId  `json:"userid"`
+	Username    string `json:"username"`
+	Displayname string `json:"displayname,omitempty"`
+}
+
+type apiPost struct {
+	Id        apiId            `json:"id"`
+	Type      string           `json:"type"`
+	Author    apiUser        `json:"author"`
+	Text      string           `json:"text"`
+	Urls      []string         `json:"urls"`
+	Mentions  []apiId          `json:"mentions"`
+	Media     []apiId          `json:"media"`
+	Hashtags  []string         `json:"hashtags"`
+	Timestamp string           `json:"timestamp"`
+	Edited    string           `json:"edited,omitempty"`
+	Parentid  apiId            `json:"parentid,omitempty"`
+	Rootid    apiId            `json:"rootid,omitempty"`
+	Replies   int32            `json:"replies"`
+	Reposts   int32            `json:"reposts"`
+	Reactions map[string]int64 `json:"reactions"`
+	Flags     []string         `json:"flags,omitempty"`
+}
+
+type apiThreadItem struct {
+	Depth int32    `json:"depth"`
+	Post  *apiPost `json:"post"`
+}
+
+type apiError struct {
+	Status     int    `json:"status"`
+	Message    string `json:"message"`
+	Retryafter int64  `json:"retryafter,omitempty"`
+}
+
+func apiTime(ts int64) string {
+	return time.Unix(0, ts).UTC().Format(time.RFC3339Nano)
+}
+
+func toApiPost(p *postpb.Post) *apiPost {
+	ap := &apiPost{
+		Id: apiId(p.Postid),
+		Type: strings.ToLower(p.Posttype.String()),
+		Author: apiUser{Userid: apiId(p.Creator), Username: p.Creatoruname},
+		Text: p.Text,
+		Urls: append([]string{}, p.Urls...),
+		Mentions: apiIds(p.Usermentions),
+		Media: apiIds(p.Medias),
+		Hashtags: append([]string{}, p.Hashtags...),
+		Timestamp: apiTime(p.Timestamp),
+		Parentid: apiId(p.Parentid),
+		Rootid: apiId(p.Rootid),
+		Replies: p.Nreplies,
+		Reposts: p.Nreposts,
+		Reactions: make(map[string]int64),
+		Flags: p.Flags,
+	}
+	if p.Author != nil {
+		ap.Author.Displayname = p.Author.Displayname
+	}
+	if p.Edittimestamp != 0 {
+		ap.Edited = apiTime(p.Edittimestamp)
+	}
+	for _, count := range p.Reactions {
+		ap.Reactions[strings.ToLower(count.Reactiontype.String())] = count.Count
+	}
+	return ap
+}
+
+func toApiPosts(posts []*postpb.Post) []*apiPost {
+	out := make([]*apiPost, len(posts))
+	for idx, p := range posts {
+		out[idx] = toApiPost(p)
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, reply interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(reply)
+}
+
+func writeApiError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": &apiError{Status: status, Message: message}})
+}
+
+// writeApiRateLimited is writeRateLimited for the API.
+func writeApiRateLimited(w http.ResponseWriter, message string, retryafter int64) {
+	w.Header().Set("Retry-After", strconv.FormatInt(retryafter, 10))
+	writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{"error": &apiError{
+		Status: http.StatusTooManyRequests, Message: message, Retryafter: retryafter}})
+}
+
+// api wraps an API handler, refusing methods it does not serve. With
+// needSession set, it also refuses requests without a valid session; the
+// session user is the acting user of the request.
+func (s *FrontendSrv) api(handler http.HandlerFunc, needSession bool, methods ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.record {
+			defer s.p.TptTick(1.0)
+		}
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		allowed := false
+		for _, method := range methods {
+			allowed = allowed || r.Method == method
+		}
+		if !allowed {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+			writeApiError(w, http.StatusMethodNotAllowed, "Method "+r.Method+" not allowed")
+			return
+		}
+		if needSession {
+			session, err := s.parseToken(requestToken(r))
+			if err != nil {
+				writeApiError(w, http.StatusUnauthorized, "Please log in: "+err.Error())
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), sessionCtxKey{}, session))
+		}
+		handler(w, r)
+	})
+}
+
+// decodeBody reads the JSON body of r into v, refusing unknown fields. An
+// empty body leaves v as it is.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, API_MAX_BODY))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil && err != io.EOF {
+		writeApiError(w, http.StatusBadRequest, "Bad request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// parsePage reads the start and stop query parameters of a paged read.
+func parsePage(w http.ResponseWriter, r *http.Request) (int32, int32, bool) {
+	start, stop := int64(0), int64(API_DEFAULT_PAGE)
+	var err1, err2 error
+	if str := r.URL.Query().Get("start"); str != "" {
+		start, err1 = strconv.ParseInt(str, 10, 32)
+	}
+	if str := r.URL.Query().Get("stop"); str != "" {
+		stop, err2 = strconv.ParseInt(str, 10, 32)
+	}
+	if err1 != nil || err2 != nil || start < 0 || stop < start {
+		writeApiError(w, http.StatusBadRequest, "Bad start or stop")
+		return 0, 0, false
+	}
+	return int32(start), int32(stop), true
+}
+
+// pathParams splits what follows prefix in the request path, so that
+// "/api/v1/posts/7/thread" gives ["7", "thread"] for API_PREFIX+"/posts/".
+func pathParams(r *http.Request, prefix string) []string {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if rest == "" {
+		return nil
+	}
+	return strings.Split(rest, "/")
+}
+
+func (s *FrontendSrv) registerApi(mux *http.ServeMux) {
+	mux.Handle(API_PREFIX+"/signup", s.api(s.apiSignupHandler, false, http.MethodPost))
+	mux.Handle(API_PREFIX+"/login", s.api(s.apiLoginHandler, false, http.MethodPost))
+	mux.Handle(API_PREFIX+"/posts", s.api(s.apiComposeHandler, true, http.MethodPost))
+	mux.Handle(API_PREFIX+"/posts/", http.HandlerFunc(s.apiPostRouter))
+	mux.Handle(API_PREFIX+"/users/", http.HandlerFunc(s.apiUserRouter))
+	mux.Handle(API_PREFIX+"/home", s.api(s.apiHomeHandler, true, http.MethodGet))
+	mux.Handle(API_PREFIX+"/home/stream", s.api(s.homeStreamHandler, true, http.MethodGet))
+	mux.Handle(API_PREFIX+"/hashtags/", s.api(s.apiHashtagHandler, false, http.MethodGet))
+}
+
+// apiPostRouter serves /posts/<id>, /posts/<id>/thread and
+// /posts/<id>/reactions.
+func (s *FrontendSrv) apiPostRouter(w http.ResponseWriter, r *http.Request) {
+	params := pathParams(r, API_PREFIX+"/posts/")
+	var handler http.Handler
+	switch {
+	case len(params) == 1:
+		handler = s.api(s.apiPostHandler, false, http.MethodGet)
+	case len(params) == 2 && params[1] == "thread":
+		handler = s.api(s.apiThreadHandler, false, http.MethodGet)
+	case len(params) == 2 && params[1] == "reactions":
+		handler = s.api(s.apiReactionHandler, true, http.MethodPost, http.MethodDelete)
+	default:
+		writeApiError(w, http.StatusNotFound, "No route "+r.URL.Path)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// apiUserRouter serves /users/<id>/timeline, /users/<id>/follow,
+// /users/<id>/followers and /users/<id>/followees.
+func (s *FrontendSrv) apiUserRouter(w http.ResponseWriter, r *http.Request) {
+	params := pathParams(r, API_PREFIX+"/users/")
+	var handler http.Handler
+	switch {
+	case len(params) != 2:
+	case params[1] == "timeline":
+		handler = s.api(s.apiTimelineHandler, false, http.MethodGet)
+	case params[1] == "follow":
+		handler = s.api(s.apiFollowHandler, true, http.MethodPost, http.MethodDelete)
+	case params[1] == "followers" || params[1] == "followees":
+		handler = s.api(s.apiFollowListHandler, false, http.MethodGet)
+	}
+	if handler == nil {
+		writeApiError(w, http.StatusNotFound, "No route "+r.URL.Path)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// pathId parses the id at position idx of the path after prefix.
+func pathId(w http.ResponseWriter, r *http.Request, prefix string, idx int) (int64, bool) {
+	params := pathParams(r, prefix)
+	if len(params) <= idx {
+		writeApiError(w, http.StatusNotFound, "No route "+r.URL.Path)
+		return 0, false
+	}
+	id, err := strconv.ParseInt(params[idx], 10, 64)
+	if err != nil {
+		writeApiError(w, http.StatusBadRequest, "Bad id "+params[idx])
+		return 0, false
+	}
+	return id, true
+}
+
+type apiCredentials struct {
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+}
+
+func (s *FrontendSrv) apiSignupHandler(w http.ResponseWriter, r *http.Request) {
+	req := &apiCredentials{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	if req.Username == "" || req.Password == "" {
+		writeApiError(w, http.StatusBadRequest, "Please specify username and password")
+		return
+	}
+	res, err := s.userc.RegisterUser(r.Context(), &userpb.RegisterUserRequest{
+		Username: req.Username, Password: req.Password,
+		Firstname: req.Firstname, Lastname: req.Lastname})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != user.USER_QUERY_OK {
+		writeApiError(w, http.StatusConflict, res.Ok)
+		return
+	}
+	token, err := s.setSession(w, r, res.Userid, req.Username)
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"userid": apiId(res.Userid), "username": req.Username, "token": token})
+}
+
+func (s *FrontendSrv) apiLoginHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	defer s.uCounter.AddTimeSince(t0)
+	req := &apiCredentials{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	if req.Username == "" || req.Password == "" {
+		writeApiError(w, http.StatusBadRequest, "Please specify username and password")
+		return
+	}
+	res, err := s.userc.Login(
+		r.Context(), &userpb.LoginRequest{Username: req.Username, Password: req.Password})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != user.USER_QUERY_OK {
+		writeApiError(w, http.StatusUnauthorized, "Please check your username and password")
+		return
+	}
+	token, err := s.setSession(w, r, res.Userid, req.Username)
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"userid": apiId(res.Userid), "username": req.Username, "token": token})
+}
+
+type apiComposeRequest struct {
+	Text     string  `json:"text"`
+	Type     string  `json:"type"`
+	Media    []apiId `json:"media"`
+	Parentid apiId   `json:"parentid"`
+	Rootid   apiId   `json:"rootid"`
+}
+
+// apiComposeHandler composes a post as the session user. A post whose steps
+// are still being retried is accepted (202) rather than created (201).
+func (s *FrontendSrv) apiComposeHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	defer s.cCounter.AddTimeSince(t0)
+	req := &apiComposeRequest{}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	session := sessionFromContext(r.Context())
+	mediaids := make([]int64, len(req.Media))
+	for idx, mediaid := range req.Media {
+		mediaids[idx] = int64(mediaid)
+	}
+	posttype := postpb.POST_TYPE_POST
+	if req.Type != "" {
+		var ok bool
+		if posttype, ok = posttypesMap[strings.ToLower(req.Type)]; !ok {
+			writeApiError(w, http.StatusBadRequest, "Bad post type "+req.Type)
+			return
+		}
+	}
+	res, err := s.composec.ComposePost(r.Context(), &composepb.ComposePostRequest{
+		Userid: session.Userid,
+		Username: session.Username,
+		Text: req.Text,
+		Posttype: posttype,
+		Mediaids: mediaids,
+		Parentid: int64(req.Parentid),
+		Rootid: int64(req.Rootid),
+		Idempotencykey: r.Header.Get("Idempotency-Key"),
+	})
+	if err != nil {
+		log.Info().Msgf("Error from compose: %v", err)
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ratelimited {
+		writeApiRateLimited(w, res.Ok, res.Retryafter)
+		return
+	}
+	if res.Ok != compose.COMPOSE_QUERY_OK {
+		writeApiError(w, http.StatusUnprocessableEntity, res.Ok)
+		return
+	}
+	status := http.StatusCreated
+	if res.Pending {
+		status = http.StatusAccepted
+	}
+	w.Header().Set("Location", fmt.Sprintf("%v/posts/%v", API_PREFIX, res.Postid))
+	writeJSON(w, status, map[string]interface{}{
+		"id": apiId(res.Postid), "replayed": res.Replayed, "pending": res.Pending})
+}
+
+func (s *FrontendSrv) apiPostHandler(w http.ResponseWriter, r *http.Request) {
+	postid, ok := pathId(w, r, API_PREFIX+"/posts/", 0)
+	if !ok {
+		return
+	}
+	res, err := s.postc.ReadPosts(r.Context(), &postpb.ReadPostsRequest{Postids: []int64{postid}})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != post.POST_QUERY_OK || len(res.Posts) == 0 {
+		writeApiError(w, http.StatusNotFound, fmt.Sprintf("No post %v", postid))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"post": toApiPost(res.Posts[0])})
+}
+
+func (s *FrontendSrv) apiThreadHandler(w http.ResponseWriter, r *http.Request) {
+	postid, ok := pathId(w, r, API_PREFIX+"/posts/", 0)
+	if !ok {
+		return
+	}
+	start, stop, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	res, err := s.postc.GetThread(
+		r.Context(), &postpb.GetThreadRequest{Postid: postid, Start: start, Stop: stop})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != post.POST_QUERY_OK {
+		writeApiError(w, http.StatusNotFound, res.Ok)
+		return
+	}
+	items := make([]*apiThreadItem, len(res.Items))
+	for idx, item := range res.Items {
+		items[idx] = &apiThreadItem{Depth: item.Depth, Post: toApiPost(item.Post)}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"rootid": apiId(res.Rootid), "total": res.Nitems, "items": items})
+}
+
+type apiReactionRequest struct {
+	Reaction string `json:"reaction"`
+}
+
+// apiReactionHandler sets (POST) or removes (DELETE) the session user's
+// reaction to a post.
+func (s *FrontendSrv) apiReactionHandler(w http.ResponseWriter, r *http.Request) {
+	postid, ok := pathId(w, r, API_PREFIX+"/posts/", 0)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	session := sessionFromContext(ctx)
+	if r.Method == http.MethodDelete {
+		res, err := s.reactionc.Unreact(
+			ctx, &reactionpb.UnreactRequest{Userid: session.Userid, Postid: postid})
+		if err != nil {
+			writeApiError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if res.Ok != reaction.REACTION_QUERY_OK {
+			writeApiError(w, http.StatusUnprocessableEntity, res.Ok)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	req := &apiReactionRequest{Reaction: "like"}
+	if !decodeBody(w, r, req) {
+		return
+	}
+	reactiontype, ok := reactiontypesMap[strings.ToLower(req.Reaction)]
+	if !ok || reactiontype == reactionpb.REACTION_TYPE_ANY {
+		writeApiError(w, http.StatusBadRequest, "Bad reaction type "+req.Reaction)
+		return
+	}
+	// as with /react, only live posts take reactions
+	postRes, err := s.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{postid}})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if postRes.Ok != post.POST_QUERY_OK || len(postRes.Posts) == 0 {
+		writeApiError(w, http.StatusNotFound, fmt.Sprintf("No post %v", postid))
+		return
+	}
+	res, err := s.reactionc.React(ctx, &reactionpb.ReactRequest{
+		Userid: session.Userid, Postid: postid, Reactiontype: reactiontype,
+		Postcreator: postRes.Posts[0].Creator, Username: session.Username})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != reaction.REACTION_QUERY_OK {
+		writeApiError(w, http.StatusUnprocessableEntity, res.Ok)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiTimelineHandler serves /users/<id>/timeline. A session, if any, makes
+// the timeline hide what its user may not see.
+func (s *FrontendSrv) apiTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	defer s.tCounter.AddTimeSince(t0)
+	userid, ok := pathId(w, r, API_PREFIX+"/users/", 0)
+	if !ok {
+		return
+	}
+	start, stop, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	readReq := &tlpb.ReadTimelineRequest{Userid: userid, Start: start, Stop: stop}
+	if session, err := s.parseToken(requestToken(r)); err == nil {
+		readReq.Viewerid, readReq.Hasviewer = session.Userid, true
+	}
+	res, err := s.tlc.ReadTimeline(r.Context(), readReq)
+	s.writeTimeline(w, res, err)
+}
+
+func (s *FrontendSrv) apiHomeHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	defer s.hCounter.AddTimeSince(t0)
+	start, stop, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	session := sessionFromContext(r.Context())
+	res, err := s.homec.ReadHomeTimeline(r.Context(),
+		&tlpb.ReadTimelineRequest{Userid: session.Userid, Start: start, Stop: stop})
+	s.writeTimeline(w, res, err)
+}
+
+// apiFollowHandler makes the session user follow (POST) or unfollow
+// (DELETE) a user.
+func (s *FrontendSrv) apiFollowHandler(w http.ResponseWriter, r *http.Request) {
+	followeeid, ok := pathId(w, r, API_PREFIX+"/users/", 0)
+	if !ok {
+		return
+	}
+	session := sessionFromContext(r.Context())
+	res, err := s.updateFollow(
+		r.Context(), session.Userid, session.Username, followeeid, "", r.Method == http.MethodPost)
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ratelimited {
+		writeApiRateLimited(w, res.Ok, res.Retryafter)
+		return
+	}
+	if res.Ok != graph.GRAPH_QUERY_OK {
+		writeApiError(w, http.StatusUnprocessableEntity, res.Ok)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiFollowListHandler pages through followers or followees with the
+// cursor and limit query parameters; nextcursor is empty after the last
+// page.
+func (s *FrontendSrv) apiFollowListHandler(w http.ResponseWriter, r *http.Request) {
+	userid, ok := pathId(w, r, API_PREFIX+"/users/", 0)
+	if !ok {
+		return
+	}
+	var limit int64
+	if str := r.URL.Query().Get("limit"); str != "" {
+		var err error
+		if limit, err = strconv.ParseInt(str, 10, 32); err != nil || limit < 0 {
+			writeApiError(w, http.StatusBadRequest, "Bad limit")
+			return
+		}
+	}
+	followers := pathParams(r, API_PREFIX+"/users/")[1] == "followers"
+	res, profiles, err := s.followPage(r.Context(), userid, followers, r.URL.Query().Get("cursor"), int32(limit))
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != graph.GRAPH_QUERY_OK {
+		status := http.StatusInternalServerError
+		if strings.HasPrefix(res.Ok, "Bad cursor") {
+			status = http.StatusBadRequest
+		}
+		writeApiError(w, status, res.Ok)
+		return
+	}
+	users := make([]*apiUser, len(res.Userids))
+	for idx, followid := range res.Userids {
+		users[idx] = &apiUser{
+			Userid: apiId(followid), Username: profiles[idx].Username,
+			Displayname: profiles[idx].Displayname}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"users": users, "nextcursor": res.Nextcursor})
+}
+
+func (s *FrontendSrv) writeTimeline(w http.ResponseWriter, res *tlpb.ReadTimelineResponse, err error) {
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	// an empty page is no error; the timeline is empty or the page past its end
+	if res.Ok != timeline.TIMELINE_QUERY_OK && res.Ok != timeline.TIMELINE_QUERY_EMPTY {
+		writeApiError(w, http.StatusInternalServerError, res.Ok)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"posts": toApiPosts(res.Posts)})
+}
+
+// apiHashtagHandler serves /hashtags/<tag>.
+func (s *FrontendSrv) apiHashtagHandler(w http.ResponseWriter, r *http.Request) {
+	params := pathParams(r, API_PREFIX+"/hashtags/")
+	if len(params) != 1 {
+		writeApiError(w, http.StatusNotFound, "No route "+r.URL.Path)
+		return
+	}
+	tag := strings.ToLower(strings.TrimPrefix(params[0], "#"))
+	start, stop, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	res, err := s.hashtagc.ReadHashtagTimeline(r.Context(), &hashtagpb.ReadHashtagTimelineRequest{
+		Hashtag: tag, Start: start, Stop: stop})
+	if err != nil {
+		writeApiError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if res.Ok != hashtag.HASHTAG_QUERY_OK && res.Ok != hashtag.HASHTAG_QUERY_EMPTY {
+		writeApiError(w, http.StatusInternalServerError, res.Ok)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total": res.Nitems, "posts": toApiPosts(res.Posts)})
+}
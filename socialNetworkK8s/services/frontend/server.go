@@ -1,8 +1,12 @@
 package frontend
 
 import (
+	"context"
 	"encoding/json"
+	"expvar"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"strings"
 	"time"
 	"net/url"
@@ -14,10 +18,29 @@ import (
 	tlpb "socialnetworkk8/services/timeline/proto"
 	homepb "socialnetworkk8/services/home/proto"
 	postpb "socialnetworkk8/services/post/proto"
+	dmpb "socialnetworkk8/services/dm/proto"
+	reactionpb "socialnetworkk8/services/reaction/proto"
+	hashtagpb "socialnetworkk8/services/hashtag/proto"
+	searchpb "socialnetworkk8/services/search/proto"
+	notifpb "socialnetworkk8/services/notification/proto"
+	mediapb "socialnetworkk8/services/media/proto"
+	urlpb "socialnetworkk8/services/url/proto"
+	"socialnetworkk8/services/profile"
+	profilepb "socialnetworkk8/services/profile/proto"
+	graphpb "socialnetworkk8/services/graph/proto"
 	"socialnetworkk8/services/user"
 	"socialnetworkk8/services/compose"
 	"socialnetworkk8/services/timeline"
 	"socialnetworkk8/services/home"
+	"socialnetworkk8/services/post"
+	"socialnetworkk8/services/dm"
+	"socialnetworkk8/services/reaction"
+	"socialnetworkk8/services/hashtag"
+	"socialnetworkk8/services/search"
+	"socialnetworkk8/services/notification"
+	"socialnetworkk8/services/media"
+	urlsrv "socialnetworkk8/services/url"
+	"socialnetworkk8/services/graph"
 	"github.com/rs/zerolog/log"
 	"github.com/rs/zerolog"
 	"socialnetworkk8/dialer"
@@ -27,6 +50,11 @@ import (
 	"github.com/opentracing/opentracing-go"
 )
 
+const (
+	UPLOAD_CHUNK_SIZE  = 1 << 20
+	UPLOAD_FORM_SLACK  = 1 << 20
+)
+
 var (
     posttypesMap = map[string]postpb.POST_TYPE {
 		"unknown": postpb.POST_TYPE_UNKNOWN,
@@ -35,6 +63,20 @@ var (
         "reply":   postpb.POST_TYPE_REPLY,
         "dm":      postpb.POST_TYPE_DM,
     }
+	reactiontypesMap = map[string]reactionpb.REACTION_TYPE {
+		"any":   reactionpb.REACTION_TYPE_ANY,
+		"like":  reactionpb.REACTION_TYPE_LIKE,
+		"love":  reactionpb.REACTION_TYPE_LOVE,
+		"laugh": reactionpb.REACTION_TYPE_LAUGH,
+		"wow":   reactionpb.REACTION_TYPE_WOW,
+		"sad":   reactionpb.REACTION_TYPE_SAD,
+		"angry": reactionpb.REACTION_TYPE_ANGRY,
+	}
+	scoringsMap = map[string]graphpb.RECOMMEND_SCORING {
+		"overlap":     graphpb.RECOMMEND_SCORING_OVERLAP,
+		"jaccard":     graphpb.RECOMMEND_SCORING_JACCARD,
+		"adamic-adar": graphpb.RECOMMEND_SCORING_ADAMIC_ADAR,
+	}
 )
 
 // Server implements frontend service
@@ -43,6 +85,17 @@ type FrontendSrv struct {
 	tlc       tlpb.TimelineClient
 	homec     homepb.HomeClient
 	composec  composepb.ComposeClient
+	postc     postpb.PostStorageClient
+	dmc       dmpb.DmClient
+	reactionc reactionpb.ReactionClient
+	hashtagc  hashtagpb.HashtagClient
+	searchc   searchpb.SearchClient
+	notifc    notifpb.NotificationClient
+	mediac    mediapb.MediaStorageClient
+	urlc      urlpb.UrlClient
+	profilec  profilepb.ProfileClient
+	graphc    graphpb.GraphClient
+	sessionKey []byte
 	IpAddr    string
 	Port      int
 	record    bool
@@ -98,11 +151,102 @@ func (s *FrontendSrv) Run() error {
 		return fmt.Errorf("dialer error: %v", err)
 	}
 	s.composec = composepb.NewComposeClient(composeConn)
+	// post client
+	postConn, err := dialer.Dial(
+		post.POST_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.postc = postpb.NewPostStorageClient(postConn)
+	// dm client
+	dmConn, err := dialer.Dial(
+		dm.DM_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.dmc = dmpb.NewDmClient(dmConn)
+	// reaction client
+	reactionConn, err := dialer.Dial(
+		reaction.REACTION_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.reactionc = reactionpb.NewReactionClient(reactionConn)
+	// hashtag client
+	hashtagConn, err := dialer.Dial(
+		hashtag.HASHTAG_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.hashtagc = hashtagpb.NewHashtagClient(hashtagConn)
+	// search client
+	searchConn, err := dialer.Dial(
+		search.SEARCH_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.searchc = searchpb.NewSearchClient(searchConn)
+	// notification client
+	notifConn, err := dialer.Dial(
+		notification.NOTIFICATION_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.notifc = notifpb.NewNotificationClient(notifConn)
+	// media client
+	mediaConn, err := dialer.Dial(
+		media.MEDIA_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.mediac = mediapb.NewMediaStorageClient(mediaConn)
+	// url client
+	urlConn, err := dialer.Dial(
+		urlsrv.URL_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.urlc = urlpb.NewUrlClient(urlConn)
+	// profile client
+	profileConn, err := dialer.Dial(
+		profile.PROFILE_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.profilec = profilepb.NewProfileClient(profileConn)
+	// graph client
+	graphConn, err := dialer.Dial(
+		graph.GRAPH_SRV_NAME,
+		s.Registry.Client,
+		dialer.WithTracer(s.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	s.graphc = graphpb.NewGraphClient(graphConn)
 	s.uCounter = tracing.MakeCounter("Front-User")
 	s.iCounter = tracing.MakeCounter("User-Inner")
 	s.hCounter = tracing.MakeCounter("Front-Home")
 	s.tCounter = tracing.MakeCounter("Front-Timeline")
 	s.cCounter = tracing.MakeCounter("Front-Compose")
+	s.sessionKey = loadSessionKey()
 	s.p = MakePerf("social-network-perf/k8s", "social-netowrk")
 	s.record = false
 
@@ -114,13 +258,39 @@ func (s *FrontendSrv) Run() error {
 	//mux := tracing.NewServeMux(s.Tracer)
 	mux := http.NewServeMux()
 	mux.Handle("/echo", http.HandlerFunc(s.echoHandler))
+	mux.Handle("/signup", http.HandlerFunc(s.signupHandler))
+	mux.Handle("/login", http.HandlerFunc(s.userHandler))
 	mux.Handle("/user", http.HandlerFunc(s.userHandler))
-	mux.Handle("/compose", http.HandlerFunc(s.composeHandler))
-	mux.Handle("/timeline", http.HandlerFunc(s.timelineHandler))
-	mux.Handle("/home", http.HandlerFunc(s.homeHandler))
+	// routes acting for a user need a session of that user
+	mux.Handle("/compose", s.withSession(s.composeHandler, true, true))
+	mux.Handle("/timeline", s.withSession(s.timelineHandler, false, false))
+	mux.Handle("/home", s.withSession(s.homeHandler, true, false))
+	mux.Handle("/home/stream", s.withSession(s.homeStreamHandler, true, false))
+	mux.Handle("/thread", http.HandlerFunc(s.threadHandler))
+	mux.Handle("/conversations", s.withSession(s.conversationsHandler, true, false))
+	mux.Handle("/conversation", s.withSession(s.conversationHandler, true, false))
+	mux.Handle("/react", s.withSession(s.reactHandler, true, true))
+	mux.Handle("/unreact", s.withSession(s.unreactHandler, true, true))
+	mux.Handle("/reactors", http.HandlerFunc(s.reactorsHandler))
+	mux.Handle("/hashtag", http.HandlerFunc(s.hashtagHandler))
+	mux.Handle("/search", http.HandlerFunc(s.searchHandler))
+	mux.Handle("/notifications", s.withSession(s.notificationsHandler, true, false))
+	mux.Handle("/notifications/read", s.withSession(s.markReadHandler, true, true))
+	mux.Handle("/upload", s.withSession(s.uploadHandler, true, true))
+	mux.Handle("/media", http.HandlerFunc(s.mediaHandler))
+	mux.Handle("/s/", http.HandlerFunc(s.shortUrlHandler))
+	mux.Handle("/profile", http.HandlerFunc(s.profileHandler))
+	mux.Handle("/profile/update", s.withSession(s.updateProfileHandler, true, true))
+	mux.Handle("/recommend", s.withSession(s.recommendHandler, true, true))
+	mux.Handle("/follow", s.withSession(s.followHandler, true, true))
+	mux.Handle("/unfollow", s.withSession(s.unfollowHandler, true, true))
+	mux.Handle("/followers", http.HandlerFunc(s.followersHandler))
+	mux.Handle("/followees", http.HandlerFunc(s.followeesHandler))
 	mux.Handle("/saveresults", http.HandlerFunc(s.saveResultsHandler))
 	mux.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/startrecording", http.HandlerFunc(s.startRecordingHandler))
+	s.registerApi(mux)
+	mux.Handle("/debug/vars", expvar.Handler())
 	log.Trace().Msg("frontend starts serving")
 
 	tlsconfig := tls.GetHttpsOpt()
@@ -164,11 +334,9 @@ func (s *FrontendSrv) userHandler(w http.ResponseWriter, r *http.Request) {
 		defer s.p.TptTick(1.0)
 	}
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	rawQuery, _ := url.QueryUnescape(r.URL.RawQuery)
-	urlQuery, _ := url.ParseQuery(rawQuery)
-	log.Debug().Msgf("user request %v\n", rawQuery)
+	log.Debug().Msgf("user request %v\n", r.FormValue("username"))
 
-	username, password := urlQuery.Get("username"), urlQuery.Get("password")
+	username, password := r.FormValue("username"), r.FormValue("password")
 	if username == "" || password == "" {
 		http.Error(w, "Please specify username and password", http.StatusBadRequest)
 		return
@@ -182,15 +350,60 @@ func (s *FrontendSrv) userHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	str := "Login successfully!"
 	if res.Ok != user.USER_QUERY_OK {
-		str = "Failed. Please check your username and password. "
+		reply := map[string]interface{}{"message": "Failed. Please check your username and password. "}
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(reply)
+		return
 	}
-	reply := map[string]interface{}{"message": str}
+	token, err := s.setSession(w, r, res.Userid, username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reply := map[string]interface{}{
+		"message": "Login successfully!", "userid": res.Userid, "token": token}
 	json.NewEncoder(w).Encode(reply)
 	s.uCounter.AddTimeSince(t0)
 }
 
+// signupHandler registers a user and logs them in. Like login, it reads its
+// parameters from a POST form as well as the query, so clients can keep
+// passwords out of URLs.
+func (s *FrontendSrv) signupHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	log.Debug().Msgf("Signup request %v\n", r.FormValue("username"))
+	username, password := r.FormValue("username"), r.FormValue("password")
+	if username == "" || password == "" {
+		http.Error(w, "Please specify username and password", http.StatusBadRequest)
+		return
+	}
+	res, err := s.userc.RegisterUser(r.Context(), &userpb.RegisterUserRequest{
+		Username: username, Password: password,
+		Firstname: r.FormValue("firstname"), Lastname: r.FormValue("lastname")})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if res.Ok != user.USER_QUERY_OK {
+		reply := map[string]interface{}{"message": "Signup Failed!" + res.Ok}
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(reply)
+		return
+	}
+	token, err := s.setSession(w, r, res.Userid, username)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reply := map[string]interface{}{
+		"message": "Signup successfully!", "userid": res.Userid, "token": token}
+	json.NewEncoder(w).Encode(reply)
+}
+
 func (s *FrontendSrv) composeHandler(w http.ResponseWriter, r *http.Request) {
 	t0 := time.Now()
 	defer s.cCounter.AddTimeSince(t0)
@@ -198,8 +411,7 @@ func (s *FrontendSrv) composeHandler(w http.ResponseWriter, r *http.Request) {
 		defer s.p.TptTick(1.0)
 	}
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	rawQuery, _ := url.QueryUnescape(r.URL.RawQuery)
-	urlQuery, _ := url.ParseQuery(rawQuery)
+	urlQuery := requestQuery(r)
 	log.Debug().Msgf("Compose request: %v\n", urlQuery)
 	username, useridstr := urlQuery.Get("username"), urlQuery.Get("userid")
 	ctx := r.Context()
@@ -242,23 +454,47 @@ func (s *FrontendSrv) composeHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	var parentid, rootid int64
+	parentstr, rootstr := urlQuery.Get("parentid"), urlQuery.Get("rootid")
+	if parentstr != "" {
+		var err error
+		if parentid, err = strconv.ParseInt(parentstr, 10, 64); err != nil {
+			http.Error(w, "bad parent id format", http.StatusBadRequest)
+			return
+		}
+	}
+	if rootstr != "" {
+		var err error
+		if rootid, err = strconv.ParseInt(rootstr, 10, 64); err != nil {
+			http.Error(w, "bad root id format", http.StatusBadRequest)
+			return
+		}
+	}
 	res, err := s.composec.ComposePost(ctx, &composepb.ComposePostRequest{
 		Userid: userid,
 		Username: username,
 		Text: text,
 		Posttype: parsePostTypeString(posttype),
 		Mediaids: mediaids,
+		Parentid: parentid,
+		Rootid: rootid,
+		Idempotencykey: r.Header.Get("Idempotency-Key"),
 	})
 	if err != nil {
 		log.Info().Msgf("Error from compose: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if res.Ratelimited {
+		writeRateLimited(w, res.Ok, res.Retryafter)
+		return
+	}
 	str := "Compose successfully!"
 	if res.Ok != compose.COMPOSE_QUERY_OK {
 		str = res.Ok
 	}
-	reply := map[string]interface{}{"message": str}
+	reply := map[string]interface{}{"message": str, "postid": res.Postid, "replayed": res.Replayed,
+		"pending": res.Pending}
 	json.NewEncoder(w).Encode(reply)
 }
 
@@ -280,8 +516,7 @@ func (s *FrontendSrv) timelineHandlerInner(w http.ResponseWriter, r *http.Reques
 	}
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx := r.Context()
-	rawQuery, _ := url.QueryUnescape(r.URL.RawQuery)
-	urlQuery, _ := url.ParseQuery(rawQuery)
+	urlQuery := requestQuery(r)
 	debugInfo := "Timeline request"
 	if isHome {
 		debugInfo = "Home timeline request"
@@ -311,8 +546,11 @@ func (s *FrontendSrv) timelineHandlerInner(w http.ResponseWriter, r *http.Reques
 		res, err = s.homec.ReadHomeTimeline(
 			ctx, &tlpb.ReadTimelineRequest{Userid: userid, Start: int32(start), Stop: int32(stop)})
 	} else {
-		res, err = s.tlc.ReadTimeline(
-			ctx, &tlpb.ReadTimelineRequest{Userid: userid, Start: int32(start), Stop: int32(stop)})
+		readReq := &tlpb.ReadTimelineRequest{Userid: userid, Start: int32(start), Stop: int32(stop)}
+		if session := sessionFromContext(ctx); session != nil {
+			readReq.Viewerid, readReq.Hasviewer = session.Userid, true
+		}
+		res, err = s.tlc.ReadTimeline(ctx, readReq)
 	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -320,53 +558,1187 @@ func (s *FrontendSrv) timelineHandlerInner(w http.ResponseWriter, r *http.Reques
 	}
 	str := "Timeline successfully!"
 	postCreators := ""
+	postAuthors := ""
 	postTimes := ""
 	postContents := ""
+	postReactions := ""
 	if res.Ok != timeline.TIMELINE_QUERY_OK {
 		str = "Timeline Failed!" + res.Ok
 	} else {
 		for _, post := range res.Posts {
 			postTimes += time.Unix(0, post.Timestamp).Format(time.UnixDate) + "; "
 			postCreators += post.Creatoruname + "; "
+			postAuthors += authorName(post) + "; "
 			postContents += post.Text + "; "
+			postReactions += formatReactions(post) + "; "
 		}
 	}
 	reply := map[string]interface{}{
-		"message": str, "times": postTimes, "contents": postContents, "creators": postCreators}
+		"message": str, "times": postTimes, "contents": postContents, "creators": postCreators,
+		"authors": postAuthors, "reactions": postReactions}
 	json.NewEncoder(w).Encode(reply)
 }
 
-func (s *FrontendSrv) startRecordingHandler(w http.ResponseWriter, r *http.Request) {
-
-	s.record = true
-
+func (s *FrontendSrv) threadHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-
-	log.Debug().Msg("Start recording!")
-
-	str := "Started recording!"
-
-	reply := map[string]interface{}{
-		"message": str,
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Thread request: %v\n", urlQuery)
+	postidstr, startstr, stopstr := 
+		urlQuery.Get("postid"), urlQuery.Get("start"), urlQuery.Get("stop")
+	var err1, err2, err3 error
+	var start, stop int64
+	postid, err1 := strconv.ParseInt(postidstr, 10, 64)
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err2 = strconv.ParseInt(startstr, 10, 32)
 	}
-
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err3 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	if start < 0 {
+		http.Error(w, "start must not be negative", http.StatusBadRequest)
+		return
+	}
+	res, err := s.postc.GetThread(
+		ctx, &postpb.GetThreadRequest{Postid: postid, Start: int32(start), Stop: int32(stop)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Thread successfully!"
+	postIds := ""
+	postParents := ""
+	postDepths := ""
+	postCreators := ""
+	postAuthors := ""
+	postTimes := ""
+	postContents := ""
+	postCounts := ""
+	postReactions := ""
+	if res.Ok != post.POST_QUERY_OK {
+		str = "Thread Failed!" + res.Ok
+	} else {
+		for _, item := range res.Items {
+			postIds += strconv.FormatInt(item.Post.Postid, 10) + "; "
+			postParents += strconv.FormatInt(item.Post.Parentid, 10) + "; "
+			postDepths += strconv.Itoa(int(item.Depth)) + "; "
+			postTimes += time.Unix(0, item.Post.Timestamp).Format(time.UnixDate) + "; "
+			postCreators += item.Post.Creatoruname + "; "
+			postAuthors += authorName(item.Post) + "; "
+			postContents += item.Post.Text + "; "
+			postCounts += fmt.Sprintf("%v replies %v reposts; ", item.Post.Nreplies, item.Post.Nreposts)
+			postReactions += formatReactions(item.Post) + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "rootid": res.Rootid, "total": res.Nitems, "postids": postIds,
+		"parents": postParents, "depths": postDepths, "times": postTimes,
+		"contents": postContents, "creators": postCreators, "authors": postAuthors,
+		"counts": postCounts, "reactions": postReactions}
 	json.NewEncoder(w).Encode(reply)
 }
 
-func (s *FrontendSrv) saveResultsHandler(w http.ResponseWriter, r *http.Request) {
+func (s *FrontendSrv) conversationsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
 	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Conversations request: %v\n", urlQuery)
+	useridstr, startstr, stopstr := 
+		urlQuery.Get("userid"), urlQuery.Get("start"), urlQuery.Get("stop")
+	var err1, err2, err3 error
+	var start, stop int64
+	userid, err1 := strconv.ParseInt(useridstr, 10, 64)
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err2 = strconv.ParseInt(startstr, 10, 32)
+	}
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err3 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	if start < 0 {
+		http.Error(w, "start must not be negative", http.StatusBadRequest)
+		return
+	}
+	res, err := s.dmc.ListConversations(ctx, &dmpb.ListConversationsRequest{
+		Userid: userid, Start: int32(start), Stop: int32(stop)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Conversations successfully!"
+	convIds := ""
+	convTimes := ""
+	convPreviews := ""
+	convCounts := ""
+	if res.Ok != dm.DM_QUERY_OK {
+		str = "Conversations Failed!" + res.Ok
+	} else {
+		for _, conv := range res.Conversations {
+			convIds += conv.Conversationid + "; "
+			convTimes += time.Unix(0, conv.Lasttimestamp).Format(time.UnixDate) + "; "
+			convPreviews += conv.Lastpreview + "; "
+			convCounts += strconv.FormatInt(conv.Nmessages, 10) + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "conversationids": convIds, "times": convTimes,
+		"previews": convPreviews, "counts": convCounts}
+	json.NewEncoder(w).Encode(reply)
+}
 
-	s.p.Done()
-
-	str := "Done!"
-
-	res := map[string]interface{}{
-		"message": str,
+func (s *FrontendSrv) conversationHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Conversation request: %v\n", urlQuery)
+	useridstr, convid, startstr, stopstr := urlQuery.Get("userid"), 
+		urlQuery.Get("conversationid"), urlQuery.Get("start"), urlQuery.Get("stop")
+	var err1, err2, err3 error
+	var start, stop int64
+	userid, err1 := strconv.ParseInt(useridstr, 10, 64)
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err2 = strconv.ParseInt(startstr, 10, 32)
+	}
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err3 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	if convid == "" {
+		http.Error(w, "Please specify conversationid", http.StatusBadRequest)
+		return
+	}
+	res, err := s.dmc.ReadConversation(ctx, &dmpb.ReadConversationRequest{
+		Userid: userid, Conversationid: convid, Start: int32(start), Stop: int32(stop)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
+	str := "Conversation successfully!"
+	msgSenders := ""
+	msgTimes := ""
+	msgContents := ""
+	if res.Ok != dm.DM_QUERY_OK {
+		str = "Conversation Failed!" + res.Ok
+	} else {
+		for _, msg := range res.Messages {
+			msgTimes += time.Unix(0, msg.Timestamp).Format(time.UnixDate) + "; "
+			msgSenders += msg.Senderuname + "; "
+			msgContents += msg.Text + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "times": msgTimes, "contents": msgContents, "senders": msgSenders}
+	json.NewEncoder(w).Encode(reply)
+}
 
-	json.NewEncoder(w).Encode(res)
+func (s *FrontendSrv) hashtagHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Hashtag request: %v\n", urlQuery)
+	tag, startstr, stopstr := urlQuery.Get("tag"), urlQuery.Get("start"), urlQuery.Get("stop")
+	// accept both "tag=go" and "tag=#go"; tags are indexed lower-cased
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	if tag == "" {
+		http.Error(w, "Please specify tag", http.StatusBadRequest)
+		return
+	}
+	var err1, err2 error
+	var start, stop int64
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err1 = strconv.ParseInt(startstr, 10, 32)
+	}
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err2 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	res, err := s.hashtagc.ReadHashtagTimeline(ctx, &hashtagpb.ReadHashtagTimelineRequest{
+		Hashtag: tag, Start: int32(start), Stop: int32(stop)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Hashtag successfully!"
+	postCreators := ""
+	postAuthors := ""
+	postTimes := ""
+	postContents := ""
+	postReactions := ""
+	if res.Ok != hashtag.HASHTAG_QUERY_OK {
+		str = "Hashtag Failed!" + res.Ok
+	} else {
+		for _, post := range res.Posts {
+			postTimes += time.Unix(0, post.Timestamp).Format(time.UnixDate) + "; "
+			postCreators += post.Creatoruname + "; "
+			postAuthors += authorName(post) + "; "
+			postContents += post.Text + "; "
+			postReactions += formatReactions(post) + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "total": res.Nitems, "times": postTimes, "contents": postContents,
+		"creators": postCreators, "authors": postAuthors, "reactions": postReactions}
+	json.NewEncoder(w).Encode(reply)
 }
 
+func (s *FrontendSrv) searchHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Search request: %v\n", urlQuery)
+	query, author := urlQuery.Get("q"), urlQuery.Get("author")
+	if query == "" {
+		http.Error(w, "Please specify q", http.StatusBadRequest)
+		return
+	}
+	sincestr, untilstr, startstr, stopstr := urlQuery.Get("since"), urlQuery.Get("until"), 
+		urlQuery.Get("start"), urlQuery.Get("stop")
+	var err1, err2, err3, err4 error
+	var since, until, start, stop int64
+	// since and until are unix seconds; post timestamps are in nanoseconds
+	if sincestr != "" {
+		since, err1 = strconv.ParseInt(sincestr, 10, 64)
+	}
+	if untilstr != "" {
+		until, err2 = strconv.ParseInt(untilstr, 10, 64)
+	}
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err3 = strconv.ParseInt(startstr, 10, 32)
+	}
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err4 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	searchReq := &searchpb.SearchPostsRequest{
+		Query: query, Start: int32(start), Stop: int32(stop),
+		Since: since * int64(time.Second), Until: until * int64(time.Second)}
+	if author != "" {
+		userRes, err := s.userc.CheckUser(ctx, &userpb.CheckUserRequest{Usernames: []string{author}})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if userRes.Ok != user.USER_QUERY_OK {
+			http.Error(w, "bad author name", http.StatusBadRequest)
+			return
+		}
+		searchReq.Creators = userRes.Userids
+	}
+	res, err := s.searchc.SearchPosts(ctx, searchReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Search successfully!"
+	postIds := ""
+	postCreators := ""
+	postTimes := ""
+	postContents := ""
+	if res.Ok != search.SEARCH_QUERY_OK {
+		str = "Search Failed!" + res.Ok
+	} else if len(res.Postids) > 0 {
+		postRes, err := s.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: res.Postids})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, post := range postRes.Posts {
+			postIds += strconv.FormatInt(post.Postid, 10) + "; "
+			postTimes += time.Unix(0, post.Timestamp).Format(time.UnixDate) + "; "
+			postCreators += post.Creatoruname + "; "
+			postContents += post.Text + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "total": res.Nhits, "postids": postIds, "times": postTimes,
+		"contents": postContents, "creators": postCreators}
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *FrontendSrv) reactHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("React request: %v\n", urlQuery)
+	useridstr, postidstr, reactionstr := 
+		urlQuery.Get("userid"), urlQuery.Get("postid"), urlQuery.Get("reaction")
+	userid, err1 := strconv.ParseInt(useridstr, 10, 64)
+	postid, err2 := strconv.ParseInt(postidstr, 10, 64)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	if reactionstr == "" {
+		reactionstr = "like"
+	}
+	reactiontype, ok := reactiontypesMap[strings.ToLower(reactionstr)]
+	if !ok || reactiontype == reactionpb.REACTION_TYPE_ANY {
+		http.Error(w, "bad reaction type", http.StatusBadRequest)
+		return
+	}
+	// the reaction service does not know about posts; only react to live ones
+	postRes, err := s.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{postid}})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "React successfully!"
+	if postRes.Ok != post.POST_QUERY_OK || len(postRes.Posts) == 0 {
+		str = "React Failed! No post " + postidstr
+	} else {
+		res, err := s.reactionc.React(ctx, &reactionpb.ReactRequest{
+			Userid: userid, Postid: postid, Reactiontype: reactiontype,
+			Postcreator: postRes.Posts[0].Creator, Username: urlQuery.Get("username")})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if res.Ok != reaction.REACTION_QUERY_OK {
+			str = "React Failed!" + res.Ok
+		}
+	}
+	reply := map[string]interface{}{"message": str}
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *FrontendSrv) unreactHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Unreact request: %v\n", urlQuery)
+	userid, err1 := strconv.ParseInt(urlQuery.Get("userid"), 10, 64)
+	postid, err2 := strconv.ParseInt(urlQuery.Get("postid"), 10, 64)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	res, err := s.reactionc.Unreact(ctx, &reactionpb.UnreactRequest{Userid: userid, Postid: postid})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Unreact successfully!"
+	if res.Ok != reaction.REACTION_QUERY_OK {
+		str = "Unreact Failed!" + res.Ok
+	}
+	reply := map[string]interface{}{"message": str}
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *FrontendSrv) reactorsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Reactors request: %v\n", urlQuery)
+	postidstr, reactionstr, startstr, stopstr := urlQuery.Get("postid"), 
+		urlQuery.Get("reaction"), urlQuery.Get("start"), urlQuery.Get("stop")
+	var err1, err2, err3 error
+	var start, stop int64
+	postid, err1 := strconv.ParseInt(postidstr, 10, 64)
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err2 = strconv.ParseInt(startstr, 10, 32)
+	}
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err3 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	if reactionstr == "" {
+		reactionstr = "any"
+	}
+	reactiontype, ok := reactiontypesMap[strings.ToLower(reactionstr)]
+	if !ok {
+		http.Error(w, "bad reaction type", http.StatusBadRequest)
+		return
+	}
+	res, err := s.reactionc.ListReactors(ctx, &reactionpb.ListReactorsRequest{
+		Postid: postid, Reactiontype: reactiontype, Start: int32(start), Stop: int32(stop)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Reactors successfully!"
+	userIds := ""
+	reactionTypes := ""
+	if res.Ok != reaction.REACTION_QUERY_OK {
+		str = "Reactors Failed!" + res.Ok
+	} else {
+		for idx, userid := range res.Userids {
+			userIds += strconv.FormatInt(userid, 10) + "; "
+			reactionTypes += strings.ToLower(res.Reactiontypes[idx].String()) + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "userids": userIds, "reactions": reactionTypes}
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *FrontendSrv) notificationsHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Notifications request: %v\n", urlQuery)
+	useridstr, startstr, stopstr := 
+		urlQuery.Get("userid"), urlQuery.Get("start"), urlQuery.Get("stop")
+	var err1, err2, err3 error
+	var start, stop int64
+	userid, err1 := strconv.ParseInt(useridstr, 10, 64)
+	if startstr == "" {
+		start = 0
+	} else {
+		start, err2 = strconv.ParseInt(startstr, 10, 32)
+	}
+	if stopstr == "" {
+		stop = 10
+	} else {
+		stop, err3 = strconv.ParseInt(stopstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	res, err := s.notifc.ReadNotifications(ctx, &notifpb.ReadNotificationsRequest{
+		Userid: userid, Start: int32(start), Stop: int32(stop), 
+		Unreadonly: urlQuery.Get("unread") == "true"})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Notifications successfully!"
+	notifIds := ""
+	notifTypes := ""
+	notifActors := ""
+	notifPosts := ""
+	notifTimes := ""
+	if res.Ok != notification.NOTIFICATION_QUERY_OK {
+		str = "Notifications Failed!" + res.Ok
+	} else {
+		for _, notif := range res.Notifications {
+			notifIds += strconv.FormatInt(notif.Notificationid, 10) + "; "
+			notifTypes += strings.ToLower(notif.Notificationtype.String()) + "; "
+			notifActors += notif.Actoruname + "; "
+			notifPosts += strconv.FormatInt(notif.Postid, 10) + "; "
+			notifTimes += time.Unix(0, notif.Timestamp).Format(time.UnixDate) + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": str, "unread": res.Nunread, "notificationids": notifIds, "types": notifTypes,
+		"actors": notifActors, "postids": notifPosts, "times": notifTimes}
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *FrontendSrv) markReadHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Mark read request: %v\n", urlQuery)
+	userid, err := strconv.ParseInt(urlQuery.Get("userid"), 10, 64)
+	if err != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	markReq := &notifpb.MarkReadRequest{Userid: userid, All: urlQuery.Get("all") == "true"}
+	if idsstr := urlQuery.Get("ids"); !markReq.All && idsstr != "" {
+		for _, idstr := range strings.Split(idsstr, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(idstr), 10, 64)
+			if err != nil {
+				http.Error(w, "bad number format in request", http.StatusBadRequest)
+				return
+			}
+			markReq.Notificationids = append(markReq.Notificationids, id)
+		}
+	}
+	res, err := s.notifc.MarkRead(ctx, markReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Mark read successfully!"
+	if res.Ok != notification.NOTIFICATION_QUERY_OK {
+		str = "Mark read Failed!" + res.Ok
+	}
+	reply := map[string]interface{}{"message": str}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// uploadHandler streams the "media" file of a multipart form to the media
+// service in chunks as it arrives, so no more than a chunk of it is held.
+func (s *FrontendSrv) uploadHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if r.Method != http.MethodPost {
+		http.Error(w, "Please upload with POST", http.StatusMethodNotAllowed)
+		return
+	}
+	// leave room for the multipart framing around the file
+	r.Body = http.MaxBytesReader(w, r.Body, media.MEDIA_MAX_SIZE+UPLOAD_FORM_SLACK)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "bad multipart form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var part *multipart.Part
+	for {
+		if part, err = reader.NextPart(); err != nil {
+			http.Error(w, "Please specify media", http.StatusBadRequest)
+			return
+		}
+		if part.FormName() == "media" {
+			break
+		}
+	}
+	defer part.Close()
+	log.Debug().Msgf("Upload request: %v\n", part.FileName())
+	// the first chunk is enough to sniff the type before anything is stored
+	chunk := make([]byte, UPLOAD_CHUNK_SIZE)
+	n, readErr := io.ReadFull(part, chunk)
+	if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+		http.Error(w, "bad upload: "+readErr.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if mediatype := media.SniffMediaType(chunk[:n]); !media.MEDIA_TYPES[mediatype] {
+		http.Error(w, "unsupported media type "+mediatype, http.StatusUnsupportedMediaType)
+		return
+	}
+	// cancelling the stream keeps a partly sent upload from being stored
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	stream, err := s.mediac.StoreMediaStream(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	mediatype := part.Header.Get("Content-Type")
+	for size := 0; n > 0; {
+		size += n
+		if size > media.MEDIA_MAX_SIZE {
+			http.Error(w, fmt.Sprintf("media is larger than %v bytes", media.MEDIA_MAX_SIZE),
+				http.StatusRequestEntityTooLarge)
+			return
+		}
+		req := &mediapb.StoreMediaRequest{Mediadata: chunk[:n]}
+		if size == n {
+			req.Mediatype = mediatype
+		}
+		if err := stream.Send(req); err != nil {
+			// the server closed the stream early; its answer says why
+			break
+		}
+		if readErr != nil {
+			break
+		}
+		n, readErr = io.ReadFull(part, chunk)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			http.Error(w, "bad upload: "+readErr.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Upload successfully!"
+	if res.Ok != media.MEDIA_QUERY_OK {
+		str = "Upload Failed!" + res.Ok
+	}
+	reply := map[string]interface{}{
+		"message": str, "mediaid": res.Mediaid, "mediatype": res.Mediatype}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// mediaHandler serves the content of a media, or its thumbnail. Content is
+// streamed from the media service and honors a single-range Range header.
+func (s *FrontendSrv) mediaHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Media request: %v\n", urlQuery)
+	mediaid, err := strconv.ParseInt(urlQuery.Get("mediaid"), 10, 64)
+	if err != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	if urlQuery.Get("thumbnail") == "true" {
+		res, err := s.mediac.ReadMedia(ctx, &mediapb.ReadMediaRequest{
+			Mediaids: []int64{mediaid}, Thumbnail: true})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if res.Ok != media.MEDIA_QUERY_OK {
+			http.Error(w, "Media Failed!"+res.Ok, http.StatusNotFound)
+			return
+		}
+		if len(res.Mediadatas[0]) == 0 {
+			http.Error(w, "No thumbnail for media", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", res.Mediatypes[0])
+		w.Write(res.Mediadatas[0])
+		return
+	}
+	offset, length, isRange, ok := parseRange(r.Header.Get("Range"))
+	if !ok {
+		http.Error(w, "bad range", http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	stream, err := s.mediac.ReadMediaRange(ctx, &mediapb.ReadMediaRangeRequest{
+		Mediaid: mediaid, Offset: offset, Length: length})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if first.Ok != media.MEDIA_QUERY_OK {
+		status := http.StatusNotFound
+		if isRange {
+			status = http.StatusRequestedRangeNotSatisfiable
+		}
+		http.Error(w, "Media Failed!"+first.Ok, status)
+		return
+	}
+	sent := first.Size - offset
+	if length > 0 && length < sent {
+		sent = length
+	}
+	if isRange && sent <= 0 {
+		http.Error(w, "bad range", http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	w.Header().Set("Content-Type", first.Mediatype)
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("Content-Length", strconv.FormatInt(sent, 10))
+	if isRange {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %v-%v/%v", offset, offset+sent-1, first.Size))
+		w.WriteHeader(http.StatusPartialContent)
+	}
+	for res := first; ; {
+		if _, err := w.Write(res.Data); err != nil {
+			return
+		}
+		if res, err = stream.Recv(); err != nil {
+			if err != io.EOF {
+				log.Error().Msgf("Error streaming media %v: %v", mediaid, err)
+			}
+			return
+		}
+	}
+}
+
+// parseRange reads a "bytes=start-end" or "bytes=start-" Range header into an
+// offset and a length, 0 meaning up to the end.
+func parseRange(header string) (int64, int64, bool, bool) {
+	if header == "" {
+		return 0, 0, false, true
+	}
+	spec := strings.TrimPrefix(header, "bytes=")
+	bounds := strings.Split(spec, "-")
+	if spec == header || len(bounds) != 2 || bounds[0] == "" {
+		return 0, 0, true, false
+	}
+	start, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, true, false
+	}
+	if bounds[1] == "" {
+		return start, 0, true, true
+	}
+	end, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil || end < start {
+		return 0, 0, true, false
+	}
+	return start, end - start + 1, true, true
+}
+
+// writeRateLimited answers a request refused by a rate limit with 429 and
+// the seconds to wait in Retry-After.
+func writeRateLimited(w http.ResponseWriter, message string, retryafter int64) {
+	w.Header().Set("Retry-After", strconv.FormatInt(retryafter, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+	reply := map[string]interface{}{"message": message, "retryafter": retryafter}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// shortUrlHandler redirects /s/<code> to the url behind the short url
+// http://short-url/<code>, counting the click.
+func (s *FrontendSrv) shortUrlHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	code := strings.TrimPrefix(r.URL.Path, "/s/")
+	log.Debug().Msgf("Short url request: %v\n", code)
+	if code == "" || strings.Contains(code, "/") {
+		http.Error(w, "Please specify a short url", http.StatusBadRequest)
+		return
+	}
+	res, err := s.urlc.GetUrls(ctx, &urlpb.GetUrlsRequest{
+		Shorturls: []string{urlsrv.URL_HOSTNAME + code}, Click: true})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if res.Ok != urlsrv.URL_QUERY_OK {
+		http.NotFound(w, r)
+		return
+	}
+	// only ever send users on to web pages
+	target, err := url.Parse(res.Extendedurls[0])
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") {
+		http.NotFound(w, r)
+		return
+	}
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+func (s *FrontendSrv) profileHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Profile request: %v\n", urlQuery)
+	userid, err := strconv.ParseInt(urlQuery.Get("userid"), 10, 64)
+	if err != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	res, err := s.profilec.GetUsers(ctx, &profilepb.GetUsersRequest{Userids: []int64{userid}})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if res.Ok != profile.PROFILE_QUERY_OK {
+		reply := map[string]interface{}{"message": "Profile Failed!" + res.Ok}
+		json.NewEncoder(w).Encode(reply)
+		return
+	}
+	userProfile := res.Profiles[0]
+	reply := map[string]interface{}{
+		"message": "Profile successfully!", "userid": userProfile.Userid,
+		"username": userProfile.Username, "displayname": userProfile.Displayname,
+		"bio": userProfile.Bio, "avatar": userProfile.Avatarmediaid,
+		"createdat": time.Unix(0, userProfile.Createdat).Format(time.UnixDate)}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// updateProfileHandler changes only the profile fields present in the query,
+// so "bio=" clears the bio while leaving out "bio" keeps it.
+func (s *FrontendSrv) updateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Update profile request: %v\n", urlQuery)
+	userid, err := strconv.ParseInt(urlQuery.Get("userid"), 10, 64)
+	if err != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	updateReq := &profilepb.UpdateProfileRequest{Userid: userid}
+	if urlQuery.Has("displayname") {
+		updateReq.Displayname = urlQuery.Get("displayname")
+		updateReq.Fields = append(updateReq.Fields, "displayname")
+	}
+	if urlQuery.Has("bio") {
+		updateReq.Bio = urlQuery.Get("bio")
+		updateReq.Fields = append(updateReq.Fields, "bio")
+	}
+	if urlQuery.Has("avatar") {
+		updateReq.Avatarmediaid, err = strconv.ParseInt(urlQuery.Get("avatar"), 10, 64)
+		if err != nil {
+			http.Error(w, "bad number format in request", http.StatusBadRequest)
+			return
+		}
+		updateReq.Fields = append(updateReq.Fields, "avatarmediaid")
+	}
+	if len(updateReq.Fields) == 0 {
+		http.Error(w, "Please specify displayname, bio or avatar", http.StatusBadRequest)
+		return
+	}
+	res, err := s.profilec.UpdateProfile(ctx, updateReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	str := "Update profile successfully!"
+	if res.Ok != profile.PROFILE_QUERY_OK {
+		str = "Update profile Failed!" + res.Ok
+	}
+	reply := map[string]interface{}{"message": str}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// recommendHandler suggests users to follow, best first. scoring is one of
+// overlap (the default), jaccard or adamic-adar.
+func (s *FrontendSrv) recommendHandler(w http.ResponseWriter, r *http.Request) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	log.Debug().Msgf("Recommend request: %v\n", urlQuery)
+	useridstr, limitstr, scoringstr :=
+		urlQuery.Get("userid"), urlQuery.Get("limit"), urlQuery.Get("scoring")
+	var err1, err2 error
+	var limit int64
+	userid, err1 := strconv.ParseInt(useridstr, 10, 64)
+	if limitstr != "" {
+		limit, err2 = strconv.ParseInt(limitstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	scoring := graphpb.RECOMMEND_SCORING_OVERLAP
+	if scoringstr != "" {
+		var ok bool
+		if scoring, ok = scoringsMap[scoringstr]; !ok {
+			http.Error(w, "Unknown scoring: "+scoringstr, http.StatusBadRequest)
+			return
+		}
+	}
+	res, err := s.graphc.RecommendFollows(ctx, &graphpb.RecommendFollowsRequest{
+		Userid: userid, Scoring: scoring, Limit: int32(limit)})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if res.Ok != graph.GRAPH_QUERY_OK {
+		reply := map[string]interface{}{"message": "Recommend Failed!" + res.Ok}
+		json.NewEncoder(w).Encode(reply)
+		return
+	}
+	userids := ""
+	usernames := ""
+	scores := ""
+	for idx, recid := range res.Userids {
+		userids += strconv.FormatInt(recid, 10) + "; "
+		scores += strconv.FormatFloat(res.Scores[idx], 'f', 3, 64) + "; "
+	}
+	if len(res.Userids) > 0 {
+		profileRes, err := s.profilec.GetUsers(ctx, &profilepb.GetUsersRequest{Userids: res.Userids})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// missing profiles come back as placeholders, so names stay aligned
+		for _, recProfile := range profileRes.Profiles {
+			usernames += recProfile.Username + "; "
+		}
+	}
+	reply := map[string]interface{}{
+		"message": "Recommend successfully!", "userids": userids, "usernames": usernames,
+		"scores": scores}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// followHandler makes the session user follow the user named by followeeid
+// or followeename.
+func (s *FrontendSrv) followHandler(w http.ResponseWriter, r *http.Request) {
+	s.followHandlerInner(w, r, true)
+}
+
+func (s *FrontendSrv) unfollowHandler(w http.ResponseWriter, r *http.Request) {
+	s.followHandlerInner(w, r, false)
+}
+
+func (s *FrontendSrv) followHandlerInner(w http.ResponseWriter, r *http.Request, isFollow bool) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	urlQuery := requestQuery(r)
+	action := "Follow"
+	if !isFollow {
+		action = "Unfollow"
+	}
+	log.Debug().Msgf("%v request: %v\n", action, urlQuery)
+	useridstr, username := urlQuery.Get("userid"), urlQuery.Get("username")
+	followeestr, followeename := urlQuery.Get("followeeid"), urlQuery.Get("followeename")
+	userid, err1 := strconv.ParseInt(useridstr, 10, 64)
+	var followeeid int64
+	var err2 error
+	if followeename == "" {
+		if followeestr == "" {
+			http.Error(w, "Please specify followeeid or followeename", http.StatusBadRequest)
+			return
+		}
+		followeeid, err2 = strconv.ParseInt(followeestr, 10, 64)
+	}
+	if err1 != nil || err2 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	res, err := s.updateFollow(r.Context(), userid, username, followeeid, followeename, isFollow)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if res.Ratelimited {
+		writeRateLimited(w, res.Ok, res.Retryafter)
+		return
+	}
+	str := action + " successfully!"
+	if res.Ok != graph.GRAPH_QUERY_OK {
+		str = action + " Failed!" + res.Ok
+	}
+	reply := map[string]interface{}{"message": str}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// updateFollow adds or removes the edge from userid to the followee, named
+// by followeename if set and by followeeid otherwise.
+func (s *FrontendSrv) updateFollow(ctx context.Context, userid int64, username string,
+		followeeid int64, followeename string, isFollow bool) (*graphpb.GraphUpdateResponse, error) {
+	if followeename != "" {
+		if isFollow {
+			return s.graphc.FollowWithUname(ctx, &graphpb.FollowWithUnameRequest{
+				Followeruname: username, Followeeuname: followeename})
+		}
+		return s.graphc.UnfollowWithUname(ctx, &graphpb.UnfollowWithUnameRequest{
+			Followeruname: username, Followeeuname: followeename})
+	}
+	if isFollow {
+		return s.graphc.Follow(ctx, &graphpb.FollowRequest{Followerid: userid, Followeeid: followeeid})
+	}
+	return s.graphc.Unfollow(ctx, &graphpb.UnfollowRequest{Followerid: userid, Followeeid: followeeid})
+}
+
+func (s *FrontendSrv) followersHandler(w http.ResponseWriter, r *http.Request) {
+	s.followListHandlerInner(w, r, true)
+}
+
+func (s *FrontendSrv) followeesHandler(w http.ResponseWriter, r *http.Request) {
+	s.followListHandlerInner(w, r, false)
+}
+
+// followListHandlerInner lists a page of the followers or followees of the
+// user named by userid or username. Pass the returned nextcursor as cursor
+// for the next page; it is empty after the last one.
+func (s *FrontendSrv) followListHandlerInner(w http.ResponseWriter, r *http.Request, followers bool) {
+	if s.record {
+		defer s.p.TptTick(1.0)
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	ctx := r.Context()
+	urlQuery := requestQuery(r)
+	action := "Followers"
+	if !followers {
+		action = "Followees"
+	}
+	log.Debug().Msgf("%v request: %v\n", action, urlQuery)
+	useridstr, username, limitstr :=
+		urlQuery.Get("userid"), urlQuery.Get("username"), urlQuery.Get("limit")
+	var userid, limit int64
+	var err1, err2 error
+	if useridstr == "" {
+		if username == "" {
+			http.Error(w, "Please specify username or id", http.StatusBadRequest)
+			return
+		}
+		userRes, err := s.userc.CheckUser(ctx, &userpb.CheckUserRequest{Usernames: []string{username}})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if userRes.Ok != user.USER_QUERY_OK {
+			http.Error(w, "bad user name or id", http.StatusBadRequest)
+			return
+		}
+		userid = userRes.Userids[0]
+	} else {
+		userid, err1 = strconv.ParseInt(useridstr, 10, 64)
+	}
+	if limitstr != "" {
+		limit, err2 = strconv.ParseInt(limitstr, 10, 32)
+	}
+	if err1 != nil || err2 != nil {
+		http.Error(w, "bad number format in request", http.StatusBadRequest)
+		return
+	}
+	res, profiles, err := s.followPage(ctx, userid, followers, urlQuery.Get("cursor"), int32(limit))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if res.Ok != graph.GRAPH_QUERY_OK {
+		reply := map[string]interface{}{"message": action + " Failed!" + res.Ok}
+		json.NewEncoder(w).Encode(reply)
+		return
+	}
+	userids := ""
+	usernames := ""
+	for idx, followid := range res.Userids {
+		userids += strconv.FormatInt(followid, 10) + "; "
+		usernames += profiles[idx].Username + "; "
+	}
+	reply := map[string]interface{}{
+		"message": action + " successfully!", "userids": userids, "usernames": usernames,
+		"nextcursor": res.Nextcursor}
+	json.NewEncoder(w).Encode(reply)
+}
+
+// followPage reads a page of followers or followees of userid along with
+// their profiles.
+func (s *FrontendSrv) followPage(ctx context.Context, userid int64, followers bool, cursor string,
+		limit int32) (*graphpb.GraphGetResponse, []*profilepb.UserProfile, error) {
+	var res *graphpb.GraphGetResponse
+	var err error
+	if followers {
+		res, err = s.graphc.GetFollowers(ctx, &graphpb.GetFollowersRequest{
+			Followeeid: userid, Cursor: cursor, Limit: limit})
+	} else {
+		res, err = s.graphc.GetFollowees(ctx, &graphpb.GetFolloweesRequest{
+			Followerid: userid, Cursor: cursor, Limit: limit})
+	}
+	if err != nil || res.Ok != graph.GRAPH_QUERY_OK || len(res.Userids) == 0 {
+		return res, nil, err
+	}
+	profileRes, err := s.profilec.GetUsers(ctx, &profilepb.GetUsersRequest{Userids: res.Userids})
+	if err != nil {
+		return nil, nil, err
+	}
+	// missing profiles come back as placeholders, so profiles stay aligned
+	return res, profileRes.Profiles, nil
+}
+
+func (s *FrontendSrv) startRecordingHandler(w http.ResponseWriter, r *http.Request) {
+
+	s.record = true
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	log.Debug().Msg("Start recording!")
+
+	str := "Started recording!"
+
+	reply := map[string]interface{}{
+		"message": str,
+	}
+
+	json.NewEncoder(w).Encode(reply)
+}
+
+func (s *FrontendSrv) saveResultsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	s.p.Done()
+
+	str := "Done!"
+
+	res := map[string]interface{}{
+		"message": str,
+	}
+
+	json.NewEncoder(w).Encode(res)
+}
+
+
+// formatReactions renders the reaction counts of a post, e.g. "like:2 love:1".
+// authorName is the display name of a post's creator, or the username if
+// the creator has no profile.
+func authorName(post *postpb.Post) string {
+	if post.Author != nil && post.Author.Displayname != "" {
+		return post.Author.Displayname
+	}
+	return post.Creatoruname
+}
+
+func formatReactions(post *postpb.Post) string {
+	counts := make([]string, len(post.Reactions))
+	for idx, count := range post.Reactions {
+		counts[idx] = fmt.Sprintf("%v:%v", strings.ToLower(count.Reactiontype.String()), count.Count)
+	}
+	return strings.Join(counts, " ")
+}
 
 func parsePostTypeString(str string) (postpb.POST_TYPE) {
     c, ok := posttypesMap[strings.ToLower(str)]
@@ -0,0 +1,197 @@
+package frontend
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	SESSION_TTL = 24 * time.Hour
+	SESSION_COOKIE = "session"
+	// all frontend replicas must share the key to accept each other's tokens
+	SESSION_KEY_ENV = "SESSION_KEY"
+	// shortest signing key accepted, in bytes
+	SESSION_KEY_SIZE = 32
+)
+
+// Session is the signed content of a session token.
+type Session struct {
+	Userid   int64  `json:"uid"`
+	Username string `json:"uname"`
+	Expires  int64  `json:"exp"`
+}
+
+type sessionCtxKey struct{}
+
+// loadSessionKey reads the token signing key from the environment. The
+// frontend refuses to start without one: a key of its own would make tokens
+// fail on the other replicas and on every restart.
+func loadSessionKey() []byte {
+	key := os.Getenv(SESSION_KEY_ENV)
+	if key == "" {
+		log.Panic().Msgf("%v not set; all frontend replicas need the same session key", SESSION_KEY_ENV)
+	}
+	if len(key) < SESSION_KEY_SIZE {
+		log.Panic().Msgf("%v is shorter than %v bytes", SESSION_KEY_ENV, SESSION_KEY_SIZE)
+	}
+	return []byte(key)
+}
+
+// makeToken signs a session for the user, valid for SESSION_TTL. Tokens are
+// the base64 JSON session and its HMAC-SHA256, joined by a dot.
+func (s *FrontendSrv) makeToken(userid int64, username string) (string, *Session, error) {
+	session := &Session{
+		Userid: userid, Username: username, Expires: time.Now().Add(SESSION_TTL).Unix()}
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return "", nil, err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signToken(encoded), session, nil
+}
+
+func (s *FrontendSrv) signToken(encoded string) string {
+	mac := hmac.New(sha256.New, s.sessionKey)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// parseToken returns the session of a token if its signature is ours and it
+// has not expired.
+func (s *FrontendSrv) parseToken(token string) (*Session, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed session token")
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(s.signToken(parts[0]))) {
+		return nil, fmt.Errorf("bad session token signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed session token")
+	}
+	session := &Session{}
+	if err := json.Unmarshal(payload, session); err != nil {
+		return nil, fmt.Errorf("malformed session token")
+	}
+	if time.Now().Unix() >= session.Expires {
+		return nil, fmt.Errorf("session expired")
+	}
+	return session, nil
+}
+
+// requestToken finds the session token of a request, either as a bearer
+// token or in the session cookie.
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	if cookie, err := r.Cookie(SESSION_COOKIE); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// withSession only lets requests with a valid session token through, and
+// attaches the session to their context, where requestQuery finds it. With
+// actAs set, requests naming any other user are refused, as the handler
+// would act on that user's behalf. With changes set, the handler changes
+// state: as browsers send the session cookie along with cross-site GETs,
+// such requests must then come as POST unless they carry a bearer token.
+func (s *FrontendSrv) withSession(handler http.HandlerFunc, actAs bool, changes bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		session, err := s.parseToken(requestToken(r))
+		if err != nil {
+			http.Error(w, "Please log in: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if changes && !hasBearerToken(r) && r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Please send session cookie requests with POST", http.StatusMethodNotAllowed)
+			return
+		}
+		urlQuery := requestQuery(r)
+		useridstr, username := urlQuery.Get("userid"), urlQuery.Get("username")
+		if actAs {
+			if (useridstr != "" && useridstr != strconv.FormatInt(session.Userid, 10)) ||
+					(username != "" && username != session.Username) {
+				http.Error(w, "Request does not match the session user", http.StatusForbidden)
+				return
+			}
+		}
+		handler(w, r.WithContext(context.WithValue(r.Context(), sessionCtxKey{}, session)))
+	})
+}
+
+// hasBearerToken tells whether a request carries its session token in the
+// Authorization header, which browsers never add on their own.
+func hasBearerToken(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// requestQuery returns the parameters of a request: those of the query,
+// which the load scripts escape twice, and of a url-encoded POST body. Under
+// withSession, the session user is the default userid, and its username the
+// default username when the request acts as the session user.
+func requestQuery(r *http.Request) url.Values {
+	rawQuery, _ := url.QueryUnescape(r.URL.RawQuery)
+	urlQuery, _ := url.ParseQuery(rawQuery)
+	if r.Method == http.MethodPost {
+		// only reads url-encoded bodies; multipart forms are left to the handler
+		if err := r.ParseForm(); err == nil {
+			for key, values := range r.PostForm {
+				urlQuery[key] = append(urlQuery[key], values...)
+			}
+		}
+	}
+	if session := sessionFromContext(r.Context()); session != nil {
+		sessionid := strconv.FormatInt(session.Userid, 10)
+		useridstr := urlQuery.Get("userid")
+		if useridstr == "" {
+			urlQuery.Set("userid", sessionid)
+		}
+		if urlQuery.Get("username") == "" && (useridstr == "" || useridstr == sessionid) {
+			urlQuery.Set("username", session.Username)
+		}
+	}
+	return urlQuery
+}
+
+// setSession hands a new session token to the client, both in the reply and
+// as a cookie for browsers.
+func (s *FrontendSrv) setSession(
+		w http.ResponseWriter, r *http.Request, userid int64, username string) (string, error) {
+	token, session, err := s.makeToken(userid, username)
+	if err != nil {
+		return "", err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name: SESSION_COOKIE,
+		Value: token,
+		Path: "/",
+		Expires: time.Unix(session.Expires, 0),
+		HttpOnly: true,
+		Secure: r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return token, nil
+}
+
+// sessionFromContext returns the session withSession attached to a request,
+// or nil outside of withSession.
+func sessionFromContext(ctx context.Context) *Session {
+	session, _ := ctx.Value(sessionCtxKey{}).(*Session)
+	return session
+}
@@ -0,0 +1,123 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/rs/zerolog/log"
+	homepb "socialnetworkk8/services/home/proto"
+	postpb "socialnetworkk8/services/post/proto"
+	"socialnetworkk8/services/timeline"
+)
+
+const (
+	// comments sent on idle streams, so proxies do not cut them
+	STREAM_HEARTBEAT = 15 * time.Second
+)
+
+var (
+	nStreams = expvar.NewInt("frontend_home_streams")
+	nStreamsTotal = expvar.NewInt("frontend_home_streams_total")
+)
+
+// homeStreamHandler pushes the session user's new home timeline posts as
+// Server-Sent Events. The stream opens with a "ready" event once the home
+// service holds the subscription; each post then comes as a "post" event
+// with the post in the JSON API's form and its id as the event id. A
+// "dropped" event says some posts were skipped because the client fell
+// behind, and that it should re-read its home timeline.
+func (s *FrontendSrv) homeStreamHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	userid := sessionFromContext(ctx).Userid
+	stream, err := s.homec.SubscribeHomeTimeline(ctx, &homepb.SubscribeHomeTimelineRequest{Userid: userid})
+	if err == nil {
+		_, err = stream.Header()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nStreams.Add(1)
+	nStreamsTotal.Add(1)
+	defer nStreams.Add(-1)
+	log.Debug().Msgf("Home stream of %v open", userid)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "event: ready\ndata: {}\n\n")
+	flusher.Flush()
+
+	events := make(chan *homepb.HomeTimelineEvent)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Info().Msgf("Home stream of %v ended: %v", userid, err)
+				}
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	heartbeat := time.NewTicker(STREAM_HEARTBEAT)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Dropped > 0 {
+				fmt.Fprintf(w, "event: dropped\ndata: {\"count\":%v}\n\n", event.Dropped)
+			}
+			if err := s.writePostEvent(ctx, w, userid, event.Postid); err != nil {
+				log.Error().Msgf("Cannot send post %v to %v: %v", event.Postid, userid, err)
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writePostEvent sends postid as a "post" event, unless it is gone or hidden
+// from userid.
+func (s *FrontendSrv) writePostEvent(ctx context.Context, w http.ResponseWriter, userid, postid int64) error {
+	res, err := s.postc.ReadPosts(ctx, &postpb.ReadPostsRequest{Postids: []int64{postid}})
+	if err != nil {
+		return err
+	}
+	posts, err := timeline.FilterHidden(ctx, s.graphc, userid, res.Posts)
+	if err != nil {
+		return err
+	}
+	for _, p := range posts {
+		data, err := json.Marshal(toApiPost(p))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "id: %v\nevent: post\ndata: %s\n\n", p.Postid, data)
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package graph
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// Edge is one follow, stored as its own document so that popular users do
+// not outgrow a single document. Pages are ordered by _id, which also serves
+// as their cursor.
+type Edge struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"`
+	Followerid int64              `bson:"followerid"`
+	Followeeid int64              `bson:"followeeid"`
+	Createdat  int64              `bson:"createdat"`
+}
+
+// EdgeCount keeps a user's follower and followee counts, so they need not be
+// counted edge by edge.
+type EdgeCount struct {
+	Userid    int64 `bson:"userid"`
+	Followers int64 `bson:"followers"`
+	Followees int64 `bson:"followees"`
+}
+
+// EdgePage is one page of a user's followers or followees.
+type EdgePage struct {
+	Userids    []int64
+	Nextcursor string
+}
+
+// addEdge stores the follow from followerid to followeeid and reports
+// whether it is new.
+func (gsrv *GraphSrv) addEdge(followerid, followeeid int64) (bool, error) {
+	res, err := gsrv.mongoEdgeCo.UpdateOne(
+		context.TODO(), &bson.M{"followerid": followerid, "followeeid": followeeid},
+		&bson.M{"$setOnInsert": bson.M{"createdat": time.Now().UnixNano()}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		// a concurrent follow of the same user won the race
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if res.UpsertedCount == 0 {
+		return false, nil
+	}
+	return true, gsrv.incCounts(followerid, followeeid, 1)
+}
+
+// removeEdge deletes the follow from followerid to followeeid and reports
+// whether there was one.
+func (gsrv *GraphSrv) removeEdge(followerid, followeeid int64) (bool, error) {
+	res, err := gsrv.mongoEdgeCo.DeleteOne(
+		context.TODO(), &bson.M{"followerid": followerid, "followeeid": followeeid})
+	if err != nil {
+		return false, err
+	}
+	if res.DeletedCount == 0 {
+		return false, nil
+	}
+	return true, gsrv.incCounts(followerid, followeeid, -1)
+}
+
+func (gsrv *GraphSrv) incCounts(followerid, followeeid, delta int64) error {
+	_, err := gsrv.mongoCountCo.UpdateOne(
+		context.TODO(), &bson.M{"userid": followerid},
+		&bson.M{"$inc": bson.M{"followees": delta}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	_, err = gsrv.mongoCountCo.UpdateOne(
+		context.TODO(), &bson.M{"userid": followeeid},
+		&bson.M{"$inc": bson.M{"followers": delta}}, options.Update().SetUpsert(true))
+	return err
+}
+
+// getPage returns up to limit followers (or followees) of userid whose edges
+// come after the after cursor. Only default-sized first pages are cached, as
+// those are by far the most read.
+func (gsrv *GraphSrv) getPage(ctx context.Context, userid int64, followers bool,
+		after primitive.ObjectID, limit int) (*EdgePage, error) {
+	field, other, prefix := "followerid", "followeeid", FOLLOWEE_CACHE_PREFIX
+	if followers {
+		field, other, prefix = "followeeid", "followerid", FOLLOWER_CACHE_PREFIX
+	}
+	key := prefix + strconv.FormatInt(userid, 10)
+	cacheable := after.IsZero() && limit == GRAPH_PAGE_SIZE
+	page := &EdgePage{}
+	if cacheable {
+		if item, err := gsrv.cachec.Get(ctx, key); err == nil {
+			if json.Unmarshal(item.Value, page) == nil {
+				log.Debug().Msgf("Found edges %v in cache!", key)
+				return page, nil
+			}
+		} else if err != memcache.ErrCacheMiss {
+			return nil, err
+		}
+	}
+	filter := bson.M{field: userid}
+	if !after.IsZero() {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	// read one more than needed to know if there is a next page
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit + 1)).
+		SetProjection(bson.M{other: 1})
+	cur, err := gsrv.mongoEdgeCo.Find(context.TODO(), filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]Edge, 0)
+	if err := cur.All(context.TODO(), &edges); err != nil {
+		return nil, err
+	}
+	if len(edges) > limit {
+		edges = edges[:limit]
+		page.Nextcursor = edges[limit-1].Id.Hex()
+	}
+	page.Userids = make([]int64, len(edges))
+	for idx, edge := range edges {
+		if followers {
+			page.Userids[idx] = edge.Followerid
+		} else {
+			page.Userids[idx] = edge.Followeeid
+		}
+	}
+	if cacheable {
+		if encoded, err := json.Marshal(page); err != nil {
+			log.Error().Msg(err.Error())
+		} else {
+			gsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encoded})
+		}
+	}
+	return page, nil
+}
+
+// getAllEdges pages through all followers (or followees) of userid. Only
+// use it where the whole list is needed.
+func (gsrv *GraphSrv) getAllEdges(ctx context.Context, userid int64, followers bool) ([]int64, error) {
+	userids := make([]int64, 0)
+	after := primitive.NilObjectID
+	for {
+		page, err := gsrv.getPage(ctx, userid, followers, after, GRAPH_MAX_PAGE_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		userids = append(userids, page.Userids...)
+		if page.Nextcursor == "" {
+			return userids, nil
+		}
+		after, _ = primitive.ObjectIDFromHex(page.Nextcursor)
+	}
+}
+
+func (gsrv *GraphSrv) getCount(ctx context.Context, userid int64) (*EdgeCount, error) {
+	key := COUNT_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+	count := &EdgeCount{}
+	if item, err := gsrv.cachec.Get(ctx, key); err == nil {
+		if json.Unmarshal(item.Value, count) == nil {
+			return count, nil
+		}
+	} else if err != memcache.ErrCacheMiss {
+		return nil, err
+	}
+	err := gsrv.mongoCountCo.FindOne(context.TODO(), &bson.M{"userid": userid}).Decode(count)
+	if err == mongo.ErrNoDocuments {
+		count = &EdgeCount{Userid: userid}
+	} else if err != nil {
+		return nil, err
+	}
+	if encoded, err := json.Marshal(count); err != nil {
+		log.Error().Msg(err.Error())
+	} else {
+		gsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encoded})
+	}
+	return count, nil
+}
+
+// getCounts reads the counts of many users in one query, bypassing the
+// cache. Users without edges get zero counts.
+func (gsrv *GraphSrv) getCounts(userids []int64) (map[int64]*EdgeCount, error) {
+	counts := make(map[int64]*EdgeCount, len(userids))
+	for _, userid := range userids {
+		counts[userid] = &EdgeCount{Userid: userid}
+	}
+	if len(userids) == 0 {
+		return counts, nil
+	}
+	cur, err := gsrv.mongoCountCo.Find(context.TODO(), &bson.M{"userid": bson.M{"$in": userids}})
+	if err != nil {
+		return nil, err
+	}
+	found := make([]EdgeCount, 0)
+	if err := cur.All(context.TODO(), &found); err != nil {
+		return nil, err
+	}
+	for idx := range found {
+		counts[found[idx].Userid] = &found[idx]
+	}
+	return counts, nil
+}
+
+func (gsrv *GraphSrv) isFollowing(followerid int64, followeeids []int64) ([]bool, error) {
+	cur, err := gsrv.mongoEdgeCo.Find(
+		context.TODO(), &bson.M{"followerid": followerid, "followeeid": bson.M{"$in": followeeids}},
+		options.Find().SetProjection(bson.M{"followeeid": 1}))
+	if err != nil {
+		return nil, err
+	}
+	edges := make([]Edge, 0)
+	if err := cur.All(context.TODO(), &edges); err != nil {
+		return nil, err
+	}
+	followed := make(map[int64]bool, len(edges))
+	for _, edge := range edges {
+		followed[edge.Followeeid] = true
+	}
+	following := make([]bool, len(followeeids))
+	for idx, followeeid := range followeeids {
+		following[idx] = followed[followeeid]
+	}
+	return following, nil
+}
+
+// migrateLegacyEdges moves follows from the old per-user edge arrays into
+// edge documents. It is idempotent, so replicas starting together may all
+// run it; each legacy document is removed once its edges are moved. It runs
+// before any cache server has registered, so it leaves the caches alone;
+// they start out empty.
+func (gsrv *GraphSrv) migrateLegacyEdges(followeesCo, followersCo *mongo.Collection) error {
+	cur, err := followeesCo.Find(context.TODO(), &bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cur.Close(context.TODO())
+	nedges := 0
+	for cur.Next(context.TODO()) {
+		edgeInfo := &EdgeInfo{}
+		if err := cur.Decode(edgeInfo); err != nil {
+			return err
+		}
+		for _, followeeid := range edgeInfo.Edges {
+			if _, err := gsrv.addEdge(edgeInfo.Userid, followeeid); err != nil {
+				return err
+			}
+			nedges++
+		}
+		if _, err := followeesCo.DeleteOne(context.TODO(), &bson.M{"userid": edgeInfo.Userid}); err != nil {
+			return err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return err
+	}
+	// followers mirrored the followees, so there is nothing left to move
+	if _, err := followersCo.DeleteMany(context.TODO(), &bson.M{}); err != nil {
+		return err
+	}
+	if nedges > 0 {
+		log.Info().Msgf("Migrated %v legacy follow edges", nedges)
+	}
+	return nil
+}
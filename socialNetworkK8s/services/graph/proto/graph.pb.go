@@ -25,12 +25,71 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type RECOMMEND_SCORING int32
+
+const (
+	// number of followees following the candidate
+	RECOMMEND_SCORING_OVERLAP RECOMMEND_SCORING = 0
+	// overlap over the union of followees and the candidate's followers
+	RECOMMEND_SCORING_JACCARD RECOMMEND_SCORING = 1
+	// followees following the candidate, each weighted by 1/log(1+d) where
+	// d is how many users that followee follows
+	RECOMMEND_SCORING_ADAMIC_ADAR RECOMMEND_SCORING = 2
+)
+
+// Enum value maps for RECOMMEND_SCORING.
+var (
+	RECOMMEND_SCORING_name = map[int32]string{
+		0: "OVERLAP",
+		1: "JACCARD",
+		2: "ADAMIC_ADAR",
+	}
+	RECOMMEND_SCORING_value = map[string]int32{
+		"OVERLAP":     0,
+		"JACCARD":     1,
+		"ADAMIC_ADAR": 2,
+	}
+)
+
+func (x RECOMMEND_SCORING) Enum() *RECOMMEND_SCORING {
+	p := new(RECOMMEND_SCORING)
+	*p = x
+	return p
+}
+
+func (x RECOMMEND_SCORING) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RECOMMEND_SCORING) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_graph_proto_graph_proto_enumTypes[0].Descriptor()
+}
+
+func (RECOMMEND_SCORING) Type() protoreflect.EnumType {
+	return &file_services_graph_proto_graph_proto_enumTypes[0]
+}
+
+func (x RECOMMEND_SCORING) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RECOMMEND_SCORING.Descriptor instead.
+func (RECOMMEND_SCORING) EnumDescriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{0}
+}
+
+// GetFollowers and GetFollowees return one page of users, oldest edge
+// first. Pass the nextcursor of a page as cursor to get the next one; an
+// empty nextcursor means there are no more. limit defaults to 100 and is
+// capped at 1000.
 type GetFollowersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Followeeid int64 `protobuf:"varint,1,opt,name=followeeid,proto3" json:"followeeid,omitempty"`
+	Followeeid int64  `protobuf:"varint,1,opt,name=followeeid,proto3" json:"followeeid,omitempty"`
+	Cursor     string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit      int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
 func (x *GetFollowersRequest) Reset() {
@@ -72,12 +131,28 @@ func (x *GetFollowersRequest) GetFolloweeid() int64 {
 	return 0
 }
 
+func (x *GetFollowersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetFollowersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type GetFolloweesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Followerid int64 `protobuf:"varint,1,opt,name=followerid,proto3" json:"followerid,omitempty"`
+	Followerid int64  `protobuf:"varint,1,opt,name=followerid,proto3" json:"followerid,omitempty"`
+	Cursor     string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit      int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
 func (x *GetFolloweesRequest) Reset() {
@@ -119,13 +194,28 @@ func (x *GetFolloweesRequest) GetFollowerid() int64 {
 	return 0
 }
 
+func (x *GetFolloweesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetFolloweesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type GraphGetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok      string  `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Userids []int64 `protobuf:"varint,2,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+	Ok         string  `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Userids    []int64 `protobuf:"varint,2,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+	Nextcursor string  `protobuf:"bytes,3,opt,name=nextcursor,proto3" json:"nextcursor,omitempty"`
 }
 
 func (x *GraphGetResponse) Reset() {
@@ -174,6 +264,13 @@ func (x *GraphGetResponse) GetUserids() []int64 {
 	return nil
 }
 
+func (x *GraphGetResponse) GetNextcursor() string {
+	if x != nil {
+		return x.Nextcursor
+	}
+	return ""
+}
+
 type FollowRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -394,12 +491,16 @@ func (x *UnfollowWithUnameRequest) GetFolloweeuname() string {
 	return ""
 }
 
+// ratelimited marks a follow refused for coming too fast; it may be sent
+// again after retryafter seconds.
 type GraphUpdateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Ok          string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Ratelimited bool   `protobuf:"varint,2,opt,name=ratelimited,proto3" json:"ratelimited,omitempty"`
+	Retryafter  int64  `protobuf:"varint,3,opt,name=retryafter,proto3" json:"retryafter,omitempty"`
 }
 
 func (x *GraphUpdateResponse) Reset() {
@@ -441,193 +542,955 @@ func (x *GraphUpdateResponse) GetOk() string {
 	return ""
 }
 
-var File_services_graph_proto_graph_proto protoreflect.FileDescriptor
+func (x *GraphUpdateResponse) GetRatelimited() bool {
+	if x != nil {
+		return x.Ratelimited
+	}
+	return false
+}
 
-var file_services_graph_proto_graph_proto_rawDesc = []byte{
-	0x0a, 0x20, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x22, 0x35, 0x0a, 0x13, 0x47, 0x65, 0x74,
-	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64,
-	0x22, 0x35, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
-	0x77, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x69, 0x64, 0x22, 0x3c, 0x0a, 0x10, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
-	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x75,
-	0x73, 0x65, 0x72, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73,
-	0x65, 0x72, 0x69, 0x64, 0x73, 0x22, 0x4f, 0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
-	0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x72, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
-	0x65, 0x65, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x22, 0x51, 0x0a, 0x0f, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66,
-	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66,
-	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x16, 0x46, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x75,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x72, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x6f, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x65, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x22,
-	0x66, 0x0a, 0x18, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55,
-	0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x66,
-	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x75, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x75, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
-	0x65, 0x65, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x32, 0xad,
-	0x03, 0x0a, 0x05, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x46,
-	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61,
-	0x70, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a,
-	0x0c, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x73, 0x12, 0x1a, 0x2e,
-	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
-	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70,
-	0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x14, 0x2e, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e,
-	0x0a, 0x08, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x2e, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c,
-	0x0a, 0x0f, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
-	0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x11,
-	0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c,
-	0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x18,
-	0x5a, 0x16, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *GraphUpdateResponse) GetRetryafter() int64 {
+	if x != nil {
+		return x.Retryafter
+	}
+	return 0
 }
 
-var (
-	file_services_graph_proto_graph_proto_rawDescOnce sync.Once
-	file_services_graph_proto_graph_proto_rawDescData = file_services_graph_proto_graph_proto_rawDesc
-)
+// RelationRequest makes userid block or mute targetid, or undo it.
+type RelationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_services_graph_proto_graph_proto_rawDescGZIP() []byte {
-	file_services_graph_proto_graph_proto_rawDescOnce.Do(func() {
-		file_services_graph_proto_graph_proto_rawDescData = protoimpl.X.CompressGZIP(file_services_graph_proto_graph_proto_rawDescData)
-	})
-	return file_services_graph_proto_graph_proto_rawDescData
+	Userid   int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Targetid int64 `protobuf:"varint,2,opt,name=targetid,proto3" json:"targetid,omitempty"`
 }
 
-var file_services_graph_proto_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_services_graph_proto_graph_proto_goTypes = []interface{}{
-	(*GetFollowersRequest)(nil),      // 0: graph.GetFollowersRequest
-	(*GetFolloweesRequest)(nil),      // 1: graph.GetFolloweesRequest
-	(*GraphGetResponse)(nil),         // 2: graph.GraphGetResponse
-	(*FollowRequest)(nil),            // 3: graph.FollowRequest
-	(*UnfollowRequest)(nil),          // 4: graph.UnfollowRequest
-	(*FollowWithUnameRequest)(nil),   // 5: graph.FollowWithUnameRequest
-	(*UnfollowWithUnameRequest)(nil), // 6: graph.UnfollowWithUnameRequest
-	(*GraphUpdateResponse)(nil),      // 7: graph.GraphUpdateResponse
+func (x *RelationRequest) Reset() {
+	*x = RelationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_services_graph_proto_graph_proto_depIdxs = []int32{
-	0, // 0: graph.Graph.GetFollowers:input_type -> graph.GetFollowersRequest
-	1, // 1: graph.Graph.GetFollowees:input_type -> graph.GetFolloweesRequest
-	3, // 2: graph.Graph.Follow:input_type -> graph.FollowRequest
-	4, // 3: graph.Graph.Unfollow:input_type -> graph.UnfollowRequest
-	5, // 4: graph.Graph.FollowWithUname:input_type -> graph.FollowWithUnameRequest
-	6, // 5: graph.Graph.UnfollowWithUname:input_type -> graph.UnfollowWithUnameRequest
-	2, // 6: graph.Graph.GetFollowers:output_type -> graph.GraphGetResponse
-	2, // 7: graph.Graph.GetFollowees:output_type -> graph.GraphGetResponse
-	7, // 8: graph.Graph.Follow:output_type -> graph.GraphUpdateResponse
-	7, // 9: graph.Graph.Unfollow:output_type -> graph.GraphUpdateResponse
-	7, // 10: graph.Graph.FollowWithUname:output_type -> graph.GraphUpdateResponse
-	7, // 11: graph.Graph.UnfollowWithUname:output_type -> graph.GraphUpdateResponse
-	6, // [6:12] is the sub-list for method output_type
-	0, // [0:6] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *RelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_services_graph_proto_graph_proto_init() }
-func file_services_graph_proto_graph_proto_init() {
-	if File_services_graph_proto_graph_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_services_graph_proto_graph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetFollowersRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_services_graph_proto_graph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetFolloweesRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*RelationRequest) ProtoMessage() {}
+
+func (x *RelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_services_graph_proto_graph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GraphGetResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationRequest.ProtoReflect.Descriptor instead.
+func (*RelationRequest) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RelationRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *RelationRequest) GetTargetid() int64 {
+	if x != nil {
+		return x.Targetid
+	}
+	return 0
+}
+
+type GetRelationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+}
+
+func (x *GetRelationsRequest) Reset() {
+	*x = GetRelationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationsRequest) ProtoMessage() {}
+
+func (x *GetRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_services_graph_proto_graph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FollowRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetRelationsRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+// GetHidden returns, for each user, the users whose content they should not
+// see: those they block or mute and those blocking them.
+type GetHiddenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userids []int64 `protobuf:"varint,1,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+}
+
+func (x *GetHiddenRequest) Reset() {
+	*x = GetHiddenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHiddenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHiddenRequest) ProtoMessage() {}
+
+func (x *GetHiddenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_services_graph_proto_graph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnfollowRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHiddenRequest.ProtoReflect.Descriptor instead.
+func (*GetHiddenRequest) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetHiddenRequest) GetUserids() []int64 {
+	if x != nil {
+		return x.Userids
+	}
+	return nil
+}
+
+type GetHiddenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok     string      `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Hidden []*UserList `protobuf:"bytes,2,rep,name=hidden,proto3" json:"hidden,omitempty"`
+}
+
+func (x *GetHiddenResponse) Reset() {
+	*x = GetHiddenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHiddenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHiddenResponse) ProtoMessage() {}
+
+func (x *GetHiddenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_services_graph_proto_graph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FollowWithUnameRequest); i {
-			case 0:
-				return &v.state
-			case 1:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHiddenResponse.ProtoReflect.Descriptor instead.
+func (*GetHiddenResponse) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetHiddenResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *GetHiddenResponse) GetHidden() []*UserList {
+	if x != nil {
+		return x.Hidden
+	}
+	return nil
+}
+
+type UserList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userids []int64 `protobuf:"varint,1,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+}
+
+func (x *UserList) Reset() {
+	*x = UserList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserList) ProtoMessage() {}
+
+func (x *UserList) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserList.ProtoReflect.Descriptor instead.
+func (*UserList) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UserList) GetUserids() []int64 {
+	if x != nil {
+		return x.Userids
+	}
+	return nil
+}
+
+type GetCountsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userids []int64 `protobuf:"varint,1,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+}
+
+func (x *GetCountsRequest) Reset() {
+	*x = GetCountsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountsRequest) ProtoMessage() {}
+
+func (x *GetCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetCountsRequest) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetCountsRequest) GetUserids() []int64 {
+	if x != nil {
+		return x.Userids
+	}
+	return nil
+}
+
+// followers[i] and followees[i] are the counts of userids[i].
+type GetCountsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok        string  `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Followers []int64 `protobuf:"varint,2,rep,packed,name=followers,proto3" json:"followers,omitempty"`
+	Followees []int64 `protobuf:"varint,3,rep,packed,name=followees,proto3" json:"followees,omitempty"`
+}
+
+func (x *GetCountsResponse) Reset() {
+	*x = GetCountsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountsResponse) ProtoMessage() {}
+
+func (x *GetCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetCountsResponse) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCountsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *GetCountsResponse) GetFollowers() []int64 {
+	if x != nil {
+		return x.Followers
+	}
+	return nil
+}
+
+func (x *GetCountsResponse) GetFollowees() []int64 {
+	if x != nil {
+		return x.Followees
+	}
+	return nil
+}
+
+type IsFollowingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Followerid  int64   `protobuf:"varint,1,opt,name=followerid,proto3" json:"followerid,omitempty"`
+	Followeeids []int64 `protobuf:"varint,2,rep,packed,name=followeeids,proto3" json:"followeeids,omitempty"`
+}
+
+func (x *IsFollowingRequest) Reset() {
+	*x = IsFollowingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingRequest) ProtoMessage() {}
+
+func (x *IsFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingRequest.ProtoReflect.Descriptor instead.
+func (*IsFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IsFollowingRequest) GetFollowerid() int64 {
+	if x != nil {
+		return x.Followerid
+	}
+	return 0
+}
+
+func (x *IsFollowingRequest) GetFolloweeids() []int64 {
+	if x != nil {
+		return x.Followeeids
+	}
+	return nil
+}
+
+// following[i] tells whether followerid follows followeeids[i].
+type IsFollowingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok        string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Following []bool `protobuf:"varint,2,rep,packed,name=following,proto3" json:"following,omitempty"`
+}
+
+func (x *IsFollowingResponse) Reset() {
+	*x = IsFollowingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsFollowingResponse) ProtoMessage() {}
+
+func (x *IsFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsFollowingResponse.ProtoReflect.Descriptor instead.
+func (*IsFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IsFollowingResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *IsFollowingResponse) GetFollowing() []bool {
+	if x != nil {
+		return x.Following
+	}
+	return nil
+}
+
+// RecommendFollows ranks the users followed by userid's followees, leaving
+// out userid's followees and hidden users. At most limit users are returned,
+// best first.
+type RecommendFollowsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid  int64             `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Scoring RECOMMEND_SCORING `protobuf:"varint,2,opt,name=scoring,proto3,enum=graph.RECOMMEND_SCORING" json:"scoring,omitempty"`
+	Limit   int32             `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *RecommendFollowsRequest) Reset() {
+	*x = RecommendFollowsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecommendFollowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendFollowsRequest) ProtoMessage() {}
+
+func (x *RecommendFollowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendFollowsRequest.ProtoReflect.Descriptor instead.
+func (*RecommendFollowsRequest) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecommendFollowsRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *RecommendFollowsRequest) GetScoring() RECOMMEND_SCORING {
+	if x != nil {
+		return x.Scoring
+	}
+	return RECOMMEND_SCORING_OVERLAP
+}
+
+func (x *RecommendFollowsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecommendFollowsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok      string    `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Userids []int64   `protobuf:"varint,2,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+	Scores  []float64 `protobuf:"fixed64,3,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+}
+
+func (x *RecommendFollowsResponse) Reset() {
+	*x = RecommendFollowsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_graph_proto_graph_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecommendFollowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendFollowsResponse) ProtoMessage() {}
+
+func (x *RecommendFollowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_graph_proto_graph_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendFollowsResponse.ProtoReflect.Descriptor instead.
+func (*RecommendFollowsResponse) Descriptor() ([]byte, []int) {
+	return file_services_graph_proto_graph_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecommendFollowsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *RecommendFollowsResponse) GetUserids() []int64 {
+	if x != nil {
+		return x.Userids
+	}
+	return nil
+}
+
+func (x *RecommendFollowsResponse) GetScores() []float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+var File_services_graph_proto_graph_proto protoreflect.FileDescriptor
+
+var file_services_graph_proto_graph_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x22, 0x63, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x63,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x5c, 0x0a, 0x10, 0x47, 0x72, 0x61, 0x70, 0x68, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x22, 0x4f, 0x0a, 0x0d, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65,
+	0x69, 0x64, 0x22, 0x51, 0x0a, 0x0f, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x65, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x65, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x16, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57,
+	0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x75, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x75, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x65, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x66, 0x0a, 0x18, 0x55,
+	0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x75, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x75, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x67, 0x0a, 0x13, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x61,
+	0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x72, 0x61, 0x74, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x66, 0x74, 0x65, 0x72, 0x22, 0x45, 0x0a, 0x0f,
+	0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x69, 0x64, 0x22, 0x2d, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x69, 0x64, 0x22, 0x2c, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x73,
+	0x22, 0x4c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x27, 0x0a, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x22, 0x24,
+	0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x69, 0x64, 0x73, 0x22, 0x2c, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69,
+	0x64, 0x73, 0x22, 0x5f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x65, 0x73, 0x22, 0x56, 0x0a, 0x12, 0x49, 0x73, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0b,
+	0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x69, 0x64, 0x73, 0x22, 0x43, 0x0a, 0x13, 0x49,
+	0x73, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x6f, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x09, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67,
+	0x22, 0x7b, 0x0a, 0x17, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6c,
+	0x6c, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x69, 0x64, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x45, 0x43,
+	0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x44, 0x5f, 0x53, 0x43, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x52, 0x07,
+	0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x5c, 0x0a,
+	0x18, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x69, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x01, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x2a, 0x3e, 0x0a, 0x11, 0x52,
+	0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x44, 0x5f, 0x53, 0x43, 0x4f, 0x52, 0x49, 0x4e, 0x47,
+	0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x56, 0x45, 0x52, 0x4c, 0x41, 0x50, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x4a, 0x41, 0x43, 0x43, 0x41, 0x52, 0x44, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x44,
+	0x41, 0x4d, 0x49, 0x43, 0x5f, 0x41, 0x44, 0x41, 0x52, 0x10, 0x02, 0x32, 0xc2, 0x08, 0x0a, 0x05,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65,
+	0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x47, 0x65,
+	0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3a, 0x0a, 0x06, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x14, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x08, 0x55,
+	0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x46,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74,
+	0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x11, 0x55, 0x6e, 0x66,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x55, 0x6e, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57,
+	0x69, 0x74, 0x68, 0x55, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x05, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x07, 0x55, 0x6e, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x04, 0x4d, 0x75, 0x74, 0x65, 0x12,
+	0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x06, 0x55, 0x6e, 0x6d, 0x75, 0x74, 0x65, 0x12, 0x16, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x41, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12,
+	0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4d, 0x75, 0x74, 0x65, 0x64,
+	0x12, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48, 0x69, 0x64, 0x64,
+	0x65, 0x6e, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x69,
+	0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x64, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x73, 0x12, 0x1e, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x49, 0x73,
+	0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x2e, 0x49, 0x73, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x49, 0x73, 0x46,
+	0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x18, 0x5a, 0x16, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_services_graph_proto_graph_proto_rawDescOnce sync.Once
+	file_services_graph_proto_graph_proto_rawDescData = file_services_graph_proto_graph_proto_rawDesc
+)
+
+func file_services_graph_proto_graph_proto_rawDescGZIP() []byte {
+	file_services_graph_proto_graph_proto_rawDescOnce.Do(func() {
+		file_services_graph_proto_graph_proto_rawDescData = protoimpl.X.CompressGZIP(file_services_graph_proto_graph_proto_rawDescData)
+	})
+	return file_services_graph_proto_graph_proto_rawDescData
+}
+
+var file_services_graph_proto_graph_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_services_graph_proto_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_services_graph_proto_graph_proto_goTypes = []interface{}{
+	(RECOMMEND_SCORING)(0),           // 0: graph.RECOMMEND_SCORING
+	(*GetFollowersRequest)(nil),      // 1: graph.GetFollowersRequest
+	(*GetFolloweesRequest)(nil),      // 2: graph.GetFolloweesRequest
+	(*GraphGetResponse)(nil),         // 3: graph.GraphGetResponse
+	(*FollowRequest)(nil),            // 4: graph.FollowRequest
+	(*UnfollowRequest)(nil),          // 5: graph.UnfollowRequest
+	(*FollowWithUnameRequest)(nil),   // 6: graph.FollowWithUnameRequest
+	(*UnfollowWithUnameRequest)(nil), // 7: graph.UnfollowWithUnameRequest
+	(*GraphUpdateResponse)(nil),      // 8: graph.GraphUpdateResponse
+	(*RelationRequest)(nil),          // 9: graph.RelationRequest
+	(*GetRelationsRequest)(nil),      // 10: graph.GetRelationsRequest
+	(*GetHiddenRequest)(nil),         // 11: graph.GetHiddenRequest
+	(*GetHiddenResponse)(nil),        // 12: graph.GetHiddenResponse
+	(*UserList)(nil),                 // 13: graph.UserList
+	(*GetCountsRequest)(nil),         // 14: graph.GetCountsRequest
+	(*GetCountsResponse)(nil),        // 15: graph.GetCountsResponse
+	(*IsFollowingRequest)(nil),       // 16: graph.IsFollowingRequest
+	(*IsFollowingResponse)(nil),      // 17: graph.IsFollowingResponse
+	(*RecommendFollowsRequest)(nil),  // 18: graph.RecommendFollowsRequest
+	(*RecommendFollowsResponse)(nil), // 19: graph.RecommendFollowsResponse
+}
+var file_services_graph_proto_graph_proto_depIdxs = []int32{
+	13, // 0: graph.GetHiddenResponse.hidden:type_name -> graph.UserList
+	0,  // 1: graph.RecommendFollowsRequest.scoring:type_name -> graph.RECOMMEND_SCORING
+	1,  // 2: graph.Graph.GetFollowers:input_type -> graph.GetFollowersRequest
+	2,  // 3: graph.Graph.GetFollowees:input_type -> graph.GetFolloweesRequest
+	4,  // 4: graph.Graph.Follow:input_type -> graph.FollowRequest
+	5,  // 5: graph.Graph.Unfollow:input_type -> graph.UnfollowRequest
+	6,  // 6: graph.Graph.FollowWithUname:input_type -> graph.FollowWithUnameRequest
+	7,  // 7: graph.Graph.UnfollowWithUname:input_type -> graph.UnfollowWithUnameRequest
+	9,  // 8: graph.Graph.Block:input_type -> graph.RelationRequest
+	9,  // 9: graph.Graph.Unblock:input_type -> graph.RelationRequest
+	9,  // 10: graph.Graph.Mute:input_type -> graph.RelationRequest
+	9,  // 11: graph.Graph.Unmute:input_type -> graph.RelationRequest
+	10, // 12: graph.Graph.GetBlocked:input_type -> graph.GetRelationsRequest
+	10, // 13: graph.Graph.GetMuted:input_type -> graph.GetRelationsRequest
+	11, // 14: graph.Graph.GetHidden:input_type -> graph.GetHiddenRequest
+	18, // 15: graph.Graph.RecommendFollows:input_type -> graph.RecommendFollowsRequest
+	14, // 16: graph.Graph.GetCounts:input_type -> graph.GetCountsRequest
+	16, // 17: graph.Graph.IsFollowing:input_type -> graph.IsFollowingRequest
+	3,  // 18: graph.Graph.GetFollowers:output_type -> graph.GraphGetResponse
+	3,  // 19: graph.Graph.GetFollowees:output_type -> graph.GraphGetResponse
+	8,  // 20: graph.Graph.Follow:output_type -> graph.GraphUpdateResponse
+	8,  // 21: graph.Graph.Unfollow:output_type -> graph.GraphUpdateResponse
+	8,  // 22: graph.Graph.FollowWithUname:output_type -> graph.GraphUpdateResponse
+	8,  // 23: graph.Graph.UnfollowWithUname:output_type -> graph.GraphUpdateResponse
+	8,  // 24: graph.Graph.Block:output_type -> graph.GraphUpdateResponse
+	8,  // 25: graph.Graph.Unblock:output_type -> graph.GraphUpdateResponse
+	8,  // 26: graph.Graph.Mute:output_type -> graph.GraphUpdateResponse
+	8,  // 27: graph.Graph.Unmute:output_type -> graph.GraphUpdateResponse
+	3,  // 28: graph.Graph.GetBlocked:output_type -> graph.GraphGetResponse
+	3,  // 29: graph.Graph.GetMuted:output_type -> graph.GraphGetResponse
+	12, // 30: graph.Graph.GetHidden:output_type -> graph.GetHiddenResponse
+	19, // 31: graph.Graph.RecommendFollows:output_type -> graph.RecommendFollowsResponse
+	15, // 32: graph.Graph.GetCounts:output_type -> graph.GetCountsResponse
+	17, // 33: graph.Graph.IsFollowing:output_type -> graph.IsFollowingResponse
+	18, // [18:34] is the sub-list for method output_type
+	2,  // [2:18] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_services_graph_proto_graph_proto_init() }
+func file_services_graph_proto_graph_proto_init() {
+	if File_services_graph_proto_graph_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_services_graph_proto_graph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFollowersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFolloweesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphGetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FollowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnfollowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FollowWithUnameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
 				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
@@ -659,19 +1522,152 @@ func file_services_graph_proto_graph_proto_init() {
 				return nil
 			}
 		}
+		file_services_graph_proto_graph_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RelationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRelationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHiddenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHiddenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCountsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCountsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsFollowingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsFollowingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecommendFollowsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_graph_proto_graph_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecommendFollowsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_graph_proto_graph_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_services_graph_proto_graph_proto_goTypes,
 		DependencyIndexes: file_services_graph_proto_graph_proto_depIdxs,
+		EnumInfos:         file_services_graph_proto_graph_proto_enumTypes,
 		MessageInfos:      file_services_graph_proto_graph_proto_msgTypes,
 	}.Build()
 	File_services_graph_proto_graph_proto = out.File
@@ -25,6 +25,16 @@ const (
 	Graph_Unfollow_FullMethodName          = "/graph.Graph/Unfollow"
 	Graph_FollowWithUname_FullMethodName   = "/graph.Graph/FollowWithUname"
 	Graph_UnfollowWithUname_FullMethodName = "/graph.Graph/UnfollowWithUname"
+	Graph_Block_FullMethodName             = "/graph.Graph/Block"
+	Graph_Unblock_FullMethodName           = "/graph.Graph/Unblock"
+	Graph_Mute_FullMethodName              = "/graph.Graph/Mute"
+	Graph_Unmute_FullMethodName            = "/graph.Graph/Unmute"
+	Graph_GetBlocked_FullMethodName        = "/graph.Graph/GetBlocked"
+	Graph_GetMuted_FullMethodName          = "/graph.Graph/GetMuted"
+	Graph_GetHidden_FullMethodName         = "/graph.Graph/GetHidden"
+	Graph_RecommendFollows_FullMethodName  = "/graph.Graph/RecommendFollows"
+	Graph_GetCounts_FullMethodName         = "/graph.Graph/GetCounts"
+	Graph_IsFollowing_FullMethodName       = "/graph.Graph/IsFollowing"
 )
 
 // GraphClient is the client API for Graph service.
@@ -37,6 +47,16 @@ type GraphClient interface {
 	Unfollow(ctx context.Context, in *UnfollowRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
 	FollowWithUname(ctx context.Context, in *FollowWithUnameRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
 	UnfollowWithUname(ctx context.Context, in *UnfollowWithUnameRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
+	Block(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
+	Unblock(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
+	Mute(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
+	Unmute(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error)
+	GetBlocked(ctx context.Context, in *GetRelationsRequest, opts ...grpc.CallOption) (*GraphGetResponse, error)
+	GetMuted(ctx context.Context, in *GetRelationsRequest, opts ...grpc.CallOption) (*GraphGetResponse, error)
+	GetHidden(ctx context.Context, in *GetHiddenRequest, opts ...grpc.CallOption) (*GetHiddenResponse, error)
+	RecommendFollows(ctx context.Context, in *RecommendFollowsRequest, opts ...grpc.CallOption) (*RecommendFollowsResponse, error)
+	GetCounts(ctx context.Context, in *GetCountsRequest, opts ...grpc.CallOption) (*GetCountsResponse, error)
+	IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error)
 }
 
 type graphClient struct {
@@ -101,6 +121,96 @@ func (c *graphClient) UnfollowWithUname(ctx context.Context, in *UnfollowWithUna
 	return out, nil
 }
 
+func (c *graphClient) Block(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error) {
+	out := new(GraphUpdateResponse)
+	err := c.cc.Invoke(ctx, Graph_Block_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Unblock(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error) {
+	out := new(GraphUpdateResponse)
+	err := c.cc.Invoke(ctx, Graph_Unblock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Mute(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error) {
+	out := new(GraphUpdateResponse)
+	err := c.cc.Invoke(ctx, Graph_Mute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) Unmute(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*GraphUpdateResponse, error) {
+	out := new(GraphUpdateResponse)
+	err := c.cc.Invoke(ctx, Graph_Unmute_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) GetBlocked(ctx context.Context, in *GetRelationsRequest, opts ...grpc.CallOption) (*GraphGetResponse, error) {
+	out := new(GraphGetResponse)
+	err := c.cc.Invoke(ctx, Graph_GetBlocked_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) GetMuted(ctx context.Context, in *GetRelationsRequest, opts ...grpc.CallOption) (*GraphGetResponse, error) {
+	out := new(GraphGetResponse)
+	err := c.cc.Invoke(ctx, Graph_GetMuted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) GetHidden(ctx context.Context, in *GetHiddenRequest, opts ...grpc.CallOption) (*GetHiddenResponse, error) {
+	out := new(GetHiddenResponse)
+	err := c.cc.Invoke(ctx, Graph_GetHidden_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) RecommendFollows(ctx context.Context, in *RecommendFollowsRequest, opts ...grpc.CallOption) (*RecommendFollowsResponse, error) {
+	out := new(RecommendFollowsResponse)
+	err := c.cc.Invoke(ctx, Graph_RecommendFollows_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) GetCounts(ctx context.Context, in *GetCountsRequest, opts ...grpc.CallOption) (*GetCountsResponse, error) {
+	out := new(GetCountsResponse)
+	err := c.cc.Invoke(ctx, Graph_GetCounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphClient) IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error) {
+	out := new(IsFollowingResponse)
+	err := c.cc.Invoke(ctx, Graph_IsFollowing_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GraphServer is the server API for Graph service.
 // All implementations must embed UnimplementedGraphServer
 // for forward compatibility
@@ -111,6 +221,16 @@ type GraphServer interface {
 	Unfollow(context.Context, *UnfollowRequest) (*GraphUpdateResponse, error)
 	FollowWithUname(context.Context, *FollowWithUnameRequest) (*GraphUpdateResponse, error)
 	UnfollowWithUname(context.Context, *UnfollowWithUnameRequest) (*GraphUpdateResponse, error)
+	Block(context.Context, *RelationRequest) (*GraphUpdateResponse, error)
+	Unblock(context.Context, *RelationRequest) (*GraphUpdateResponse, error)
+	Mute(context.Context, *RelationRequest) (*GraphUpdateResponse, error)
+	Unmute(context.Context, *RelationRequest) (*GraphUpdateResponse, error)
+	GetBlocked(context.Context, *GetRelationsRequest) (*GraphGetResponse, error)
+	GetMuted(context.Context, *GetRelationsRequest) (*GraphGetResponse, error)
+	GetHidden(context.Context, *GetHiddenRequest) (*GetHiddenResponse, error)
+	RecommendFollows(context.Context, *RecommendFollowsRequest) (*RecommendFollowsResponse, error)
+	GetCounts(context.Context, *GetCountsRequest) (*GetCountsResponse, error)
+	IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error)
 	mustEmbedUnimplementedGraphServer()
 }
 
@@ -136,6 +256,36 @@ func (UnimplementedGraphServer) FollowWithUname(context.Context, *FollowWithUnam
 func (UnimplementedGraphServer) UnfollowWithUname(context.Context, *UnfollowWithUnameRequest) (*GraphUpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnfollowWithUname not implemented")
 }
+func (UnimplementedGraphServer) Block(context.Context, *RelationRequest) (*GraphUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Block not implemented")
+}
+func (UnimplementedGraphServer) Unblock(context.Context, *RelationRequest) (*GraphUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unblock not implemented")
+}
+func (UnimplementedGraphServer) Mute(context.Context, *RelationRequest) (*GraphUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mute not implemented")
+}
+func (UnimplementedGraphServer) Unmute(context.Context, *RelationRequest) (*GraphUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unmute not implemented")
+}
+func (UnimplementedGraphServer) GetBlocked(context.Context, *GetRelationsRequest) (*GraphGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlocked not implemented")
+}
+func (UnimplementedGraphServer) GetMuted(context.Context, *GetRelationsRequest) (*GraphGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMuted not implemented")
+}
+func (UnimplementedGraphServer) GetHidden(context.Context, *GetHiddenRequest) (*GetHiddenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHidden not implemented")
+}
+func (UnimplementedGraphServer) RecommendFollows(context.Context, *RecommendFollowsRequest) (*RecommendFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecommendFollows not implemented")
+}
+func (UnimplementedGraphServer) GetCounts(context.Context, *GetCountsRequest) (*GetCountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCounts not implemented")
+}
+func (UnimplementedGraphServer) IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsFollowing not implemented")
+}
 func (UnimplementedGraphServer) mustEmbedUnimplementedGraphServer() {}
 
 // UnsafeGraphServer may be embedded to opt out of forward compatibility for this service.
@@ -257,6 +407,186 @@ func _Graph_UnfollowWithUname_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Graph_Block_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Block(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Block_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Block(ctx, req.(*RelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Unblock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Unblock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Unblock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Unblock(ctx, req.(*RelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Mute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Mute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Mute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Mute(ctx, req.(*RelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_Unmute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).Unmute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_Unmute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).Unmute(ctx, req.(*RelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_GetBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).GetBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_GetBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).GetBlocked(ctx, req.(*GetRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_GetMuted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).GetMuted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_GetMuted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).GetMuted(ctx, req.(*GetRelationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_GetHidden_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHiddenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).GetHidden(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_GetHidden_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).GetHidden(ctx, req.(*GetHiddenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_RecommendFollows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).RecommendFollows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_RecommendFollows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).RecommendFollows(ctx, req.(*RecommendFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_GetCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).GetCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_GetCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).GetCounts(ctx, req.(*GetCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Graph_IsFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsFollowingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServer).IsFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Graph_IsFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServer).IsFollowing(ctx, req.(*IsFollowingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Graph_ServiceDesc is the grpc.ServiceDesc for Graph service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -288,6 +618,46 @@ var Graph_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnfollowWithUname",
 			Handler:    _Graph_UnfollowWithUname_Handler,
 		},
+		{
+			MethodName: "Block",
+			Handler:    _Graph_Block_Handler,
+		},
+		{
+			MethodName: "Unblock",
+			Handler:    _Graph_Unblock_Handler,
+		},
+		{
+			MethodName: "Mute",
+			Handler:    _Graph_Mute_Handler,
+		},
+		{
+			MethodName: "Unmute",
+			Handler:    _Graph_Unmute_Handler,
+		},
+		{
+			MethodName: "GetBlocked",
+			Handler:    _Graph_GetBlocked_Handler,
+		},
+		{
+			MethodName: "GetMuted",
+			Handler:    _Graph_GetMuted_Handler,
+		},
+		{
+			MethodName: "GetHidden",
+			Handler:    _Graph_GetHidden_Handler,
+		},
+		{
+			MethodName: "RecommendFollows",
+			Handler:    _Graph_RecommendFollows_Handler,
+		},
+		{
+			MethodName: "GetCounts",
+			Handler:    _Graph_GetCounts_Handler,
+		},
+		{
+			MethodName: "IsFollowing",
+			Handler:    _Graph_IsFollowing_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/graph/proto/graph.proto",
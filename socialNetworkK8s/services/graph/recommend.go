@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/net/context"
+	"socialnetworkk8/services/graph/proto"
+)
+
+const (
+	RECOMMEND_CACHE_PREFIX = "recommend_"
+	RECOMMEND_DEFAULT_LIMIT = 10
+	RECOMMEND_MAX_LIMIT = 100
+	// Follow/Unfollow of the user drops their recommendations right away;
+	// follows further out in the graph are picked up once these expire.
+	RECOMMEND_CACHE_TTL = 10 * time.Minute
+	// bounds on the work per computation: how many followees are sampled,
+	// how many of each one's followees are read, and how many candidates
+	// are scored when scoring needs their counts
+	RECOMMEND_MAX_FOLLOWEES = 100
+	RECOMMEND_MAX_FANOUT = GRAPH_PAGE_SIZE
+	RECOMMEND_MAX_CANDIDATES = 1000
+)
+
+// Recommendations is the ranked list cached per user and scoring.
+type Recommendations struct {
+	Userids    []int64
+	Scores     []float64
+	Computedat int64
+}
+
+// RecommendFollows suggests users to follow from userid's 2-hop
+// neighbourhood: users followed by its followees that it neither follows
+// nor hides.
+func (gsrv *GraphSrv) RecommendFollows(
+		ctx context.Context, req *proto.RecommendFollowsRequest) (*proto.RecommendFollowsResponse, error) {
+	res := &proto.RecommendFollowsResponse{Ok: "No"}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = RECOMMEND_DEFAULT_LIMIT
+	} else if limit > RECOMMEND_MAX_LIMIT {
+		limit = RECOMMEND_MAX_LIMIT
+	}
+	if _, ok := proto.RECOMMEND_SCORING_name[int32(req.Scoring)]; !ok {
+		res.Ok = fmt.Sprintf("Unknown scoring %v.", req.Scoring)
+		return res, nil
+	}
+	recs, err := gsrv.getRecommendations(ctx, req.Userid, req.Scoring)
+	if err != nil {
+		return nil, err
+	}
+	if len(recs.Userids) > limit {
+		recs.Userids, recs.Scores = recs.Userids[:limit], recs.Scores[:limit]
+	}
+	res.Userids = recs.Userids
+	res.Scores = recs.Scores
+	res.Ok = GRAPH_QUERY_OK
+	return res, nil
+}
+
+func recommendKey(userid int64, scoring proto.RECOMMEND_SCORING) string {
+	return RECOMMEND_CACHE_PREFIX + strconv.FormatInt(userid, 10) + "_" + scoring.String()
+}
+
+// clearRecommendations drops userid's cached recommendations for all
+// scorings.
+func (gsrv *GraphSrv) clearRecommendations(ctx context.Context, userid int64) {
+	for value := range proto.RECOMMEND_SCORING_name {
+		key := recommendKey(userid, proto.RECOMMEND_SCORING(value))
+		if !gsrv.cachec.Delete(ctx, key) {
+			log.Error().Msgf("cannot delete recommendations of %v", key)
+		}
+	}
+}
+
+func (gsrv *GraphSrv) getRecommendations(
+		ctx context.Context, userid int64, scoring proto.RECOMMEND_SCORING) (*Recommendations, error) {
+	key := recommendKey(userid, scoring)
+	recs := &Recommendations{}
+	if item, err := gsrv.cachec.Get(ctx, key); err == nil {
+		if json.Unmarshal(item.Value, recs) == nil &&
+				time.Since(time.Unix(0, recs.Computedat)) < RECOMMEND_CACHE_TTL {
+			log.Debug().Msgf("Found recommendations %v in cache!", key)
+			return recs, nil
+		}
+	} else if err != memcache.ErrCacheMiss {
+		return nil, err
+	}
+	recs, err := gsrv.computeRecommendations(ctx, userid, scoring)
+	if err != nil {
+		return nil, err
+	}
+	if encoded, err := json.Marshal(recs); err != nil {
+		log.Error().Msg(err.Error())
+	} else {
+		gsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encoded})
+	}
+	return recs, nil
+}
+
+// computeRecommendations ranks the candidates by score, ties broken by the
+// lower userid, and keeps the best RECOMMEND_MAX_LIMIT. Candidates come
+// from at most RECOMMEND_MAX_FOLLOWEES followees, so users with large
+// neighbourhoods get scores from a sample.
+func (gsrv *GraphSrv) computeRecommendations(
+		ctx context.Context, userid int64, scoring proto.RECOMMEND_SCORING) (*Recommendations, error) {
+	followees, err := gsrv.getFollowees(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	hiddenRes, err := gsrv.GetHidden(ctx, &proto.GetHiddenRequest{Userids: []int64{userid}})
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[int64]bool{userid: true}
+	for _, other := range followees {
+		excluded[other] = true
+	}
+	for _, other := range hiddenRes.Hidden[0].Userids {
+		excluded[other] = true
+	}
+	// users following many others are scored from a random sample of them
+	sampled := followees
+	if len(sampled) > RECOMMEND_MAX_FOLLOWEES {
+		sampled = make([]int64, len(followees))
+		copy(sampled, followees)
+		rand.Shuffle(len(sampled), func(i, j int) { sampled[i], sampled[j] = sampled[j], sampled[i] })
+		sampled = sampled[:RECOMMEND_MAX_FOLLOWEES]
+	}
+	var degrees map[int64]*EdgeCount
+	if scoring == proto.RECOMMEND_SCORING_ADAMIC_ADAR {
+		if degrees, err = gsrv.getCounts(sampled); err != nil {
+			return nil, err
+		}
+	}
+	overlap := make(map[int64]int)
+	adamicAdar := make(map[int64]float64)
+	for _, followee := range sampled {
+		// the cached first page of each followee's followees
+		page, err := gsrv.getPage(ctx, followee, false, primitive.NilObjectID, RECOMMEND_MAX_FANOUT)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range page.Userids {
+			if excluded[candidate] {
+				continue
+			}
+			overlap[candidate]++
+			if scoring == proto.RECOMMEND_SCORING_ADAMIC_ADAR {
+				adamicAdar[candidate] += 1 / math.Log(1+float64(degrees[followee].Followees))
+			}
+		}
+	}
+	candidates := make([]int64, 0, len(overlap))
+	for candidate := range overlap {
+		candidates = append(candidates, candidate)
+	}
+	scores := make(map[int64]float64, len(overlap))
+	switch scoring {
+	case proto.RECOMMEND_SCORING_JACCARD:
+		// only the candidates sharing the most followees are worth a count
+		if len(candidates) > RECOMMEND_MAX_CANDIDATES {
+			sort.Slice(candidates, func(i, j int) bool {
+				if overlap[candidates[i]] != overlap[candidates[j]] {
+					return overlap[candidates[i]] > overlap[candidates[j]]
+				}
+				return candidates[i] < candidates[j]
+			})
+			candidates = candidates[:RECOMMEND_MAX_CANDIDATES]
+		}
+		counts, err := gsrv.getCounts(candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			common := int64(overlap[candidate])
+			scores[candidate] = float64(common) /
+				float64(int64(len(followees))+counts[candidate].Followers-common)
+		}
+	case proto.RECOMMEND_SCORING_ADAMIC_ADAR:
+		for _, candidate := range candidates {
+			scores[candidate] = adamicAdar[candidate]
+		}
+	default:
+		for _, candidate := range candidates {
+			scores[candidate] = float64(overlap[candidate])
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+		return candidates[i] < candidates[j]
+	})
+	if len(candidates) > RECOMMEND_MAX_LIMIT {
+		candidates = candidates[:RECOMMEND_MAX_LIMIT]
+	}
+	recs := &Recommendations{
+		Userids: candidates, Scores: make([]float64, len(candidates)), Computedat: time.Now().UnixNano()}
+	for idx, candidate := range candidates {
+		recs.Scores[idx] = scores[candidate]
+	}
+	return recs, nil
+}
@@ -8,7 +8,9 @@ import (
 	"strconv"
 	"time"
 	"fmt"
+	"math"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"net"
@@ -17,11 +19,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"socialnetworkk8/registry"
+	"socialnetworkk8/ratelimit"
 	"socialnetworkk8/tune"
 	"socialnetworkk8/dialer"
 	"socialnetworkk8/services/cacheclnt"
 	"socialnetworkk8/tls"
 	"socialnetworkk8/services/user"
+	"socialnetworkk8/services/notification"
+	notifpb "socialnetworkk8/services/notification/proto"
 	"socialnetworkk8/services/graph/proto"
 	userpb "socialnetworkk8/services/user/proto"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -37,22 +42,35 @@ import (
 const (
 	GRAPH_SRV_NAME = "srv-graph"
 	GRAPH_QUERY_OK = "OK"
-	FOLLOWER_CACHE_PREFIX = "followers_"
-	FOLLOWEE_CACHE_PREFIX = "followees_"
+	FOLLOWER_CACHE_PREFIX = "followerpage_"
+	FOLLOWEE_CACHE_PREFIX = "followeepage_"
+	COUNT_CACHE_PREFIX = "graphcount_"
+	BLOCK_CACHE_PREFIX = "blocks_"
+	BLOCKEDBY_CACHE_PREFIX = "blockedby_"
+	MUTE_CACHE_PREFIX = "mutes_"
+	GRAPH_PAGE_SIZE = 100
+	GRAPH_MAX_PAGE_SIZE = 1000
 )
 
+var DEFAULT_FOLLOW_LIMIT = ratelimit.Limit{Rate: 1, Burst: 100}
+
 // Server implements the user service
 type GraphSrv struct {
 	proto.UnimplementedGraphServer 
 	uuid         string
 	cachec       *cacheclnt.CacheClnt
-	mongoFlwERCo *mongo.Collection
-	mongoFlwEECo *mongo.Collection
+	mongoEdgeCo  *mongo.Collection
+	mongoCountCo *mongo.Collection
+	mongoBlockCo *mongo.Collection
+	mongoBlkByCo *mongo.Collection
+	mongoMuteCo  *mongo.Collection
 	userc        userpb.UserClient
+	notifc       notifpb.NotificationClient
 	Registry     *registry.Client
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
+	followLimiter *ratelimit.Limiter
 	fCounter     *tracing.Counter
 }
 
@@ -102,24 +120,49 @@ func MakeGraphSrv() *GraphSrv {
 	if err != nil {
 		log.Panic().Msg(err.Error())
 	}
-	followersCo := mongoClient.Database("socialnetwork").Collection("graph-follower")
-	followeesCo := mongoClient.Database("socialnetwork").Collection("graph-followee")
+	edgesCo := mongoClient.Database("socialnetwork").Collection("graph-edge")
+	countsCo := mongoClient.Database("socialnetwork").Collection("graph-count")
+	names, _ := edgesCo.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "followerid", Value: 1}, {Key: "followeeid", Value: 1}},
+			Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "followerid", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "followeeid", Value: 1}, {Key: "_id", Value: 1}}},
+	})
+	log.Info().Msgf("Name of indexes created for edges: %v", names)
+	name1, _ := countsCo.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "userid", Value: 1}}, Options: options.Index().SetUnique(true)})
+	log.Info().Msgf("Name of index created for counts: %v", name1)
 	indexModel := mongo.IndexModel{Keys: bson.D{{"userid", 1}}}
-	name1, _ := followersCo.Indexes().CreateOne(context.TODO(), indexModel)
-	log.Info().Msgf("Name of index created for followers: %v", name1)
-	name2, _ := followeesCo.Indexes().CreateOne(context.TODO(), indexModel)
-	log.Info().Msgf("Name of index created for followees: %v", name2)
+	blocksCo := mongoClient.Database("socialnetwork").Collection("graph-block")
+	blockedByCo := mongoClient.Database("socialnetwork").Collection("graph-blockedby")
+	mutesCo := mongoClient.Database("socialnetwork").Collection("graph-mute")
+	for _, co := range []*mongo.Collection{blocksCo, blockedByCo, mutesCo} {
+		name, _ := co.Indexes().CreateOne(context.TODO(), indexModel)
+		log.Info().Msgf("Name of index created for %v: %v", co.Name(), name)
+	}
 	log.Info().Msg("New mongo session successfull.")
-	return &GraphSrv{
+	gsrv := &GraphSrv{
 		Port:         serv_port,
 		IpAddr:       serv_ip,
 		Tracer:       tracer,
 		Registry:     registry,
 		cachec:       cachec,
-		mongoFlwERCo: followersCo,
-		mongoFlwEECo: followeesCo,
+		mongoEdgeCo:  edgesCo,
+		mongoCountCo: countsCo,
+		mongoBlockCo: blocksCo,
+		mongoBlkByCo: blockedByCo,
+		mongoMuteCo:  mutesCo,
+		followLimiter: ratelimit.MakeLimiter(
+			cachec, "follow", ratelimit.LoadLimit(result, "FollowLimit", DEFAULT_FOLLOW_LIMIT)),
 		fCounter:     tracing.MakeCounter("Get-Follower"),
 	}
+	err = gsrv.migrateLegacyEdges(
+		mongoClient.Database("socialnetwork").Collection("graph-followee"),
+		mongoClient.Database("socialnetwork").Collection("graph-follower"))
+	if err != nil {
+		log.Panic().Msgf("Cannot migrate legacy follow edges: %v", err)
+	}
+	return gsrv
 }
 
 // Run starts the server
@@ -138,6 +181,14 @@ func (gsrv *GraphSrv) Run() error {
 		return fmt.Errorf("dialer error: %v", err)
 	}
 	gsrv.userc = userpb.NewUserClient(conn)
+	notifConn, err := dialer.Dial(
+		notification.NOTIFICATION_SRV_NAME,
+		gsrv.Registry.Client,
+		dialer.WithTracer(gsrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	gsrv.notifc = notifpb.NewNotificationClient(notifConn)
 
 	log.Info().Msg("Initializing gRPC Server...")
 	gsrv.uuid = uuid.New().String()
@@ -180,38 +231,76 @@ func (gsrv *GraphSrv) GetFollowers(
 		ctx context.Context, req *proto.GetFollowersRequest) (*proto.GraphGetResponse, error) {
 	t0 := time.Now()
 	defer gsrv.fCounter.AddTimeSince(t0)
-	res := &proto.GraphGetResponse{}
-	res.Ok = "No"
-	res.Userids = make([]int64, 0)
-	followers, err := gsrv.getFollowers(ctx, req.Followeeid)
-	if err == nil {
-		res.Userids = followers
-		res.Ok = GRAPH_QUERY_OK
-	}
-	return res, nil
+	return gsrv.getEdgePage(ctx, req.Followeeid, true, req.Cursor, req.Limit)
 }
 
 func (gsrv *GraphSrv) GetFollowees(
 		ctx context.Context, req *proto.GetFolloweesRequest) (*proto.GraphGetResponse, error) {
+	return gsrv.getEdgePage(ctx, req.Followerid, false, req.Cursor, req.Limit)
+}
+
+func (gsrv *GraphSrv) getEdgePage(ctx context.Context, userid int64, followers bool,
+		cursor string, limit int32) (*proto.GraphGetResponse, error) {
 	res := &proto.GraphGetResponse{}
 	res.Ok = "No"
 	res.Userids = make([]int64, 0)
-	followees, err := gsrv.getFollowees(ctx, req.Followerid)
+	after := primitive.NilObjectID
+	if cursor != "" {
+		var err error
+		if after, err = primitive.ObjectIDFromHex(cursor); err != nil {
+			res.Ok = "Bad cursor."
+			return res, nil
+		}
+	}
+	pageSize := int(limit)
+	if pageSize <= 0 {
+		pageSize = GRAPH_PAGE_SIZE
+	} else if pageSize > GRAPH_MAX_PAGE_SIZE {
+		pageSize = GRAPH_MAX_PAGE_SIZE
+	}
+	page, err := gsrv.getPage(ctx, userid, followers, after, pageSize)
 	if err == nil {
-		res.Userids = followees
+		res.Userids = page.Userids
+		res.Nextcursor = page.Nextcursor
 		res.Ok = GRAPH_QUERY_OK
 	}
 	return res, nil
 }
 
+func (gsrv *GraphSrv) GetCounts(
+		ctx context.Context, req *proto.GetCountsRequest) (*proto.GetCountsResponse, error) {
+	res := &proto.GetCountsResponse{Ok: "No"}
+	res.Followers = make([]int64, len(req.Userids))
+	res.Followees = make([]int64, len(req.Userids))
+	for idx, userid := range req.Userids {
+		count, err := gsrv.getCount(ctx, userid)
+		if err != nil {
+			return nil, err
+		}
+		res.Followers[idx] = count.Followers
+		res.Followees[idx] = count.Followees
+	}
+	res.Ok = GRAPH_QUERY_OK
+	return res, nil
+}
+
+func (gsrv *GraphSrv) IsFollowing(
+		ctx context.Context, req *proto.IsFollowingRequest) (*proto.IsFollowingResponse, error) {
+	following, err := gsrv.isFollowing(req.Followerid, req.Followeeids)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.IsFollowingResponse{Ok: GRAPH_QUERY_OK, Following: following}, nil
+}
+
 func (gsrv *GraphSrv) Follow(
 		ctx context.Context, req *proto.FollowRequest) (*proto.GraphUpdateResponse, error) {
-	return gsrv.updateGraph(ctx, req.Followerid, req.Followeeid, true)
+	return gsrv.updateGraph(ctx, req.Followerid, req.Followeeid, "", true)
 }
 
 func (gsrv *GraphSrv) Unfollow(
 		ctx context.Context, req *proto.UnfollowRequest) (*proto.GraphUpdateResponse, error) {
-	return gsrv.updateGraph(ctx, req.Followerid, req.Followeeid, false)
+	return gsrv.updateGraph(ctx, req.Followerid, req.Followeeid, "", false)
 }
 
 func (gsrv *GraphSrv) FollowWithUname(
@@ -225,7 +314,7 @@ func (gsrv *GraphSrv) UnfollowWithUname(
 }
 
 func (gsrv *GraphSrv) updateGraph(
-		ctx context.Context, followerid, followeeid int64, isFollow bool) (
+		ctx context.Context, followerid, followeeid int64, followerUname string, isFollow bool) (
 		*proto.GraphUpdateResponse, error) {
 	res := &proto.GraphUpdateResponse{}
 	res.Ok = "No"
@@ -238,30 +327,199 @@ func (gsrv *GraphSrv) updateGraph(
 		}
 		return res, nil
 	}
-	var err1, err2 error
 	if isFollow {
-		_, err1 = gsrv.mongoFlwERCo.UpdateOne(
-			context.TODO(), &bson.M{"userid": followeeid}, 
-			&bson.M{"$addToSet": bson.M{"edges": followerid}}, options.Update().SetUpsert(true))
-		_, err2 = gsrv.mongoFlwEECo.UpdateOne(
-			context.TODO(), &bson.M{"userid": followerid}, 
-			&bson.M{"$addToSet": bson.M{"edges": followeeid}}, options.Update().SetUpsert(true))
+		blocked, err := gsrv.isBlocked(ctx, followerid, followeeid)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			res.Ok = fmt.Sprintf("Cannot follow %v: blocked.", followeeid)
+			return res, nil
+		}
+		// the cache being unreachable lets the follow through
+		if ok, wait, err := gsrv.followLimiter.Take(ctx, followerid, 1); err != nil {
+			log.Error().Msgf("Cannot check follow limit of %v: %v", followerid, err)
+		} else if !ok {
+			res.Ok += " Rate limit: Too many follows."
+			res.Ratelimited = true
+			res.Retryafter = int64(math.Ceil(wait.Seconds()))
+			return res, nil
+		}
+	}
+	var changed bool
+	var err error
+	if isFollow {
+		changed, err = gsrv.addEdge(followerid, followeeid)
 	} else {
-		_, err1 = gsrv.mongoFlwERCo.UpdateOne(
-			context.TODO(), &bson.M{"userid": followeeid}, 
-			&bson.M{"$pull": bson.M{"edges": followerid}})
-		_, err2 = gsrv.mongoFlwEECo.UpdateOne(
-			context.TODO(), &bson.M{"userid": followerid}, 
-			&bson.M{"$pull": bson.M{"edges": followeeid}})
+		changed, err = gsrv.removeEdge(followerid, followeeid)
+	}
+	if err != nil {
+		return res, fmt.Errorf("error updating graph %v", err)
+	}
+	res.Ok = GRAPH_QUERY_OK
+	if changed {
+		gsrv.clearCache(ctx, followerid, followeeid)
+	}
+	// only a new edge is news to the followee
+	if isFollow && changed {
+		gsrv.notifyFollow(ctx, followerid, followeeid, followerUname)
+	}
+	return res, nil
+}
+
+// Block makes userid block targetid. It drops the follow edges between them
+// in both directions, and neither can follow the other until the block is
+// lifted.
+func (gsrv *GraphSrv) Block(
+		ctx context.Context, req *proto.RelationRequest) (*proto.GraphUpdateResponse, error) {
+	res := &proto.GraphUpdateResponse{Ok: "No"}
+	if req.Userid == req.Targetid {
+		res.Ok = "Cannot block self."
+		return res, nil
+	}
+	err := gsrv.updateRelation(ctx, gsrv.mongoBlockCo, BLOCK_CACHE_PREFIX, req.Userid, req.Targetid, true)
+	if err != nil {
+		return nil, err
+	}
+	err = gsrv.updateRelation(ctx, gsrv.mongoBlkByCo, BLOCKEDBY_CACHE_PREFIX, req.Targetid, req.Userid, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range [][2]int64{{req.Userid, req.Targetid}, {req.Targetid, req.Userid}} {
+		if _, err := gsrv.updateGraph(ctx, pair[0], pair[1], "", false); err != nil {
+			return nil, err
+		}
+	}
+	res.Ok = GRAPH_QUERY_OK
+	return res, nil
+}
+
+func (gsrv *GraphSrv) Unblock(
+		ctx context.Context, req *proto.RelationRequest) (*proto.GraphUpdateResponse, error) {
+	err := gsrv.updateRelation(ctx, gsrv.mongoBlockCo, BLOCK_CACHE_PREFIX, req.Userid, req.Targetid, false)
+	if err != nil {
+		return nil, err
 	}
-	if err1 != nil || err2 != nil {
-		return res, fmt.Errorf("error updating graph %v %v", err1, err2)
+	err = gsrv.updateRelation(ctx, gsrv.mongoBlkByCo, BLOCKEDBY_CACHE_PREFIX, req.Targetid, req.Userid, false)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GraphUpdateResponse{Ok: GRAPH_QUERY_OK}, nil
+}
+
+// Mute hides targetid's content from userid without touching follows; the
+// muted user cannot tell.
+func (gsrv *GraphSrv) Mute(
+		ctx context.Context, req *proto.RelationRequest) (*proto.GraphUpdateResponse, error) {
+	if req.Userid == req.Targetid {
+		return &proto.GraphUpdateResponse{Ok: "Cannot mute self."}, nil
+	}
+	err := gsrv.updateRelation(ctx, gsrv.mongoMuteCo, MUTE_CACHE_PREFIX, req.Userid, req.Targetid, true)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GraphUpdateResponse{Ok: GRAPH_QUERY_OK}, nil
+}
+
+func (gsrv *GraphSrv) Unmute(
+		ctx context.Context, req *proto.RelationRequest) (*proto.GraphUpdateResponse, error) {
+	err := gsrv.updateRelation(ctx, gsrv.mongoMuteCo, MUTE_CACHE_PREFIX, req.Userid, req.Targetid, false)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GraphUpdateResponse{Ok: GRAPH_QUERY_OK}, nil
+}
+
+func (gsrv *GraphSrv) GetBlocked(
+		ctx context.Context, req *proto.GetRelationsRequest) (*proto.GraphGetResponse, error) {
+	blocked, err := gsrv.getEdges(ctx, gsrv.mongoBlockCo, BLOCK_CACHE_PREFIX, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GraphGetResponse{Ok: GRAPH_QUERY_OK, Userids: blocked}, nil
+}
+
+func (gsrv *GraphSrv) GetMuted(
+		ctx context.Context, req *proto.GetRelationsRequest) (*proto.GraphGetResponse, error) {
+	muted, err := gsrv.getEdges(ctx, gsrv.mongoMuteCo, MUTE_CACHE_PREFIX, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GraphGetResponse{Ok: GRAPH_QUERY_OK, Userids: muted}, nil
+}
+
+func (gsrv *GraphSrv) GetHidden(
+		ctx context.Context, req *proto.GetHiddenRequest) (*proto.GetHiddenResponse, error) {
+	res := &proto.GetHiddenResponse{Ok: "No"}
+	res.Hidden = make([]*proto.UserList, len(req.Userids))
+	for idx, userid := range req.Userids {
+		seen := make(map[int64]bool)
+		hidden := make([]int64, 0)
+		for _, rel := range []struct {
+			co     *mongo.Collection
+			prefix string
+		}{
+			{gsrv.mongoBlockCo, BLOCK_CACHE_PREFIX},
+			{gsrv.mongoBlkByCo, BLOCKEDBY_CACHE_PREFIX},
+			{gsrv.mongoMuteCo, MUTE_CACHE_PREFIX},
+		} {
+			edges, err := gsrv.getEdges(ctx, rel.co, rel.prefix, userid)
+			if err != nil {
+				return nil, err
+			}
+			for _, other := range edges {
+				if !seen[other] {
+					seen[other] = true
+					hidden = append(hidden, other)
+				}
+			}
+		}
+		res.Hidden[idx] = &proto.UserList{Userids: hidden}
 	}
 	res.Ok = GRAPH_QUERY_OK
-	gsrv.clearCache(ctx, followerid, followeeid)
 	return res, nil
 }
 
+// isBlocked reports whether either user blocks the other.
+func (gsrv *GraphSrv) isBlocked(ctx context.Context, userid1, userid2 int64) (bool, error) {
+	for _, pair := range [][2]int64{{userid1, userid2}, {userid2, userid1}} {
+		blocked, err := gsrv.getEdges(ctx, gsrv.mongoBlockCo, BLOCK_CACHE_PREFIX, pair[0])
+		if err != nil {
+			return false, err
+		}
+		for _, other := range blocked {
+			if other == pair[1] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// updateRelation adds or removes the edge from userid to otherid in a
+// relation collection and drops userid's cached edges.
+func (gsrv *GraphSrv) updateRelation(ctx context.Context, co *mongo.Collection, prefix string,
+		userid, otherid int64, add bool) error {
+	var err error
+	if add {
+		_, err = co.UpdateOne(
+			context.TODO(), &bson.M{"userid": userid},
+			&bson.M{"$addToSet": bson.M{"edges": otherid}}, options.Update().SetUpsert(true))
+	} else {
+		_, err = co.UpdateOne(
+			context.TODO(), &bson.M{"userid": userid}, &bson.M{"$pull": bson.M{"edges": otherid}})
+	}
+	if err != nil {
+		return err
+	}
+	key := prefix + strconv.FormatInt(userid, 10)
+	if !gsrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete edges of %v", key)
+	}
+	gsrv.clearRecommendations(ctx, userid)
+	return nil
+}
+
 func (gsrv *GraphSrv) updateGraphWithUname(
 		ctx context.Context, follwerUname, followeeUname string, isFollow bool) (
 		*proto.GraphUpdateResponse, error) {
@@ -274,7 +532,25 @@ func (gsrv *GraphSrv) updateGraphWithUname(
 		return &proto.GraphUpdateResponse{Ok: "Follower or Followee does not exist"}, nil
 	}
 	followerid, followeeid := userRes.Userids[0], userRes.Userids[1]
-	return gsrv.updateGraph(ctx, followerid, followeeid, isFollow)
+	return gsrv.updateGraph(ctx, followerid, followeeid, follwerUname, isFollow)
+}
+
+// notifyFollow tells followeeid about a new follower. It is best effort and
+// never fails the follow.
+func (gsrv *GraphSrv) notifyFollow(
+		ctx context.Context, followerid, followeeid int64, followerUname string) {
+	notifReq := &notifpb.NotifyRequest{Notifications: []*notifpb.NotificationItem{{
+		Userid: followeeid,
+		Notificationtype: notifpb.NOTIFICATION_TYPE_FOLLOW,
+		Actorid: followerid,
+		Actoruname: followerUname,
+		Timestamp: time.Now().UnixNano()}}}
+	notifRes, err := gsrv.notifc.Notify(ctx, notifReq)
+	if err != nil {
+		log.Error().Msgf("Error notifying %v of follower %v: %v", followeeid, followerid, err)
+	} else if notifRes.Ok != notification.NOTIFICATION_QUERY_OK {
+		log.Error().Msgf("Cannot notify %v of follower %v: %v", followeeid, followerid, notifRes.Ok)
+	}
 }
 
 func (gsrv *GraphSrv) clearCache(ctx context.Context, followerid, followeeid int64) {
@@ -286,67 +562,55 @@ func (gsrv *GraphSrv) clearCache(ctx context.Context, followerid, followeeid int
 	if !gsrv.cachec.Delete(ctx, followee_key) {
 		log.Error().Msgf("cannot delete followees of %v", follower_key)
 	}
+	for _, userid := range []int64{followerid, followeeid} {
+		count_key := COUNT_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+		if !gsrv.cachec.Delete(ctx, count_key) {
+			log.Error().Msgf("cannot delete counts of %v", count_key)
+		}
+	}
+	gsrv.clearRecommendations(ctx, followerid)
 }
 
 // Define getFollowers and getFollowees explicitly for clarity
 func (gsrv *GraphSrv) getFollowers(ctx context.Context, userid int64) ([]int64, error) {
-	key := FOLLOWER_CACHE_PREFIX + strconv.FormatInt(userid, 10)
-	flwERInfo := &EdgeInfo{}
-	if followerItem, err := gsrv.cachec.Get(ctx, key); err != nil {
-		if err != memcache.ErrCacheMiss {
-			return nil, err
-		}
-		log.Debug().Msgf("FollowER %v cache miss", key)
-		err = gsrv.mongoFlwERCo.FindOne(
-			context.TODO(), &bson.M{"userid": userid}).Decode(&flwERInfo)
-		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				return make([]int64, 0), nil
-			}
-			return nil, err
-		}
-		log.Debug().Msgf("Found followERs for  %v in DB: %v", userid, flwERInfo)
-		encodedFlwERInfo, err := json.Marshal(flwERInfo)	
-		if err != nil {
-			log.Error().Msg(err.Error())
-			return nil, err
-		}
-		gsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedFlwERInfo})
-	} else {
-		log.Debug().Msgf("Found followERs for %v in cache!", userid)
-		json.Unmarshal(followerItem.Value, flwERInfo)
-	}	
-	return flwERInfo.Edges, nil
+	return gsrv.getAllEdges(ctx, userid, true)
 }
 
 func (gsrv *GraphSrv) getFollowees(ctx context.Context, userid int64) ([]int64, error) {
-	key := FOLLOWEE_CACHE_PREFIX + strconv.FormatInt(userid, 10)
-	flwEEInfo := &EdgeInfo{}
-	if followeeItem, err := gsrv.cachec.Get(ctx, key); err != nil {
+	return gsrv.getAllEdges(ctx, userid, false)
+}
+
+// getEdges returns the edges of userid in one of the block and mute
+// collections, cached under prefix.
+func (gsrv *GraphSrv) getEdges(
+		ctx context.Context, co *mongo.Collection, prefix string, userid int64) ([]int64, error) {
+	key := prefix + strconv.FormatInt(userid, 10)
+	edgeInfo := &EdgeInfo{}
+	if edgeItem, err := gsrv.cachec.Get(ctx, key); err != nil {
 		if err != memcache.ErrCacheMiss {
 			return nil, err
 		}
-		log.Debug().Msgf("FollowEE %v cache miss", key)
-		err = gsrv.mongoFlwEECo.FindOne(
-			context.TODO(), &bson.M{"userid": userid}).Decode(&flwEEInfo)
-		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				return make([]int64, 0), nil
-			}
+		log.Debug().Msgf("Edges %v cache miss", key)
+		err = co.FindOne(context.TODO(), &bson.M{"userid": userid}).Decode(&edgeInfo)
+		if err != nil && err != mongo.ErrNoDocuments {
 			return nil, err
 		}
-		log.Debug().Msgf("Found followEEs for  %v in DB: %v", userid, flwEEInfo)
-		encodedFlwEEInfo, err := json.Marshal(flwEEInfo)	
+		// cache users without edges as well; most users block and mute nobody
+		if err == mongo.ErrNoDocuments {
+			edgeInfo = &EdgeInfo{Userid: userid, Edges: make([]int64, 0)}
+		}
+		log.Debug().Msgf("Found edges %v in DB: %v", key, edgeInfo)
+		encodedEdgeInfo, err := json.Marshal(edgeInfo)
 		if err != nil {
 			log.Error().Msg(err.Error())
 			return nil, err
 		}
-		gsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedFlwEEInfo})
+		gsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedEdgeInfo})
 	} else {
-		log.Debug().Msgf("Found followEEs for %v in cache!", userid)
-		json.Unmarshal(followeeItem.Value, flwEEInfo)
-	}	
-	return flwEEInfo.Edges, nil
+		log.Debug().Msgf("Found edges %v in cache!", key)
+		json.Unmarshal(edgeItem.Value, edgeInfo)
+	}
+	return edgeInfo.Edges, nil
 }
 
 type EdgeInfo struct {
@@ -0,0 +1,173 @@
+package hashtag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// HashtagEntry is one post under one tag, stored as its own document so
+// that popular tags do not outgrow a single document.
+type HashtagEntry struct {
+	Hashtag   string `bson:"hashtag"`
+	Postid    int64  `bson:"postid"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
+// HashtagCount keeps the number of posts under a tag, so pages need not
+// count entries.
+type HashtagCount struct {
+	Hashtag string `bson:"hashtag"`
+	N       int32  `bson:"n"`
+}
+
+// HashtagHead is the cached start of a tag's timeline: its newest postids
+// and how many posts it has in all.
+type HashtagHead struct {
+	Postids []int64
+	Nitems  int32
+}
+
+// cacheKey hashes tags into cache keys, since tags may be longer than
+// memcached keys can be and hold characters keys cannot.
+func cacheKey(hashtag string) string {
+	sum := sha256.Sum256([]byte(hashtag))
+	return HASHTAG_CACHE_PREFIX + hex.EncodeToString(sum[:])
+}
+
+// addEntry puts postid under hashtag in a single upsert and reports whether
+// it is new. An edit tagging an old post keeps the post's time. When two
+// writes of the same entry race, the loser's insert fails on the unique
+// index; tried again, its upsert finds the entry.
+func (hsrv *HashtagSrv) addEntry(hashtag string, postid, timestamp int64) (bool, error) {
+	var res *mongo.UpdateResult
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		res, err = hsrv.mongoCo.UpdateOne(
+			context.TODO(), &bson.M{"hashtag": hashtag, "postid": postid},
+			&bson.M{"$set": bson.M{"timestamp": timestamp}},
+			options.Update().SetUpsert(true))
+		if !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	if res.UpsertedCount == 0 {
+		return false, nil
+	}
+	return true, hsrv.incCount(hashtag, 1)
+}
+
+// removeEntry takes postid from under hashtag and reports whether it was
+// there.
+func (hsrv *HashtagSrv) removeEntry(hashtag string, postid int64) (bool, error) {
+	res, err := hsrv.mongoCo.DeleteOne(context.TODO(), &bson.M{"hashtag": hashtag, "postid": postid})
+	if err != nil {
+		return false, err
+	}
+	if res.DeletedCount == 0 {
+		return false, nil
+	}
+	return true, hsrv.incCount(hashtag, -1)
+}
+
+func (hsrv *HashtagSrv) incCount(hashtag string, delta int32) error {
+	_, err := hsrv.mongoCountCo.UpdateOne(
+		context.TODO(), &bson.M{"hashtag": hashtag},
+		&bson.M{"$inc": bson.M{"n": delta}}, options.Update().SetUpsert(true))
+	return err
+}
+
+// getPostids returns the postids of hashtag from start to stop, newest
+// first, and how many posts it has in all. Pages within the first
+// HASHTAG_CACHE_ITEMS posts, by far the most read, come from the cached head.
+func (hsrv *HashtagSrv) getPostids(
+		ctx context.Context, hashtag string, start, stop int32) ([]int64, int32, error) {
+	if stop <= HASHTAG_CACHE_ITEMS {
+		head, err := hsrv.getHead(ctx, hashtag)
+		if err != nil {
+			return nil, 0, err
+		}
+		if start >= int32(len(head.Postids)) {
+			return []int64{}, head.Nitems, nil
+		}
+		if stop > int32(len(head.Postids)) {
+			stop = int32(len(head.Postids))
+		}
+		return head.Postids[start:stop], head.Nitems, nil
+	}
+	count, err := hsrv.getCount(hashtag)
+	if err != nil {
+		return nil, 0, err
+	}
+	postids, err := hsrv.findPostids(hashtag, int64(start), int64(stop-start))
+	if err != nil {
+		return nil, 0, err
+	}
+	return postids, count, nil
+}
+
+func (hsrv *HashtagSrv) getHead(ctx context.Context, hashtag string) (*HashtagHead, error) {
+	key := cacheKey(hashtag)
+	head := &HashtagHead{}
+	if item, err := hsrv.cachec.Get(ctx, key); err == nil {
+		if json.Unmarshal(item.Value, head) == nil {
+			log.Debug().Msgf("Found hashtag %v in cache!", hashtag)
+			return head, nil
+		}
+	} else if err != memcache.ErrCacheMiss {
+		return nil, err
+	}
+	log.Debug().Msgf("Hashtag %v cache miss", hashtag)
+	var err error
+	if head.Nitems, err = hsrv.getCount(hashtag); err != nil {
+		return nil, err
+	}
+	if head.Postids, err = hsrv.findPostids(hashtag, 0, HASHTAG_CACHE_ITEMS); err != nil {
+		return nil, err
+	}
+	if encoded, err := json.Marshal(head); err != nil {
+		log.Error().Msg(err.Error())
+	} else {
+		hsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encoded})
+	}
+	return head, nil
+}
+
+func (hsrv *HashtagSrv) getCount(hashtag string) (int32, error) {
+	count := &HashtagCount{}
+	err := hsrv.mongoCountCo.FindOne(context.TODO(), &bson.M{"hashtag": hashtag}).Decode(count)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return 0, err
+	}
+	return count.N, nil
+}
+
+func (hsrv *HashtagSrv) findPostids(hashtag string, skip, limit int64) ([]int64, error) {
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "postid", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit).
+		SetProjection(bson.M{"postid": 1})
+	cur, err := hsrv.mongoCo.Find(context.TODO(), &bson.M{"hashtag": hashtag}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]HashtagEntry, 0)
+	if err := cur.All(context.TODO(), &entries); err != nil {
+		return nil, err
+	}
+	postids := make([]int64, len(entries))
+	for idx, entry := range entries {
+		postids[idx] = entry.Postid
+	}
+	return postids, nil
+}
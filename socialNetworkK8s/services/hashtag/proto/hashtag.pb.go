@@ -0,0 +1,499 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.20.0
+// 	protoc        v3.12.4
+// source: services/hashtag/proto/hashtag.proto
+
+package proto
+
+import (
+	//proto1 "./services/post/proto"
+	proto1 "socialnetworkk8/services/post/proto"
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// WriteHashtags indexes a post under each of its hashtags.
+type WriteHashtagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashtags  []string `protobuf:"bytes,1,rep,name=hashtags,proto3" json:"hashtags,omitempty"`
+	Postid    int64    `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+	Timestamp int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *WriteHashtagsRequest) Reset() {
+	*x = WriteHashtagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteHashtagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteHashtagsRequest) ProtoMessage() {}
+
+func (x *WriteHashtagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteHashtagsRequest.ProtoReflect.Descriptor instead.
+func (*WriteHashtagsRequest) Descriptor() ([]byte, []int) {
+	return file_services_hashtag_proto_hashtag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WriteHashtagsRequest) GetHashtags() []string {
+	if x != nil {
+		return x.Hashtags
+	}
+	return nil
+}
+
+func (x *WriteHashtagsRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *WriteHashtagsRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type RemoveHashtagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashtags []string `protobuf:"bytes,1,rep,name=hashtags,proto3" json:"hashtags,omitempty"`
+	Postid   int64    `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+}
+
+func (x *RemoveHashtagsRequest) Reset() {
+	*x = RemoveHashtagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveHashtagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveHashtagsRequest) ProtoMessage() {}
+
+func (x *RemoveHashtagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveHashtagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveHashtagsRequest) Descriptor() ([]byte, []int) {
+	return file_services_hashtag_proto_hashtag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RemoveHashtagsRequest) GetHashtags() []string {
+	if x != nil {
+		return x.Hashtags
+	}
+	return nil
+}
+
+func (x *RemoveHashtagsRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+type WriteHashtagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+}
+
+func (x *WriteHashtagsResponse) Reset() {
+	*x = WriteHashtagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteHashtagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteHashtagsResponse) ProtoMessage() {}
+
+func (x *WriteHashtagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteHashtagsResponse.ProtoReflect.Descriptor instead.
+func (*WriteHashtagsResponse) Descriptor() ([]byte, []int) {
+	return file_services_hashtag_proto_hashtag_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WriteHashtagsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type ReadHashtagTimelineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashtag string `protobuf:"bytes,1,opt,name=hashtag,proto3" json:"hashtag,omitempty"`
+	Start   int32  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Stop    int32  `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+}
+
+func (x *ReadHashtagTimelineRequest) Reset() {
+	*x = ReadHashtagTimelineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadHashtagTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadHashtagTimelineRequest) ProtoMessage() {}
+
+func (x *ReadHashtagTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadHashtagTimelineRequest.ProtoReflect.Descriptor instead.
+func (*ReadHashtagTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_services_hashtag_proto_hashtag_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReadHashtagTimelineRequest) GetHashtag() string {
+	if x != nil {
+		return x.Hashtag
+	}
+	return ""
+}
+
+func (x *ReadHashtagTimelineRequest) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ReadHashtagTimelineRequest) GetStop() int32 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+type ReadHashtagTimelineResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok     string         `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Posts  []*proto1.Post `protobuf:"bytes,2,rep,name=posts,proto3" json:"posts,omitempty"`
+	Nitems int32          `protobuf:"varint,3,opt,name=nitems,proto3" json:"nitems,omitempty"`
+}
+
+func (x *ReadHashtagTimelineResponse) Reset() {
+	*x = ReadHashtagTimelineResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadHashtagTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadHashtagTimelineResponse) ProtoMessage() {}
+
+func (x *ReadHashtagTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_hashtag_proto_hashtag_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadHashtagTimelineResponse.ProtoReflect.Descriptor instead.
+func (*ReadHashtagTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_services_hashtag_proto_hashtag_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReadHashtagTimelineResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *ReadHashtagTimelineResponse) GetPosts() []*proto1.Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *ReadHashtagTimelineResponse) GetNitems() int32 {
+	if x != nil {
+		return x.Nitems
+	}
+	return 0
+}
+
+var File_services_hashtag_proto_hashtag_proto protoreflect.FileDescriptor
+
+var file_services_hashtag_proto_hashtag_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x74,
+	0x61, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x1a,
+	0x1e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x68, 0x0a, 0x14, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x68, 0x74,
+	0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x61, 0x73, 0x68, 0x74,
+	0x61, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x4b, 0x0a, 0x15, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x22, 0x27, 0x0a, 0x15, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48,
+	0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x22,
+	0x60, 0x0a, 0x1a, 0x52, 0x65, 0x61, 0x64, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x6f,
+	0x70, 0x22, 0x67, 0x0a, 0x1b, 0x52, 0x65, 0x61, 0x64, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67,
+	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b,
+	0x12, 0x20, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x73,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6e, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x32, 0x8d, 0x02, 0x0a, 0x07, 0x48,
+	0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x12, 0x4e, 0x0a, 0x0d, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48,
+	0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61,
+	0x67, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x74,
+	0x61, 0x67, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x74,
+	0x61, 0x67, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64,
+	0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12,
+	0x23, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x48, 0x61,
+	0x73, 0x68, 0x74, 0x61, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x48, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1a, 0x5a, 0x18, 0x2e, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_services_hashtag_proto_hashtag_proto_rawDescOnce sync.Once
+	file_services_hashtag_proto_hashtag_proto_rawDescData = file_services_hashtag_proto_hashtag_proto_rawDesc
+)
+
+func file_services_hashtag_proto_hashtag_proto_rawDescGZIP() []byte {
+	file_services_hashtag_proto_hashtag_proto_rawDescOnce.Do(func() {
+		file_services_hashtag_proto_hashtag_proto_rawDescData = protoimpl.X.CompressGZIP(file_services_hashtag_proto_hashtag_proto_rawDescData)
+	})
+	return file_services_hashtag_proto_hashtag_proto_rawDescData
+}
+
+var file_services_hashtag_proto_hashtag_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_services_hashtag_proto_hashtag_proto_goTypes = []interface{}{
+	(*WriteHashtagsRequest)(nil),        // 0: hashtag.WriteHashtagsRequest
+	(*RemoveHashtagsRequest)(nil),       // 1: hashtag.RemoveHashtagsRequest
+	(*WriteHashtagsResponse)(nil),       // 2: hashtag.WriteHashtagsResponse
+	(*ReadHashtagTimelineRequest)(nil),  // 3: hashtag.ReadHashtagTimelineRequest
+	(*ReadHashtagTimelineResponse)(nil), // 4: hashtag.ReadHashtagTimelineResponse
+	(*proto1.Post)(nil),                 // 5: post.Post
+}
+var file_services_hashtag_proto_hashtag_proto_depIdxs = []int32{
+	5, // 0: hashtag.ReadHashtagTimelineResponse.posts:type_name -> post.Post
+	0, // 1: hashtag.Hashtag.WriteHashtags:input_type -> hashtag.WriteHashtagsRequest
+	1, // 2: hashtag.Hashtag.RemoveHashtags:input_type -> hashtag.RemoveHashtagsRequest
+	3, // 3: hashtag.Hashtag.ReadHashtagTimeline:input_type -> hashtag.ReadHashtagTimelineRequest
+	2, // 4: hashtag.Hashtag.WriteHashtags:output_type -> hashtag.WriteHashtagsResponse
+	2, // 5: hashtag.Hashtag.RemoveHashtags:output_type -> hashtag.WriteHashtagsResponse
+	4, // 6: hashtag.Hashtag.ReadHashtagTimeline:output_type -> hashtag.ReadHashtagTimelineResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_services_hashtag_proto_hashtag_proto_init() }
+func file_services_hashtag_proto_hashtag_proto_init() {
+	if File_services_hashtag_proto_hashtag_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_services_hashtag_proto_hashtag_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteHashtagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_hashtag_proto_hashtag_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveHashtagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_hashtag_proto_hashtag_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteHashtagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_hashtag_proto_hashtag_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadHashtagTimelineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_hashtag_proto_hashtag_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadHashtagTimelineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_services_hashtag_proto_hashtag_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_services_hashtag_proto_hashtag_proto_goTypes,
+		DependencyIndexes: file_services_hashtag_proto_hashtag_proto_depIdxs,
+		MessageInfos:      file_services_hashtag_proto_hashtag_proto_msgTypes,
+	}.Build()
+	File_services_hashtag_proto_hashtag_proto = out.File
+	file_services_hashtag_proto_hashtag_proto_rawDesc = nil
+	file_services_hashtag_proto_hashtag_proto_goTypes = nil
+	file_services_hashtag_proto_hashtag_proto_depIdxs = nil
+}
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: services/hashtag/proto/hashtag.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Hashtag_WriteHashtags_FullMethodName       = "/hashtag.Hashtag/WriteHashtags"
+	Hashtag_RemoveHashtags_FullMethodName      = "/hashtag.Hashtag/RemoveHashtags"
+	Hashtag_ReadHashtagTimeline_FullMethodName = "/hashtag.Hashtag/ReadHashtagTimeline"
+)
+
+// HashtagClient is the client API for Hashtag service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HashtagClient interface {
+	WriteHashtags(ctx context.Context, in *WriteHashtagsRequest, opts ...grpc.CallOption) (*WriteHashtagsResponse, error)
+	RemoveHashtags(ctx context.Context, in *RemoveHashtagsRequest, opts ...grpc.CallOption) (*WriteHashtagsResponse, error)
+	ReadHashtagTimeline(ctx context.Context, in *ReadHashtagTimelineRequest, opts ...grpc.CallOption) (*ReadHashtagTimelineResponse, error)
+}
+
+type hashtagClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHashtagClient(cc grpc.ClientConnInterface) HashtagClient {
+	return &hashtagClient{cc}
+}
+
+func (c *hashtagClient) WriteHashtags(ctx context.Context, in *WriteHashtagsRequest, opts ...grpc.CallOption) (*WriteHashtagsResponse, error) {
+	out := new(WriteHashtagsResponse)
+	err := c.cc.Invoke(ctx, Hashtag_WriteHashtags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashtagClient) RemoveHashtags(ctx context.Context, in *RemoveHashtagsRequest, opts ...grpc.CallOption) (*WriteHashtagsResponse, error) {
+	out := new(WriteHashtagsResponse)
+	err := c.cc.Invoke(ctx, Hashtag_RemoveHashtags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hashtagClient) ReadHashtagTimeline(ctx context.Context, in *ReadHashtagTimelineRequest, opts ...grpc.CallOption) (*ReadHashtagTimelineResponse, error) {
+	out := new(ReadHashtagTimelineResponse)
+	err := c.cc.Invoke(ctx, Hashtag_ReadHashtagTimeline_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HashtagServer is the server API for Hashtag service.
+// All implementations must embed UnimplementedHashtagServer
+// for forward compatibility
+type HashtagServer interface {
+	WriteHashtags(context.Context, *WriteHashtagsRequest) (*WriteHashtagsResponse, error)
+	RemoveHashtags(context.Context, *RemoveHashtagsRequest) (*WriteHashtagsResponse, error)
+	ReadHashtagTimeline(context.Context, *ReadHashtagTimelineRequest) (*ReadHashtagTimelineResponse, error)
+	mustEmbedUnimplementedHashtagServer()
+}
+
+// UnimplementedHashtagServer must be embedded to have forward compatible implementations.
+type UnimplementedHashtagServer struct {
+}
+
+func (UnimplementedHashtagServer) WriteHashtags(context.Context, *WriteHashtagsRequest) (*WriteHashtagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteHashtags not implemented")
+}
+func (UnimplementedHashtagServer) RemoveHashtags(context.Context, *RemoveHashtagsRequest) (*WriteHashtagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveHashtags not implemented")
+}
+func (UnimplementedHashtagServer) ReadHashtagTimeline(context.Context, *ReadHashtagTimelineRequest) (*ReadHashtagTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadHashtagTimeline not implemented")
+}
+func (UnimplementedHashtagServer) mustEmbedUnimplementedHashtagServer() {}
+
+// UnsafeHashtagServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HashtagServer will
+// result in compilation errors.
+type UnsafeHashtagServer interface {
+	mustEmbedUnimplementedHashtagServer()
+}
+
+func RegisterHashtagServer(s grpc.ServiceRegistrar, srv HashtagServer) {
+	s.RegisterService(&Hashtag_ServiceDesc, srv)
+}
+
+func _Hashtag_WriteHashtags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteHashtagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashtagServer).WriteHashtags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hashtag_WriteHashtags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashtagServer).WriteHashtags(ctx, req.(*WriteHashtagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hashtag_RemoveHashtags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveHashtagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashtagServer).RemoveHashtags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hashtag_RemoveHashtags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashtagServer).RemoveHashtags(ctx, req.(*RemoveHashtagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hashtag_ReadHashtagTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadHashtagTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HashtagServer).ReadHashtagTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hashtag_ReadHashtagTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HashtagServer).ReadHashtagTimeline(ctx, req.(*ReadHashtagTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Hashtag_ServiceDesc is the grpc.ServiceDesc for Hashtag service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Hashtag_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashtag.Hashtag",
+	HandlerType: (*HashtagServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WriteHashtags",
+			Handler:    _Hashtag_WriteHashtags_Handler,
+		},
+		{
+			MethodName: "RemoveHashtags",
+			Handler:    _Hashtag_RemoveHashtags_Handler,
+		},
+		{
+			MethodName: "ReadHashtagTimeline",
+			Handler:    _Hashtag_ReadHashtagTimeline_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services/hashtag/proto/hashtag.proto",
+}
@@ -0,0 +1,269 @@
+package hashtag
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/dialer"
+	"socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/hashtag/proto"
+	"socialnetworkk8/services/post"
+	postpb "socialnetworkk8/services/post/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	HASHTAG_SRV_NAME = "srv-hashtag"
+	HASHTAG_QUERY_OK = "OK"
+	// a page with nothing on it: the tag has no posts or the page is past
+	// its end
+	HASHTAG_QUERY_EMPTY = "No hashtag item"
+	HASHTAG_CACHE_PREFIX = "hashtag_"
+	// newest posts of a tag kept in its cache item
+	HASHTAG_CACHE_ITEMS = 100
+)
+
+type HashtagSrv struct {
+	proto.UnimplementedHashtagServer
+	uuid         string
+	cachec       *cacheclnt.CacheClnt
+	mongoCo      *mongo.Collection
+	mongoCountCo *mongo.Collection
+	postc        postpb.PostStorageClient
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	wCounter     *tracing.Counter
+	rCounter     *tracing.Counter
+	dCounter     *tracing.Counter
+}
+
+func MakeHashtagSrv() *HashtagSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["HashtagPort"])
+	serv_ip := result["HashtagIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "hashtag", *jaegeraddr)
+	tracer, err := tracing.Init("hashtag", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start cache and DB connections")
+	cachec := cacheclnt.MakeCacheClnt()
+
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	collection := mongoClient.Database("socialnetwork").Collection("hashtag-entry")
+	countCollection := mongoClient.Database("socialnetwork").Collection("hashtag-count")
+	// one entry per tag and post, which racing writes of an entry depend on
+	names, err := collection.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "hashtag", Value: 1}, {Key: "postid", Value: 1}},
+			Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "hashtag", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "postid", Value: -1}}},
+	})
+	if err != nil {
+		log.Panic().Msgf("cannot create hashtag indexes: %v", err)
+	}
+	log.Info().Msgf("Name of indexes created: %v", names)
+	name, err := countCollection.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "hashtag", Value: 1}}, Options: options.Index().SetUnique(true)})
+	if err != nil {
+		log.Panic().Msgf("cannot create hashtag count index: %v", err)
+	}
+	log.Info().Msgf("Name of index created for counts: %v", name)
+	log.Info().Msg("New mongo session successfull...")
+
+	return &HashtagSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		cachec:       cachec,
+		mongoCo:      collection,
+		mongoCountCo: countCollection,
+		wCounter:     tracing.MakeCounter("Write-Hashtags"),
+		rCounter:     tracing.MakeCounter("Read-Hashtag-Timeline"),
+		dCounter:     tracing.MakeCounter("Remove-Hashtags"),
+	}
+}
+
+// Run starts the server
+func (hsrv *HashtagSrv) Run() error {
+	if hsrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC clients...")
+	conn, err := dialer.Dial(
+		post.POST_SRV_NAME,
+		hsrv.Registry.Client,
+		dialer.WithTracer(hsrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	hsrv.postc = postpb.NewPostStorageClient(conn)
+
+	log.Info().Msg("Initializing gRPC Server...")
+	hsrv.uuid = uuid.New().String()
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(hsrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterHashtagServer(grpcSrv, hsrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", hsrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = hsrv.Registry.Register(HASHTAG_SRV_NAME, hsrv.uuid, hsrv.IpAddr, hsrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+func (hsrv *HashtagSrv) WriteHashtags(
+		ctx context.Context, req *proto.WriteHashtagsRequest) (*proto.WriteHashtagsResponse, error) {
+	t0 := time.Now()
+	defer hsrv.wCounter.AddTimeSince(t0)
+	res := &proto.WriteHashtagsResponse{Ok: "No"}
+	for _, hashtag := range req.Hashtags {
+		// a retried write finds the post already there and leaves it be
+		if _, err := hsrv.addEntry(hashtag, req.Postid, req.Timestamp); err != nil {
+			return nil, err
+		}
+		hsrv.clearCache(ctx, hashtag)
+	}
+	res.Ok = HASHTAG_QUERY_OK
+	return res, nil
+}
+
+func (hsrv *HashtagSrv) RemoveHashtags(
+		ctx context.Context, req *proto.RemoveHashtagsRequest) (*proto.WriteHashtagsResponse, error) {
+	t0 := time.Now()
+	defer hsrv.dCounter.AddTimeSince(t0)
+	res := &proto.WriteHashtagsResponse{Ok: "No"}
+	for _, hashtag := range req.Hashtags {
+		if _, err := hsrv.removeEntry(hashtag, req.Postid); err != nil {
+			return nil, err
+		}
+		hsrv.clearCache(ctx, hashtag)
+	}
+	res.Ok = HASHTAG_QUERY_OK
+	return res, nil
+}
+
+func (hsrv *HashtagSrv) ReadHashtagTimeline(
+		ctx context.Context, req *proto.ReadHashtagTimelineRequest) (
+		*proto.ReadHashtagTimelineResponse, error) {
+	t0 := time.Now()
+	defer hsrv.rCounter.AddTimeSince(t0)
+	res := &proto.ReadHashtagTimelineResponse{Ok: "No"}
+	start, stop := req.Start, req.Stop
+	if start < 0 || start > stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v", start, stop)
+		return res, nil
+	}
+	var postids []int64
+	var err error
+	if start == stop {
+		res.Nitems, err = hsrv.getCount(req.Hashtag)
+	} else {
+		postids, res.Nitems, err = hsrv.getPostids(ctx, req.Hashtag, start, stop)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(postids) == 0 {
+		res.Ok = HASHTAG_QUERY_EMPTY
+		return res, nil
+	}
+	readPostReq := &postpb.ReadPostsRequest{Postids: postids}
+	readPostRes, err := hsrv.postc.ReadPosts(ctx, readPostReq)
+	if err != nil {
+		return nil, err
+	}
+	res.Ok = readPostRes.Ok
+	res.Posts = readPostRes.Posts
+	return res, nil
+}
+
+func (hsrv *HashtagSrv) clearCache(ctx context.Context, hashtag string) {
+	key := cacheKey(hashtag)
+	if !hsrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete hashtag timeline of %v", hashtag)
+	}
+}
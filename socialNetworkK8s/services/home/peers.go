@@ -0,0 +1,121 @@
+package home
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"socialnetworkk8/dialer"
+	"socialnetworkk8/services/home/proto"
+	tlpb "socialnetworkk8/services/timeline/proto"
+)
+
+const (
+	// how often the other home replicas are looked up in consul
+	PEER_REFRESH = 10 * time.Second
+	PEER_PUBLISH_TIMEOUT = time.Second
+)
+
+type peer struct {
+	conn   *grpc.ClientConn
+	client proto.HomeClient
+}
+
+// peers keeps a connection to each other home replica, by consul service id.
+type peers struct {
+	mu    sync.Mutex
+	peers map[string]*peer
+}
+
+func makePeers() *peers {
+	return &peers{peers: make(map[string]*peer)}
+}
+
+func (ps *peers) list() []*peer {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	list := make([]*peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		list = append(list, p)
+	}
+	return list
+}
+
+// refreshPeers connects to replicas that joined and drops those that left.
+func (hsrv *HomeSrv) refreshPeers() {
+	srvs, _, err := hsrv.Registry.Health().Service(HOME_SRV_NAME, "", true, nil)
+	if err != nil {
+		log.Error().Msgf("Cannot look up home replicas: %v", err)
+		return
+	}
+	ps := hsrv.peers
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	live := make(map[string]bool)
+	for _, srv := range srvs {
+		id := srv.Service.ID
+		if id == hsrv.uuid {
+			continue
+		}
+		live[id] = true
+		if ps.peers[id] != nil {
+			continue
+		}
+		addr := net.JoinHostPort(srv.Service.Address, strconv.Itoa(srv.Service.Port))
+		// without a registry, the dialer dials addr as given
+		conn, err := dialer.Dial(addr, nil, dialer.WithTracer(hsrv.Tracer))
+		if err != nil {
+			log.Error().Msgf("Cannot dial home replica %v at %v: %v", id, addr, err)
+			continue
+		}
+		ps.peers[id] = &peer{conn: conn, client: proto.NewHomeClient(conn)}
+	}
+	for id, p := range ps.peers {
+		if !live[id] {
+			p.conn.Close()
+			delete(ps.peers, id)
+		}
+	}
+}
+
+func (hsrv *HomeSrv) runPeers() {
+	for {
+		hsrv.refreshPeers()
+		time.Sleep(PEER_REFRESH)
+	}
+}
+
+// publish delivers event to the subscribers of userids on this replica and
+// forwards it to the other replicas, without waiting for them: a replica
+// that is slow or gone only costs its own subscribers the event.
+func (hsrv *HomeSrv) publish(userids []int64, event *proto.HomeTimelineEvent) {
+	if len(userids) == 0 {
+		return
+	}
+	for _, userid := range userids {
+		hsrv.hub.publish(userid, event)
+	}
+	req := &proto.PublishHomeEventsRequest{Userids: userids, Event: event}
+	for _, p := range hsrv.peers.list() {
+		go func(p *peer) {
+			ctx, cancel := context.WithTimeout(context.Background(), PEER_PUBLISH_TIMEOUT)
+			defer cancel()
+			if _, err := p.client.PublishHomeEvents(ctx, req); err != nil {
+				log.Error().Msgf("Cannot forward home events to %v: %v", p.conn.Target(), err)
+			}
+		}(p)
+	}
+}
+
+// PublishHomeEvents delivers events forwarded by another replica to the
+// subscribers on this one.
+func (hsrv *HomeSrv) PublishHomeEvents(
+		ctx context.Context, req *proto.PublishHomeEventsRequest) (*tlpb.WriteTimelineResponse, error) {
+	for _, userid := range req.Userids {
+		hsrv.hub.publish(userid, req.Event)
+	}
+	return &tlpb.WriteTimelineResponse{Ok: HOME_QUERY_OK}, nil
+}
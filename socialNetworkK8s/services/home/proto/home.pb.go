@@ -98,6 +98,250 @@ func (x *WriteHomeTimelineRequest) GetUsermentionids() []int64 {
 	return nil
 }
 
+type RemoveHomeTimelineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid         int64   `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Postid         int64   `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+	Usermentionids []int64 `protobuf:"varint,3,rep,packed,name=usermentionids,proto3" json:"usermentionids,omitempty"`
+}
+
+func (x *RemoveHomeTimelineRequest) Reset() {
+	*x = RemoveHomeTimelineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_home_proto_home_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveHomeTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveHomeTimelineRequest) ProtoMessage() {}
+
+func (x *RemoveHomeTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_home_proto_home_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveHomeTimelineRequest.ProtoReflect.Descriptor instead.
+func (*RemoveHomeTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_services_home_proto_home_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RemoveHomeTimelineRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *RemoveHomeTimelineRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *RemoveHomeTimelineRequest) GetUsermentionids() []int64 {
+	if x != nil {
+		return x.Usermentionids
+	}
+	return nil
+}
+
+// Subscribers get an event for each post added to the home timeline of
+// userid, whichever replica they are connected to, from the time the
+// stream's header arrives.
+type SubscribeHomeTimelineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+}
+
+func (x *SubscribeHomeTimelineRequest) Reset() {
+	*x = SubscribeHomeTimelineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_home_proto_home_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeHomeTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeHomeTimelineRequest) ProtoMessage() {}
+
+func (x *SubscribeHomeTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_home_proto_home_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeHomeTimelineRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeHomeTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_services_home_proto_home_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubscribeHomeTimelineRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+// dropped counts the events lost since the previous one because the
+// subscriber fell behind; the home timeline still has their posts.
+type HomeTimelineEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postid    int64 `protobuf:"varint,1,opt,name=postid,proto3" json:"postid,omitempty"`
+	Authorid  int64 `protobuf:"varint,2,opt,name=authorid,proto3" json:"authorid,omitempty"`
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Dropped   int64 `protobuf:"varint,4,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *HomeTimelineEvent) Reset() {
+	*x = HomeTimelineEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_home_proto_home_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HomeTimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HomeTimelineEvent) ProtoMessage() {}
+
+func (x *HomeTimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_services_home_proto_home_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HomeTimelineEvent.ProtoReflect.Descriptor instead.
+func (*HomeTimelineEvent) Descriptor() ([]byte, []int) {
+	return file_services_home_proto_home_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HomeTimelineEvent) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *HomeTimelineEvent) GetAuthorid() int64 {
+	if x != nil {
+		return x.Authorid
+	}
+	return 0
+}
+
+func (x *HomeTimelineEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HomeTimelineEvent) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Home replicas forward the events of a write to each other, so that
+// subscribers connected to any replica hear of it. Receivers deliver event
+// to their own subscribers of userids only.
+type PublishHomeEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userids []int64            `protobuf:"varint,1,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+	Event   *HomeTimelineEvent `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *PublishHomeEventsRequest) Reset() {
+	*x = PublishHomeEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_home_proto_home_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublishHomeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishHomeEventsRequest) ProtoMessage() {}
+
+func (x *PublishHomeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_home_proto_home_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishHomeEventsRequest.ProtoReflect.Descriptor instead.
+func (*PublishHomeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_services_home_proto_home_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PublishHomeEventsRequest) GetUserids() []int64 {
+	if x != nil {
+		return x.Userids
+	}
+	return nil
+}
+
+func (x *PublishHomeEventsRequest) GetEvent() *HomeTimelineEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
 var File_services_home_proto_home_proto protoreflect.FileDescriptor
 
 var file_services_home_proto_home_proto_rawDesc = []byte{
@@ -115,20 +359,61 @@ var file_services_home_proto_home_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x75, 0x73, 0x65,
 	0x72, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
 	0x03, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x64,
-	0x73, 0x32, 0xaf, 0x01, 0x0a, 0x04, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x54, 0x0a, 0x11, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12,
-	0x1e, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x6f, 0x6d, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1f, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x51, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65,
-	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1d, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e,
-	0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52,
-	0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x17, 0x5a, 0x15, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2f, 0x68, 0x6f, 0x6d, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x22, 0x73, 0x0a, 0x19, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x12, 0x26,
+	0x0a, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x69, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x6d, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x69, 0x64, 0x73, 0x22, 0x36, 0x0a, 0x1c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x22, 0x7f,
+	0x0a, 0x11, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22,
+	0x63, 0x0a, 0x18, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x48, 0x6f, 0x6d, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x69, 0x64, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x2e, 0x48, 0x6f, 0x6d, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x32, 0xb5, 0x03, 0x0a, 0x04, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x54, 0x0a,
+	0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x1e, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x48,
+	0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x48, 0x6f, 0x6d, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1d, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1f, 0x2e, 0x68,
+	0x6f, 0x6d, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56,
+	0x0a, 0x15, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x22, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x2e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x68, 0x6f,
+	0x6d, 0x65, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x11, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x48, 0x6f, 0x6d, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x68, 0x6f,
+	0x6d, 0x65, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x48, 0x6f, 0x6d, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x17, 0x5a, 0x15,
+	0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x68, 0x6f, 0x6d, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -143,23 +428,34 @@ func file_services_home_proto_home_proto_rawDescGZIP() []byte {
 	return file_services_home_proto_home_proto_rawDescData
 }
 
-var file_services_home_proto_home_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_services_home_proto_home_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_services_home_proto_home_proto_goTypes = []interface{}{
 	(*WriteHomeTimelineRequest)(nil),     // 0: home.WriteHomeTimelineRequest
-	(*proto1.ReadTimelineRequest)(nil),   // 1: timeline.ReadTimelineRequest
-	(*proto1.WriteTimelineResponse)(nil), // 2: timeline.WriteTimelineResponse
-	(*proto1.ReadTimelineResponse)(nil),  // 3: timeline.ReadTimelineResponse
+	(*RemoveHomeTimelineRequest)(nil),    // 1: home.RemoveHomeTimelineRequest
+	(*SubscribeHomeTimelineRequest)(nil), // 2: home.SubscribeHomeTimelineRequest
+	(*HomeTimelineEvent)(nil),            // 3: home.HomeTimelineEvent
+	(*PublishHomeEventsRequest)(nil),     // 4: home.PublishHomeEventsRequest
+	(*proto1.ReadTimelineRequest)(nil),   // 5: timeline.ReadTimelineRequest
+	(*proto1.WriteTimelineResponse)(nil), // 6: timeline.WriteTimelineResponse
+	(*proto1.ReadTimelineResponse)(nil),  // 7: timeline.ReadTimelineResponse
 }
 var file_services_home_proto_home_proto_depIdxs = []int32{
-	0, // 0: home.Home.WriteHomeTimeline:input_type -> home.WriteHomeTimelineRequest
-	1, // 1: home.Home.ReadHomeTimeline:input_type -> timeline.ReadTimelineRequest
-	2, // 2: home.Home.WriteHomeTimeline:output_type -> timeline.WriteTimelineResponse
-	3, // 3: home.Home.ReadHomeTimeline:output_type -> timeline.ReadTimelineResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: home.PublishHomeEventsRequest.event:type_name -> home.HomeTimelineEvent
+	0, // 1: home.Home.WriteHomeTimeline:input_type -> home.WriteHomeTimelineRequest
+	5, // 2: home.Home.ReadHomeTimeline:input_type -> timeline.ReadTimelineRequest
+	1, // 3: home.Home.RemoveHomeTimeline:input_type -> home.RemoveHomeTimelineRequest
+	2, // 4: home.Home.SubscribeHomeTimeline:input_type -> home.SubscribeHomeTimelineRequest
+	4, // 5: home.Home.PublishHomeEvents:input_type -> home.PublishHomeEventsRequest
+	6, // 6: home.Home.WriteHomeTimeline:output_type -> timeline.WriteTimelineResponse
+	7, // 7: home.Home.ReadHomeTimeline:output_type -> timeline.ReadTimelineResponse
+	6, // 8: home.Home.RemoveHomeTimeline:output_type -> timeline.WriteTimelineResponse
+	3, // 9: home.Home.SubscribeHomeTimeline:output_type -> home.HomeTimelineEvent
+	6, // 10: home.Home.PublishHomeEvents:output_type -> timeline.WriteTimelineResponse
+	6, // [6:11] is the sub-list for method output_type
+	1, // [1:6] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_services_home_proto_home_proto_init() }
@@ -180,6 +476,54 @@ func file_services_home_proto_home_proto_init() {
 				return nil
 			}
 		}
+		file_services_home_proto_home_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveHomeTimelineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_home_proto_home_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeHomeTimelineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_home_proto_home_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HomeTimelineEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_home_proto_home_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublishHomeEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -187,7 +531,7 @@ func file_services_home_proto_home_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_home_proto_home_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,8 +21,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Home_WriteHomeTimeline_FullMethodName = "/home.Home/WriteHomeTimeline"
-	Home_ReadHomeTimeline_FullMethodName  = "/home.Home/ReadHomeTimeline"
+	Home_WriteHomeTimeline_FullMethodName     = "/home.Home/WriteHomeTimeline"
+	Home_ReadHomeTimeline_FullMethodName      = "/home.Home/ReadHomeTimeline"
+	Home_RemoveHomeTimeline_FullMethodName    = "/home.Home/RemoveHomeTimeline"
+	Home_SubscribeHomeTimeline_FullMethodName = "/home.Home/SubscribeHomeTimeline"
+	Home_PublishHomeEvents_FullMethodName     = "/home.Home/PublishHomeEvents"
 )
 
 // HomeClient is the client API for Home service.
@@ -31,6 +34,9 @@ const (
 type HomeClient interface {
 	WriteHomeTimeline(ctx context.Context, in *WriteHomeTimelineRequest, opts ...grpc.CallOption) (*proto.WriteTimelineResponse, error)
 	ReadHomeTimeline(ctx context.Context, in *proto.ReadTimelineRequest, opts ...grpc.CallOption) (*proto.ReadTimelineResponse, error)
+	RemoveHomeTimeline(ctx context.Context, in *RemoveHomeTimelineRequest, opts ...grpc.CallOption) (*proto.WriteTimelineResponse, error)
+	SubscribeHomeTimeline(ctx context.Context, in *SubscribeHomeTimelineRequest, opts ...grpc.CallOption) (Home_SubscribeHomeTimelineClient, error)
+	PublishHomeEvents(ctx context.Context, in *PublishHomeEventsRequest, opts ...grpc.CallOption) (*proto.WriteTimelineResponse, error)
 }
 
 type homeClient struct {
@@ -59,12 +65,65 @@ func (c *homeClient) ReadHomeTimeline(ctx context.Context, in *proto.ReadTimelin
 	return out, nil
 }
 
+func (c *homeClient) RemoveHomeTimeline(ctx context.Context, in *RemoveHomeTimelineRequest, opts ...grpc.CallOption) (*proto.WriteTimelineResponse, error) {
+	out := new(proto.WriteTimelineResponse)
+	err := c.cc.Invoke(ctx, Home_RemoveHomeTimeline_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *homeClient) SubscribeHomeTimeline(ctx context.Context, in *SubscribeHomeTimelineRequest, opts ...grpc.CallOption) (Home_SubscribeHomeTimelineClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Home_ServiceDesc.Streams[0], Home_SubscribeHomeTimeline_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &homeSubscribeHomeTimelineClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Home_SubscribeHomeTimelineClient interface {
+	Recv() (*HomeTimelineEvent, error)
+	grpc.ClientStream
+}
+
+type homeSubscribeHomeTimelineClient struct {
+	grpc.ClientStream
+}
+
+func (x *homeSubscribeHomeTimelineClient) Recv() (*HomeTimelineEvent, error) {
+	m := new(HomeTimelineEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *homeClient) PublishHomeEvents(ctx context.Context, in *PublishHomeEventsRequest, opts ...grpc.CallOption) (*proto.WriteTimelineResponse, error) {
+	out := new(proto.WriteTimelineResponse)
+	err := c.cc.Invoke(ctx, Home_PublishHomeEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HomeServer is the server API for Home service.
 // All implementations must embed UnimplementedHomeServer
 // for forward compatibility
 type HomeServer interface {
 	WriteHomeTimeline(context.Context, *WriteHomeTimelineRequest) (*proto.WriteTimelineResponse, error)
 	ReadHomeTimeline(context.Context, *proto.ReadTimelineRequest) (*proto.ReadTimelineResponse, error)
+	RemoveHomeTimeline(context.Context, *RemoveHomeTimelineRequest) (*proto.WriteTimelineResponse, error)
+	SubscribeHomeTimeline(*SubscribeHomeTimelineRequest, Home_SubscribeHomeTimelineServer) error
+	PublishHomeEvents(context.Context, *PublishHomeEventsRequest) (*proto.WriteTimelineResponse, error)
 	mustEmbedUnimplementedHomeServer()
 }
 
@@ -78,6 +137,15 @@ func (UnimplementedHomeServer) WriteHomeTimeline(context.Context, *WriteHomeTime
 func (UnimplementedHomeServer) ReadHomeTimeline(context.Context, *proto.ReadTimelineRequest) (*proto.ReadTimelineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadHomeTimeline not implemented")
 }
+func (UnimplementedHomeServer) RemoveHomeTimeline(context.Context, *RemoveHomeTimelineRequest) (*proto.WriteTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveHomeTimeline not implemented")
+}
+func (UnimplementedHomeServer) SubscribeHomeTimeline(*SubscribeHomeTimelineRequest, Home_SubscribeHomeTimelineServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeHomeTimeline not implemented")
+}
+func (UnimplementedHomeServer) PublishHomeEvents(context.Context, *PublishHomeEventsRequest) (*proto.WriteTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishHomeEvents not implemented")
+}
 func (UnimplementedHomeServer) mustEmbedUnimplementedHomeServer() {}
 
 // UnsafeHomeServer may be embedded to opt out of forward compatibility for this service.
@@ -127,6 +195,63 @@ func _Home_ReadHomeTimeline_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Home_RemoveHomeTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveHomeTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HomeServer).RemoveHomeTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Home_RemoveHomeTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HomeServer).RemoveHomeTimeline(ctx, req.(*RemoveHomeTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Home_SubscribeHomeTimeline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHomeTimelineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HomeServer).SubscribeHomeTimeline(m, &homeSubscribeHomeTimelineServer{stream})
+}
+
+type Home_SubscribeHomeTimelineServer interface {
+	Send(*HomeTimelineEvent) error
+	grpc.ServerStream
+}
+
+type homeSubscribeHomeTimelineServer struct {
+	grpc.ServerStream
+}
+
+func (x *homeSubscribeHomeTimelineServer) Send(m *HomeTimelineEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Home_PublishHomeEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishHomeEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HomeServer).PublishHomeEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Home_PublishHomeEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HomeServer).PublishHomeEvents(ctx, req.(*PublishHomeEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Home_ServiceDesc is the grpc.ServiceDesc for Home service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -142,7 +267,21 @@ var Home_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadHomeTimeline",
 			Handler:    _Home_ReadHomeTimeline_Handler,
 		},
+		{
+			MethodName: "RemoveHomeTimeline",
+			Handler:    _Home_RemoveHomeTimeline_Handler,
+		},
+		{
+			MethodName: "PublishHomeEvents",
+			Handler:    _Home_PublishHomeEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeHomeTimeline",
+			Handler:       _Home_SubscribeHomeTimeline_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/home/proto/home.proto",
 }
@@ -39,6 +39,8 @@ const (
 	HOME_SRV_NAME = "srv-home"
 	HOME_QUERY_OK = "OK"
 	HOME_CACHE_PREFIX = "home_"
+	// how far back a write looks for the post it writes, in case it is a retry
+	HOME_DEDUP_ITEMS = 1000
 )
 
 type HomeSrv struct {
@@ -47,6 +49,8 @@ type HomeSrv struct {
 	cachec       *cacheclnt.CacheClnt
 	postc        postpb.PostStorageClient
 	graphc       graphpb.GraphClient
+	hub          *hub
+	peers        *peers
 	Registry     *registry.Client
 	Tracer       opentracing.Tracer
 	Port         int
@@ -57,6 +61,7 @@ type HomeSrv struct {
 	cCounter     *tracing.Counter
 	uCounter     *tracing.Counter
 	iCounter     *tracing.Counter
+	dCounter     *tracing.Counter
 }
 
 func MakeHomeSrv() *HomeSrv {
@@ -104,12 +109,15 @@ func MakeHomeSrv() *HomeSrv {
 		Tracer:       tracer,
 		Registry:     registry,
 		cachec:       cachec,
+		hub:          makeHub(),
+		peers:        makePeers(),
 		wCounter:     tracing.MakeCounter("Write-Home"),
 		rCounter:     tracing.MakeCounter("Read-Home"),
 		gCounter:     tracing.MakeCounter("Get-Home"),
 		uCounter:     tracing.MakeCounter("Update-Homes"),
 		cCounter:     tracing.MakeCounter("Write-Home-Cache"),
 		iCounter:     tracing.MakeCounter("Write-Home-Inner"),
+		dCounter:     tracing.MakeCounter("Remove-Home"),
 	}
 }
 
@@ -172,6 +180,7 @@ func (hsrv *HomeSrv) Run() error {
 		return fmt.Errorf("failed register: %v", err)
 	}
 	log.Info().Msg("Successfully registered in consul")
+	go hsrv.runPeers()
 	return grpcSrv.Serve(lis)
 }
 
@@ -182,12 +191,11 @@ func (hsrv *HomeSrv) WriteHomeTimeline(
 	defer hsrv.wCounter.AddTimeSince(t0)
 	res := &tlpb.WriteTimelineResponse{Ok: "No"}
 	otherUserIds := make(map[int64]bool, 0)
-	argFollower := &graphpb.GetFollowersRequest{Followeeid: req.Userid}
-	resFollower, err := hsrv.graphc.GetFollowers(ctx, argFollower)
+	followers, err := hsrv.getFollowers(ctx, req.Userid)
 	if err != nil {
 		return nil, err
 	}
-	for _, followerid := range resFollower.Userids {
+	for _, followerid := range followers {
 		otherUserIds[followerid] = true
 	}
 	for _, mentionid := range req.Usermentionids {
@@ -195,6 +203,9 @@ func (hsrv *HomeSrv) WriteHomeTimeline(
 	}
 	log.Debug().Msgf("Updating timeline for %v users", len(otherUserIds))
 	missing := false
+	// subscribers hear of the post even if their home timeline could not be
+	// updated; only a retry, whose post is already there, is not announced
+	notified := make([]int64, 0, len(otherUserIds))
 	t1 := time.Now()
 	defer hsrv.uCounter.AddTimeSince(t1)
 	for userid := range otherUserIds {
@@ -204,6 +215,10 @@ func (hsrv *HomeSrv) WriteHomeTimeline(
 		if err != nil {
 			res.Ok = res.Ok + fmt.Sprintf(" Error getting home timeline for %v.", userid)	
 			missing = true
+			notified = append(notified, userid)
+			continue
+		}
+		if hometl.ContainsRecent(req.Postid, HOME_DEDUP_ITEMS) {
 			continue
 		}
 		hometl.Postids = append(hometl.Postids, req.Postid)	
@@ -217,35 +232,106 @@ func (hsrv *HomeSrv) WriteHomeTimeline(
 		t3 := time.Now()
 		hsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedHometl})
 		hsrv.cCounter.AddTimeSince(t3)
+		notified = append(notified, userid)
 		hsrv.iCounter.AddTimeSince(t2)
 	}
+	hsrv.publish(notified, &proto.HomeTimelineEvent{
+		Postid: req.Postid, Authorid: req.Userid, Timestamp: req.Timestamp})
+	if !missing {
+		res.Ok = HOME_QUERY_OK
+	}
+	return res, nil 
+}
+
+func (hsrv *HomeSrv) RemoveHomeTimeline(
+		ctx context.Context, req *proto.RemoveHomeTimelineRequest) (
+		*tlpb.WriteTimelineResponse, error) {
+	t0 := time.Now()
+	defer hsrv.dCounter.AddTimeSince(t0)
+	res := &tlpb.WriteTimelineResponse{Ok: "No"}
+	otherUserIds := make(map[int64]bool, 0)
+	followers, err := hsrv.getFollowers(ctx, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	for _, followerid := range followers {
+		otherUserIds[followerid] = true
+	}
+	for _, mentionid := range req.Usermentionids {
+		otherUserIds[mentionid] = true
+	}
+	log.Debug().Msgf("Removing post %v from timeline of %v users", req.Postid, len(otherUserIds))
+	missing := false
+	for userid := range otherUserIds {
+		hometl, err := hsrv.getHomeTimeline(ctx, userid)
+		if err != nil {
+			res.Ok = res.Ok + fmt.Sprintf(" Error getting home timeline for %v.", userid)	
+			missing = true
+			continue
+		}
+		if !hometl.Remove(req.Postid) {
+			continue
+		}
+		key := HOME_CACHE_PREFIX + strconv.FormatInt(userid, 10) 
+		encodedHometl, err := json.Marshal(hometl)	
+		if err != nil {
+			log.Error().Msg(err.Error())
+			return nil, err
+		}
+		hsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedHometl})
+	}
 	if !missing {
 		res.Ok = HOME_QUERY_OK
 	}
 	return res, nil 
 }
 
+// getFollowers pages through all followers of userid, whose home timelines
+// a post of userid goes to.
+func (hsrv *HomeSrv) getFollowers(ctx context.Context, userid int64) ([]int64, error) {
+	followers := make([]int64, 0)
+	argFollower := &graphpb.GetFollowersRequest{Followeeid: userid, Limit: graph.GRAPH_MAX_PAGE_SIZE}
+	for {
+		resFollower, err := hsrv.graphc.GetFollowers(ctx, argFollower)
+		if err != nil {
+			return nil, err
+		}
+		if resFollower.Ok != graph.GRAPH_QUERY_OK {
+			return nil, fmt.Errorf("cannot get followers of %v: %v", userid, resFollower.Ok)
+		}
+		followers = append(followers, resFollower.Userids...)
+		if resFollower.Nextcursor == "" {
+			return followers, nil
+		}
+		argFollower.Cursor = resFollower.Nextcursor
+	}
+}
+
 func (hsrv *HomeSrv) ReadHomeTimeline(
 		ctx context.Context, req *tlpb.ReadTimelineRequest) (*tlpb.ReadTimelineResponse, error) {
 	//t0 := time.Now()
 	//defer hsrv.rCounter.AddTimeSince(t0)
 	res := &tlpb.ReadTimelineResponse{Ok: "No"}
-	timeline, err := hsrv.getHomeTimeline(ctx, req.Userid)
+	hometl, err := hsrv.getHomeTimeline(ctx, req.Userid)
 	if err != nil {
 		return nil, err
 	}
 
-	start, stop, nItems := req.Start, req.Stop, int32(len(timeline.Postids))
-	if start >= int32(nItems) || start >= stop {
+	start, stop, nItems := req.Start, req.Stop, int32(len(hometl.Postids))
+	if start < 0 || start > stop {
 		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v for %v items", start, stop, nItems)
 		return res, nil
-	}	
+	}
+	if start >= nItems || start == stop {
+		res.Ok = timeline.TIMELINE_QUERY_EMPTY
+		return res, nil
+	}
 	if stop > nItems {
 		stop = nItems
 	}
 	postids := make([]int64, stop-start)
 	for i := start; i < stop; i++ {
-		postids[i-start] = timeline.Postids[nItems-i-1]
+		postids[i-start] = hometl.Postids[nItems-i-1]
 	}
 	readPostReq := &postpb.ReadPostsRequest{Postids: postids}
 	readPostRes, err := hsrv.postc.ReadPosts(ctx, readPostReq)
@@ -253,7 +339,11 @@ func (hsrv *HomeSrv) ReadHomeTimeline(
 		return nil, err 
 	}
 	res.Ok = readPostRes.Ok
-	res.Posts = readPostRes.Posts
+	// posts stay in home timelines across blocks and mutes; hide them on read
+	res.Posts, err = timeline.FilterHidden(ctx, hsrv.graphc, req.Userid, readPostRes.Posts)
+	if err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
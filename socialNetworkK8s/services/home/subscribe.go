@@ -0,0 +1,123 @@
+package home
+
+import (
+	"expvar"
+	"sync"
+	"google.golang.org/grpc/metadata"
+	"socialnetworkk8/services/home/proto"
+)
+
+const (
+	// events a subscriber may fall behind by before the oldest are dropped
+	SUBSCRIBER_BUFFER = 64
+)
+
+var (
+	nSubscribers = expvar.NewInt("home_subscribers")
+	nEventsSent = expvar.NewInt("home_events_sent")
+	nEventsDropped = expvar.NewInt("home_events_dropped")
+)
+
+type subscriber struct {
+	mu      sync.Mutex
+	events  chan *proto.HomeTimelineEvent
+	dropped int64
+}
+
+// push queues event without blocking. A subscriber whose buffer is full
+// loses its oldest event instead, so that a slow reader never holds up
+// fan-out and still sees the latest posts.
+func (sub *subscriber) push(event *proto.HomeTimelineEvent) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	for {
+		select {
+		case sub.events <- event:
+			return
+		default:
+		}
+		select {
+		case <-sub.events:
+			sub.dropped++
+			nEventsDropped.Add(1)
+		default:
+		}
+	}
+}
+
+// takeDropped returns how many events were dropped since it was last called.
+func (sub *subscriber) takeDropped() int64 {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	dropped := sub.dropped
+	sub.dropped = 0
+	return dropped
+}
+
+// hub keeps the subscribers of each user's home timeline.
+type hub struct {
+	mu   sync.Mutex
+	subs map[int64]map[*subscriber]bool
+}
+
+func makeHub() *hub {
+	return &hub{subs: make(map[int64]map[*subscriber]bool)}
+}
+
+func (h *hub) subscribe(userid int64) *subscriber {
+	sub := &subscriber{events: make(chan *proto.HomeTimelineEvent, SUBSCRIBER_BUFFER)}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs[userid] == nil {
+		h.subs[userid] = make(map[*subscriber]bool)
+	}
+	h.subs[userid][sub] = true
+	nSubscribers.Add(1)
+	return sub
+}
+
+func (h *hub) unsubscribe(userid int64, sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs[userid], sub)
+	if len(h.subs[userid]) == 0 {
+		delete(h.subs, userid)
+	}
+	nSubscribers.Add(-1)
+}
+
+func (h *hub) publish(userid int64, event *proto.HomeTimelineEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[userid] {
+		sub.push(event)
+	}
+}
+
+// SubscribeHomeTimeline streams the posts added to the home timeline of
+// req.Userid until the subscriber goes away. The header is sent once the
+// subscription is in place, so callers can wait for it before expecting
+// events. Replicas forward the events of their writes to each other, so a
+// subscriber may connect to any of them.
+func (hsrv *HomeSrv) SubscribeHomeTimeline(
+		req *proto.SubscribeHomeTimelineRequest, stream proto.Home_SubscribeHomeTimelineServer) error {
+	sub := hsrv.hub.subscribe(req.Userid)
+	defer hsrv.hub.unsubscribe(req.Userid, sub)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-sub.events:
+			sent := &proto.HomeTimelineEvent{
+				Postid: event.Postid, Authorid: event.Authorid, Timestamp: event.Timestamp,
+				Dropped: sub.takeDropped()}
+			if err := stream.Send(sent); err != nil {
+				return err
+			}
+			nEventsSent.Add(1)
+		}
+	}
+}
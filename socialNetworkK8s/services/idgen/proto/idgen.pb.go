@@ -0,0 +1,227 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.20.0
+// 	protoc        v3.12.4
+// source: services/idgen/proto/idgen.proto
+
+package proto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// count defaults to 1 and is capped at 1000.
+type NextIdsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *NextIdsRequest) Reset() {
+	*x = NextIdsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_idgen_proto_idgen_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextIdsRequest) ProtoMessage() {}
+
+func (x *NextIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_idgen_proto_idgen_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextIdsRequest.ProtoReflect.Descriptor instead.
+func (*NextIdsRequest) Descriptor() ([]byte, []int) {
+	return file_services_idgen_proto_idgen_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NextIdsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type NextIdsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok  string  `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Ids []int64 `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *NextIdsResponse) Reset() {
+	*x = NextIdsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_idgen_proto_idgen_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextIdsResponse) ProtoMessage() {}
+
+func (x *NextIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_idgen_proto_idgen_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextIdsResponse.ProtoReflect.Descriptor instead.
+func (*NextIdsResponse) Descriptor() ([]byte, []int) {
+	return file_services_idgen_proto_idgen_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NextIdsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *NextIdsResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_services_idgen_proto_idgen_proto protoreflect.FileDescriptor
+
+var file_services_idgen_proto_idgen_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x69, 0x64, 0x67, 0x65, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x69, 0x64, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x69, 0x64, 0x67, 0x65, 0x6e, 0x22, 0x26, 0x0a, 0x0e, 0x4e, 0x65, 0x78,
+	0x74, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x33, 0x0a, 0x0f, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x03, 0x69, 0x64, 0x73, 0x32, 0x41, 0x0a, 0x05, 0x49, 0x64, 0x67, 0x65, 0x6e, 0x12,
+	0x38, 0x0a, 0x07, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x64, 0x73, 0x12, 0x15, 0x2e, 0x69, 0x64, 0x67,
+	0x65, 0x6e, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x69, 0x64, 0x67, 0x65, 0x6e, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x18, 0x5a, 0x16, 0x2e, 0x2f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x69, 0x64, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_services_idgen_proto_idgen_proto_rawDescOnce sync.Once
+	file_services_idgen_proto_idgen_proto_rawDescData = file_services_idgen_proto_idgen_proto_rawDesc
+)
+
+func file_services_idgen_proto_idgen_proto_rawDescGZIP() []byte {
+	file_services_idgen_proto_idgen_proto_rawDescOnce.Do(func() {
+		file_services_idgen_proto_idgen_proto_rawDescData = protoimpl.X.CompressGZIP(file_services_idgen_proto_idgen_proto_rawDescData)
+	})
+	return file_services_idgen_proto_idgen_proto_rawDescData
+}
+
+var file_services_idgen_proto_idgen_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_services_idgen_proto_idgen_proto_goTypes = []interface{}{
+	(*NextIdsRequest)(nil),  // 0: idgen.NextIdsRequest
+	(*NextIdsResponse)(nil), // 1: idgen.NextIdsResponse
+}
+var file_services_idgen_proto_idgen_proto_depIdxs = []int32{
+	0, // 0: idgen.Idgen.NextIds:input_type -> idgen.NextIdsRequest
+	1, // 1: idgen.Idgen.NextIds:output_type -> idgen.NextIdsResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_services_idgen_proto_idgen_proto_init() }
+func file_services_idgen_proto_idgen_proto_init() {
+	if File_services_idgen_proto_idgen_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_services_idgen_proto_idgen_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextIdsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_idgen_proto_idgen_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextIdsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_services_idgen_proto_idgen_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_services_idgen_proto_idgen_proto_goTypes,
+		DependencyIndexes: file_services_idgen_proto_idgen_proto_depIdxs,
+		MessageInfos:      file_services_idgen_proto_idgen_proto_msgTypes,
+	}.Build()
+	File_services_idgen_proto_idgen_proto = out.File
+	file_services_idgen_proto_idgen_proto_rawDesc = nil
+	file_services_idgen_proto_idgen_proto_goTypes = nil
+	file_services_idgen_proto_idgen_proto_depIdxs = nil
+}
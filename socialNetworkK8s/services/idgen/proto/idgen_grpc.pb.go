@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: services/idgen/proto/idgen.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Idgen_NextIds_FullMethodName = "/idgen.Idgen/NextIds"
+)
+
+// IdgenClient is the client API for Idgen service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IdgenClient interface {
+	NextIds(ctx context.Context, in *NextIdsRequest, opts ...grpc.CallOption) (*NextIdsResponse, error)
+}
+
+type idgenClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIdgenClient(cc grpc.ClientConnInterface) IdgenClient {
+	return &idgenClient{cc}
+}
+
+func (c *idgenClient) NextIds(ctx context.Context, in *NextIdsRequest, opts ...grpc.CallOption) (*NextIdsResponse, error) {
+	out := new(NextIdsResponse)
+	err := c.cc.Invoke(ctx, Idgen_NextIds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IdgenServer is the server API for Idgen service.
+// All implementations must embed UnimplementedIdgenServer
+// for forward compatibility
+type IdgenServer interface {
+	NextIds(context.Context, *NextIdsRequest) (*NextIdsResponse, error)
+	mustEmbedUnimplementedIdgenServer()
+}
+
+// UnimplementedIdgenServer must be embedded to have forward compatible implementations.
+type UnimplementedIdgenServer struct {
+}
+
+func (UnimplementedIdgenServer) NextIds(context.Context, *NextIdsRequest) (*NextIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextIds not implemented")
+}
+func (UnimplementedIdgenServer) mustEmbedUnimplementedIdgenServer() {}
+
+// UnsafeIdgenServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IdgenServer will
+// result in compilation errors.
+type UnsafeIdgenServer interface {
+	mustEmbedUnimplementedIdgenServer()
+}
+
+func RegisterIdgenServer(s grpc.ServiceRegistrar, srv IdgenServer) {
+	s.RegisterService(&Idgen_ServiceDesc, srv)
+}
+
+func _Idgen_NextIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IdgenServer).NextIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Idgen_NextIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IdgenServer).NextIds(ctx, req.(*NextIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Idgen_ServiceDesc is the grpc.ServiceDesc for Idgen service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Idgen_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "idgen.Idgen",
+	HandlerType: (*IdgenServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NextIds",
+			Handler:    _Idgen_NextIds_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services/idgen/proto/idgen.proto",
+}
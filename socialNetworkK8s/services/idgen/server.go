@@ -0,0 +1,175 @@
+package idgen
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/snowflake"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/idgen/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	IDGEN_SRV_NAME = "srv-idgen"
+	IDGEN_QUERY_OK = "OK"
+	IDGEN_MAX_COUNT = 1000
+)
+
+// IdgenSrv serves ids from a snowflake generator. Go services embed a
+// generator instead and only share the worker id space with this one.
+type IdgenSrv struct {
+	proto.UnimplementedIdgenServer
+	uuid         string
+	idgen        *snowflake.Generator
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	nCounter     *tracing.Counter
+}
+
+func MakeIdgenSrv() *IdgenSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["IdgenPort"])
+	serv_ip := result["IdgenIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "idgen", *jaegeraddr)
+	tracer, err := tracing.Init("idgen", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	return &IdgenSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		nCounter:     tracing.MakeCounter("Next-Ids"),
+	}
+}
+
+// Run starts the server
+func (isrv *IdgenSrv) Run() error {
+	if isrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC Server...")
+	isrv.uuid = uuid.New().String()
+	var err error
+	isrv.idgen, err = snowflake.MakeLeasedGenerator(isrv.Registry)
+	if err != nil {
+		return fmt.Errorf("cannot lease snowflake worker id: %v", err)
+	}
+	log.Info().Msgf("Generating ids as worker %v", isrv.idgen.Workerid())
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(isrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterIdgenServer(grpcSrv, isrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", isrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = isrv.Registry.Register(IDGEN_SRV_NAME, isrv.uuid, isrv.IpAddr, isrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+// Shutdown cleans up any processes
+func (isrv *IdgenSrv) Shutdown() {
+	isrv.Registry.Deregister(isrv.uuid)
+	isrv.idgen.Close()
+}
+
+func (isrv *IdgenSrv) NextIds(
+		ctx context.Context, req *proto.NextIdsRequest) (*proto.NextIdsResponse, error) {
+	t0 := time.Now()
+	defer isrv.nCounter.AddTimeSince(t0)
+	res := &proto.NextIdsResponse{Ok: "No"}
+	count := int(req.Count)
+	if count <= 0 {
+		count = 1
+	} else if count > IDGEN_MAX_COUNT {
+		count = IDGEN_MAX_COUNT
+	}
+	res.Ids = make([]int64, count)
+	for idx := range res.Ids {
+		id, err := isrv.idgen.Next()
+		if err != nil {
+			log.Error().Msg(err.Error())
+			res.Ids = nil
+			res.Ok = "Cannot generate ids: " + err.Error()
+			return res, nil
+		}
+		res.Ids[idx] = id
+	}
+	res.Ok = IDGEN_QUERY_OK
+	return res, nil
+}
@@ -0,0 +1,238 @@
+package media
+
+import (
+	"fmt"
+	"time"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/context"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+const (
+	// same as GridFS, so a chunk and its key stay well below the BSON limit
+	BLOB_CHUNK_SIZE = 255 << 10
+	BLOB_CACHE_PREFIX = "mediablob_"
+	// how long a new reference waits for a blob being removed to be gone
+	BLOB_RETRY_WAIT     = 10 * time.Millisecond
+	BLOB_RETRY_ATTEMPTS = 100
+)
+
+// Blobs hold the content of media, one per distinct content hash. The bytes
+// live in BLOB_CHUNK_SIZE chunks of their own so no document gets near the
+// BSON size limit and ranges can be read without loading the whole blob.
+type Blob struct {
+	Hash      string `bson:"hash"`
+	Type      string `bson:"type"`
+	Size      int64  `bson:"size"`
+	Nchunks   int64  `bson:"nchunks"`
+	Refcount  int64  `bson:"refcount" json:"-"`
+	Deleting  bool   `bson:"deleting" json:"-"`
+	Thumbnail []byte `bson:"thumbnail"`
+}
+
+type Chunk struct {
+	Hash string `bson:"hash"`
+	N    int64  `bson:"n"`
+	Data []byte `bson:"data"`
+}
+
+func hashContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// refBlob takes a reference on the blob of data, writing the blob first if
+// this is the first reference to it. Chunks are written before the blob
+// document, so a blob that can be found is always complete.
+//
+// A blob whose last reference is dropped is marked deleting until its
+// chunks are gone, and is never revived: a new reference to the same
+// content waits for the blob document to go and writes the blob again, so
+// it cannot share chunks that are being deleted.
+func (msrv *MediaSrv) refBlob(mediatype string, data []byte) (string, error) {
+	hash := hashContent(data)
+	for attempt := 0; attempt < BLOB_RETRY_ATTEMPTS; attempt++ {
+		if attempt > 0 {
+			time.Sleep(BLOB_RETRY_WAIT)
+		}
+		live := bson.M{"hash": hash, "deleting": bson.M{"$ne": true}}
+		updateRes, err := msrv.mongoBlobCo.UpdateOne(
+			context.TODO(), live, &bson.M{"$inc": bson.M{"refcount": 1}})
+		if err != nil {
+			return "", err
+		}
+		if updateRes.MatchedCount == 1 {
+			log.Debug().Msgf("Reusing blob %v", hash)
+			return hash, nil
+		}
+		count, err := msrv.mongoBlobCo.CountDocuments(context.TODO(), &bson.M{"hash": hash})
+		if err != nil {
+			return "", err
+		}
+		if count > 0 {
+			// the blob is being deleted; a deletion that has not finished
+			// by the last attempt was most likely cut short, so finish it
+			if attempt == BLOB_RETRY_ATTEMPTS-2 {
+				if err := msrv.removeBlob(hash); err != nil {
+					return "", err
+				}
+			}
+			continue
+		}
+		if err := msrv.writeChunks(hash, data); err != nil {
+			return "", err
+		}
+		// the upsert cannot match a blob being deleted; inserting next to
+		// one fails on the unique index, and the blob is written again once
+		// the deletion is over
+		_, err = msrv.mongoBlobCo.UpdateOne(
+			context.TODO(), live,
+			&bson.M{
+				"$inc": bson.M{"refcount": 1},
+				"$setOnInsert": bson.M{
+					"type": mediatype, "size": len(data),
+					"nchunks": (int64(len(data)) + BLOB_CHUNK_SIZE - 1) / BLOB_CHUNK_SIZE,
+					"thumbnail": makeThumbnail(data)}},
+			options.Update().SetUpsert(true))
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return hash, nil
+	}
+	return "", fmt.Errorf("blob %v is still being deleted", hash)
+}
+
+func (msrv *MediaSrv) writeChunks(hash string, data []byte) error {
+	nchunks := (int64(len(data)) + BLOB_CHUNK_SIZE - 1) / BLOB_CHUNK_SIZE
+	chunks := make([]interface{}, nchunks)
+	for n := int64(0); n < nchunks; n++ {
+		start, stop := n*BLOB_CHUNK_SIZE, (n+1)*BLOB_CHUNK_SIZE
+		if stop > int64(len(data)) {
+			stop = int64(len(data))
+		}
+		chunks[n] = &Chunk{Hash: hash, N: n, Data: data[start:stop]}
+	}
+	// a concurrent upload of the same content may have written some chunks
+	_, err := msrv.mongoChunkCo.InsertMany(context.TODO(), chunks, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// unrefBlob drops a reference on a blob and removes the blob once nothing
+// refers to it. Only the caller whose conditional update marks the blob
+// deleting removes it: the chunks first, then the blob document, so that a
+// new reference to the content waits until no chunk is left to delete.
+func (msrv *MediaSrv) unrefBlob(ctx context.Context, hash string) error {
+	blob := &Blob{}
+	err := msrv.mongoBlobCo.FindOneAndUpdate(
+		context.TODO(), &bson.M{"hash": hash}, &bson.M{"$inc": bson.M{"refcount": -1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(blob)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		return err
+	}
+	if blob.Refcount > 0 {
+		return nil
+	}
+	updateRes, err := msrv.mongoBlobCo.UpdateOne(
+		context.TODO(),
+		&bson.M{"hash": hash, "refcount": bson.M{"$lte": 0}, "deleting": bson.M{"$ne": true}},
+		&bson.M{"$set": bson.M{"deleting": true}})
+	if err != nil {
+		return err
+	}
+	if updateRes.ModifiedCount == 0 {
+		// referenced again, or another caller is deleting it
+		return nil
+	}
+	if !msrv.cachec.Delete(ctx, BLOB_CACHE_PREFIX+hash) {
+		log.Error().Msgf("cannot delete blob %v from cache", hash)
+	}
+	return msrv.removeBlob(hash)
+}
+
+// removeBlob deletes a blob marked deleting, its chunks first.
+func (msrv *MediaSrv) removeBlob(hash string) error {
+	if _, err := msrv.mongoChunkCo.DeleteMany(context.TODO(), &bson.M{"hash": hash}); err != nil {
+		return err
+	}
+	_, err := msrv.mongoBlobCo.DeleteOne(context.TODO(), &bson.M{"hash": hash, "deleting": true})
+	return err
+}
+
+// readBlob returns size bytes of a blob from offset, calling emit with the
+// part of each chunk in range.
+func (msrv *MediaSrv) readBlob(
+		hash string, offset, size int64, emit func(offset int64, data []byte) error) error {
+	if size <= 0 {
+		return nil
+	}
+	first, last := offset/BLOB_CHUNK_SIZE, (offset+size-1)/BLOB_CHUNK_SIZE
+	cursor, err := msrv.mongoChunkCo.Find(
+		context.TODO(), &bson.M{"hash": hash, "n": bson.M{"$gte": first, "$lte": last}},
+		options.Find().SetSort(bson.D{{Key: "n", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		chunk := &Chunk{}
+		if err := cursor.Decode(chunk); err != nil {
+			return err
+		}
+		chunkStart := chunk.N * BLOB_CHUNK_SIZE
+		start, stop := int64(0), int64(len(chunk.Data))
+		if offset > chunkStart {
+			start = offset - chunkStart
+		}
+		if offset+size < chunkStart+stop {
+			stop = offset + size - chunkStart
+		}
+		if err := emit(chunkStart+start, chunk.Data[start:stop]); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// getBlob returns the metadata of a blob, without its chunks.
+func (msrv *MediaSrv) getBlob(ctx context.Context, hash string) (*Blob, error) {
+	key := BLOB_CACHE_PREFIX + hash
+	blob := &Blob{}
+	if blobItem, err := msrv.cachec.Get(ctx, key); err != nil {
+		if err != memcache.ErrCacheMiss {
+			return nil, err
+		}
+		log.Debug().Msgf("Blob %v cache miss", key)
+		err = msrv.mongoBlobCo.FindOne(context.TODO(), &bson.M{"hash": hash}).Decode(blob)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, nil
+			}
+			return nil, err
+		}
+		encodedBlob, err := json.Marshal(blob)
+		if err != nil {
+			log.Error().Msg(err.Error())
+			return nil, err
+		}
+		msrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedBlob})
+	} else {
+		log.Debug().Msgf("Found blob %v in cache!", hash)
+		json.Unmarshal(blobItem.Value, blob)
+	}
+	return blob, nil
+}
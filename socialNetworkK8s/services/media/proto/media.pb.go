@@ -25,6 +25,9 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// The stored type is sniffed from the content; mediatype is only advisory.
+// When streaming, mediadata is split across messages and only the first
+// message needs a mediatype.
 type StoreMediaRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -80,13 +83,17 @@ func (x *StoreMediaRequest) GetMediadata() []byte {
 	return nil
 }
 
+// Identical content gets a new mediaid but shares its stored blob, named by
+// the sha256 hash of the content.
 type StoreMediaResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok      string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Mediaid int64  `protobuf:"varint,2,opt,name=mediaid,proto3" json:"mediaid,omitempty"`
+	Ok        string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Mediaid   int64  `protobuf:"varint,2,opt,name=mediaid,proto3" json:"mediaid,omitempty"`
+	Mediatype string `protobuf:"bytes,3,opt,name=mediatype,proto3" json:"mediatype,omitempty"`
+	Hash      string `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
 func (x *StoreMediaResponse) Reset() {
@@ -135,12 +142,30 @@ func (x *StoreMediaResponse) GetMediaid() int64 {
 	return 0
 }
 
+func (x *StoreMediaResponse) GetMediatype() string {
+	if x != nil {
+		return x.Mediatype
+	}
+	return ""
+}
+
+func (x *StoreMediaResponse) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+// With thumbnail set, mediadatas hold the thumbnails of images instead and
+// stay empty for media without one. Content beyond MEDIA_MAX_READ bytes in all
+// is refused, and must be read with ReadMediaRange.
 type ReadMediaRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Mediaids []int64 `protobuf:"varint,1,rep,packed,name=mediaids,proto3" json:"mediaids,omitempty"`
+	Mediaids  []int64 `protobuf:"varint,1,rep,packed,name=mediaids,proto3" json:"mediaids,omitempty"`
+	Thumbnail bool    `protobuf:"varint,2,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
 }
 
 func (x *ReadMediaRequest) Reset() {
@@ -182,6 +207,13 @@ func (x *ReadMediaRequest) GetMediaids() []int64 {
 	return nil
 }
 
+func (x *ReadMediaRequest) GetThumbnail() bool {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return false
+}
+
 type ReadMediaResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -245,6 +277,347 @@ func (x *ReadMediaResponse) GetMediadatas() [][]byte {
 	return nil
 }
 
+type CheckMediaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mediaids []int64 `protobuf:"varint,1,rep,packed,name=mediaids,proto3" json:"mediaids,omitempty"`
+}
+
+func (x *CheckMediaRequest) Reset() {
+	*x = CheckMediaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_media_proto_media_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckMediaRequest) ProtoMessage() {}
+
+func (x *CheckMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_media_proto_media_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckMediaRequest.ProtoReflect.Descriptor instead.
+func (*CheckMediaRequest) Descriptor() ([]byte, []int) {
+	return file_services_media_proto_media_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckMediaRequest) GetMediaids() []int64 {
+	if x != nil {
+		return x.Mediaids
+	}
+	return nil
+}
+
+type CheckMediaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok      string  `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Missing []int64 `protobuf:"varint,2,rep,packed,name=missing,proto3" json:"missing,omitempty"`
+}
+
+func (x *CheckMediaResponse) Reset() {
+	*x = CheckMediaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_media_proto_media_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckMediaResponse) ProtoMessage() {}
+
+func (x *CheckMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_media_proto_media_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckMediaResponse.ProtoReflect.Descriptor instead.
+func (*CheckMediaResponse) Descriptor() ([]byte, []int) {
+	return file_services_media_proto_media_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckMediaResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *CheckMediaResponse) GetMissing() []int64 {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+// ReadMediaRange streams length bytes of a media from offset, or up to its end
+// if length is 0. The first message carries the type and total size.
+type ReadMediaRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mediaid int64 `protobuf:"varint,1,opt,name=mediaid,proto3" json:"mediaid,omitempty"`
+	Offset  int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length  int64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *ReadMediaRangeRequest) Reset() {
+	*x = ReadMediaRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_media_proto_media_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadMediaRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadMediaRangeRequest) ProtoMessage() {}
+
+func (x *ReadMediaRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_media_proto_media_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadMediaRangeRequest.ProtoReflect.Descriptor instead.
+func (*ReadMediaRangeRequest) Descriptor() ([]byte, []int) {
+	return file_services_media_proto_media_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReadMediaRangeRequest) GetMediaid() int64 {
+	if x != nil {
+		return x.Mediaid
+	}
+	return 0
+}
+
+func (x *ReadMediaRangeRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadMediaRangeRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type ReadMediaRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok        string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Mediatype string `protobuf:"bytes,2,opt,name=mediatype,proto3" json:"mediatype,omitempty"`
+	Size      int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Offset    int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data      []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ReadMediaRangeResponse) Reset() {
+	*x = ReadMediaRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_media_proto_media_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadMediaRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadMediaRangeResponse) ProtoMessage() {}
+
+func (x *ReadMediaRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_media_proto_media_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadMediaRangeResponse.ProtoReflect.Descriptor instead.
+func (*ReadMediaRangeResponse) Descriptor() ([]byte, []int) {
+	return file_services_media_proto_media_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReadMediaRangeResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *ReadMediaRangeResponse) GetMediatype() string {
+	if x != nil {
+		return x.Mediatype
+	}
+	return ""
+}
+
+func (x *ReadMediaRangeResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ReadMediaRangeResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadMediaRangeResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// DeleteMedia drops a mediaid; its blob goes once no mediaid refers to it.
+type DeleteMediaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mediaid int64 `protobuf:"varint,1,opt,name=mediaid,proto3" json:"mediaid,omitempty"`
+}
+
+func (x *DeleteMediaRequest) Reset() {
+	*x = DeleteMediaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_media_proto_media_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMediaRequest) ProtoMessage() {}
+
+func (x *DeleteMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_media_proto_media_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMediaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMediaRequest) Descriptor() ([]byte, []int) {
+	return file_services_media_proto_media_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteMediaRequest) GetMediaid() int64 {
+	if x != nil {
+		return x.Mediaid
+	}
+	return 0
+}
+
+type DeleteMediaResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+}
+
+func (x *DeleteMediaResponse) Reset() {
+	*x = DeleteMediaResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_media_proto_media_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMediaResponse) ProtoMessage() {}
+
+func (x *DeleteMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_media_proto_media_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMediaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMediaResponse) Descriptor() ([]byte, []int) {
+	return file_services_media_proto_media_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteMediaResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
 var File_services_media_proto_media_proto protoreflect.FileDescriptor
 
 var file_services_media_proto_media_proto_rawDesc = []byte{
@@ -255,32 +628,82 @@ var file_services_media_proto_media_proto_rawDesc = []byte{
 	0x0a, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09,
 	0x6d, 0x65, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3e, 0x0a, 0x12, 0x53, 0x74,
+	0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x70, 0x0a, 0x12, 0x53, 0x74,
 	0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b,
 	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x07, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x22, 0x2e, 0x0a, 0x10, 0x52, 0x65,
-	0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03,
-	0x52, 0x08, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x22, 0x63, 0x0a, 0x11, 0x52, 0x65,
+	0x03, 0x52, 0x07, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x4c, 0x0a, 0x10,
+	0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x08, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x74, 0x68, 0x75, 0x6d, 0x62, 0x6e, 0x61, 0x69, 0x6c, 0x22, 0x63, 0x0a, 0x11, 0x52, 0x65,
 	0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12,
 	0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20,
 	0x03, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12,
 	0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x32,
-	0x91, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x12, 0x41, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x18,
-	0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69,
-	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61,
-	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61,
-	0x12, 0x17, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64,
-	0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69,
-	0x61, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x18, 0x5a, 0x16, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x64, 0x61, 0x74, 0x61, 0x73, 0x22,
+	0x2f, 0x0a, 0x11, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x73,
+	0x22, 0x3e, 0x0a, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67,
+	0x22, 0x61, 0x0a, 0x15, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x22, 0x86, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69,
+	0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1c,
+	0x0a, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2e, 0x0a, 0x12,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x22, 0x25, 0x0a, 0x13,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x6f, 0x6b, 0x32, 0xb6, 0x03, 0x0a, 0x0c, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x12, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x18, 0x2e, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x12, 0x3e, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12,
+	0x17, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69,
+	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65, 0x64, 0x69, 0x61,
+	0x12, 0x18, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65,
+	0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x52, 0x65,
+	0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x12, 0x19, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d,
+	0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x18, 0x5a, 0x16,
+	0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -295,20 +718,34 @@ func file_services_media_proto_media_proto_rawDescGZIP() []byte {
 	return file_services_media_proto_media_proto_rawDescData
 }
 
-var file_services_media_proto_media_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_services_media_proto_media_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_services_media_proto_media_proto_goTypes = []interface{}{
-	(*StoreMediaRequest)(nil),  // 0: media.StoreMediaRequest
-	(*StoreMediaResponse)(nil), // 1: media.StoreMediaResponse
-	(*ReadMediaRequest)(nil),   // 2: media.ReadMediaRequest
-	(*ReadMediaResponse)(nil),  // 3: media.ReadMediaResponse
+	(*StoreMediaRequest)(nil),      // 0: media.StoreMediaRequest
+	(*StoreMediaResponse)(nil),     // 1: media.StoreMediaResponse
+	(*ReadMediaRequest)(nil),       // 2: media.ReadMediaRequest
+	(*ReadMediaResponse)(nil),      // 3: media.ReadMediaResponse
+	(*CheckMediaRequest)(nil),      // 4: media.CheckMediaRequest
+	(*CheckMediaResponse)(nil),     // 5: media.CheckMediaResponse
+	(*ReadMediaRangeRequest)(nil),  // 6: media.ReadMediaRangeRequest
+	(*ReadMediaRangeResponse)(nil), // 7: media.ReadMediaRangeResponse
+	(*DeleteMediaRequest)(nil),     // 8: media.DeleteMediaRequest
+	(*DeleteMediaResponse)(nil),    // 9: media.DeleteMediaResponse
 }
 var file_services_media_proto_media_proto_depIdxs = []int32{
 	0, // 0: media.MediaStorage.StoreMedia:input_type -> media.StoreMediaRequest
-	2, // 1: media.MediaStorage.ReadMedia:input_type -> media.ReadMediaRequest
-	1, // 2: media.MediaStorage.StoreMedia:output_type -> media.StoreMediaResponse
-	3, // 3: media.MediaStorage.ReadMedia:output_type -> media.ReadMediaResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	0, // 1: media.MediaStorage.StoreMediaStream:input_type -> media.StoreMediaRequest
+	2, // 2: media.MediaStorage.ReadMedia:input_type -> media.ReadMediaRequest
+	4, // 3: media.MediaStorage.CheckMedia:input_type -> media.CheckMediaRequest
+	6, // 4: media.MediaStorage.ReadMediaRange:input_type -> media.ReadMediaRangeRequest
+	8, // 5: media.MediaStorage.DeleteMedia:input_type -> media.DeleteMediaRequest
+	1, // 6: media.MediaStorage.StoreMedia:output_type -> media.StoreMediaResponse
+	1, // 7: media.MediaStorage.StoreMediaStream:output_type -> media.StoreMediaResponse
+	3, // 8: media.MediaStorage.ReadMedia:output_type -> media.ReadMediaResponse
+	5, // 9: media.MediaStorage.CheckMedia:output_type -> media.CheckMediaResponse
+	7, // 10: media.MediaStorage.ReadMediaRange:output_type -> media.ReadMediaRangeResponse
+	9, // 11: media.MediaStorage.DeleteMedia:output_type -> media.DeleteMediaResponse
+	6, // [6:12] is the sub-list for method output_type
+	0, // [0:6] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -368,6 +805,78 @@ func file_services_media_proto_media_proto_init() {
 				return nil
 			}
 		}
+		file_services_media_proto_media_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckMediaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_media_proto_media_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckMediaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_media_proto_media_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadMediaRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_media_proto_media_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadMediaRangeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_media_proto_media_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteMediaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_media_proto_media_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteMediaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -375,7 +884,7 @@ func file_services_media_proto_media_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_media_proto_media_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
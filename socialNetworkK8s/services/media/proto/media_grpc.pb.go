@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MediaStorage_StoreMedia_FullMethodName = "/media.MediaStorage/StoreMedia"
-	MediaStorage_ReadMedia_FullMethodName  = "/media.MediaStorage/ReadMedia"
+	MediaStorage_StoreMedia_FullMethodName       = "/media.MediaStorage/StoreMedia"
+	MediaStorage_StoreMediaStream_FullMethodName = "/media.MediaStorage/StoreMediaStream"
+	MediaStorage_ReadMedia_FullMethodName        = "/media.MediaStorage/ReadMedia"
+	MediaStorage_CheckMedia_FullMethodName       = "/media.MediaStorage/CheckMedia"
+	MediaStorage_ReadMediaRange_FullMethodName   = "/media.MediaStorage/ReadMediaRange"
+	MediaStorage_DeleteMedia_FullMethodName      = "/media.MediaStorage/DeleteMedia"
 )
 
 // MediaStorageClient is the client API for MediaStorage service.
@@ -28,7 +32,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MediaStorageClient interface {
 	StoreMedia(ctx context.Context, in *StoreMediaRequest, opts ...grpc.CallOption) (*StoreMediaResponse, error)
+	StoreMediaStream(ctx context.Context, opts ...grpc.CallOption) (MediaStorage_StoreMediaStreamClient, error)
 	ReadMedia(ctx context.Context, in *ReadMediaRequest, opts ...grpc.CallOption) (*ReadMediaResponse, error)
+	CheckMedia(ctx context.Context, in *CheckMediaRequest, opts ...grpc.CallOption) (*CheckMediaResponse, error)
+	ReadMediaRange(ctx context.Context, in *ReadMediaRangeRequest, opts ...grpc.CallOption) (MediaStorage_ReadMediaRangeClient, error)
+	DeleteMedia(ctx context.Context, in *DeleteMediaRequest, opts ...grpc.CallOption) (*DeleteMediaResponse, error)
 }
 
 type mediaStorageClient struct {
@@ -48,6 +56,40 @@ func (c *mediaStorageClient) StoreMedia(ctx context.Context, in *StoreMediaReque
 	return out, nil
 }
 
+func (c *mediaStorageClient) StoreMediaStream(ctx context.Context, opts ...grpc.CallOption) (MediaStorage_StoreMediaStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MediaStorage_ServiceDesc.Streams[0], MediaStorage_StoreMediaStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mediaStorageStoreMediaStreamClient{stream}
+	return x, nil
+}
+
+type MediaStorage_StoreMediaStreamClient interface {
+	Send(*StoreMediaRequest) error
+	CloseAndRecv() (*StoreMediaResponse, error)
+	grpc.ClientStream
+}
+
+type mediaStorageStoreMediaStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *mediaStorageStoreMediaStreamClient) Send(m *StoreMediaRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *mediaStorageStoreMediaStreamClient) CloseAndRecv() (*StoreMediaResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StoreMediaResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *mediaStorageClient) ReadMedia(ctx context.Context, in *ReadMediaRequest, opts ...grpc.CallOption) (*ReadMediaResponse, error) {
 	out := new(ReadMediaResponse)
 	err := c.cc.Invoke(ctx, MediaStorage_ReadMedia_FullMethodName, in, out, opts...)
@@ -57,12 +99,66 @@ func (c *mediaStorageClient) ReadMedia(ctx context.Context, in *ReadMediaRequest
 	return out, nil
 }
 
+func (c *mediaStorageClient) CheckMedia(ctx context.Context, in *CheckMediaRequest, opts ...grpc.CallOption) (*CheckMediaResponse, error) {
+	out := new(CheckMediaResponse)
+	err := c.cc.Invoke(ctx, MediaStorage_CheckMedia_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaStorageClient) ReadMediaRange(ctx context.Context, in *ReadMediaRangeRequest, opts ...grpc.CallOption) (MediaStorage_ReadMediaRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MediaStorage_ServiceDesc.Streams[1], MediaStorage_ReadMediaRange_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mediaStorageReadMediaRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MediaStorage_ReadMediaRangeClient interface {
+	Recv() (*ReadMediaRangeResponse, error)
+	grpc.ClientStream
+}
+
+type mediaStorageReadMediaRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *mediaStorageReadMediaRangeClient) Recv() (*ReadMediaRangeResponse, error) {
+	m := new(ReadMediaRangeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mediaStorageClient) DeleteMedia(ctx context.Context, in *DeleteMediaRequest, opts ...grpc.CallOption) (*DeleteMediaResponse, error) {
+	out := new(DeleteMediaResponse)
+	err := c.cc.Invoke(ctx, MediaStorage_DeleteMedia_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MediaStorageServer is the server API for MediaStorage service.
 // All implementations must embed UnimplementedMediaStorageServer
 // for forward compatibility
 type MediaStorageServer interface {
 	StoreMedia(context.Context, *StoreMediaRequest) (*StoreMediaResponse, error)
+	StoreMediaStream(MediaStorage_StoreMediaStreamServer) error
 	ReadMedia(context.Context, *ReadMediaRequest) (*ReadMediaResponse, error)
+	CheckMedia(context.Context, *CheckMediaRequest) (*CheckMediaResponse, error)
+	ReadMediaRange(*ReadMediaRangeRequest, MediaStorage_ReadMediaRangeServer) error
+	DeleteMedia(context.Context, *DeleteMediaRequest) (*DeleteMediaResponse, error)
 	mustEmbedUnimplementedMediaStorageServer()
 }
 
@@ -73,9 +169,21 @@ type UnimplementedMediaStorageServer struct {
 func (UnimplementedMediaStorageServer) StoreMedia(context.Context, *StoreMediaRequest) (*StoreMediaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreMedia not implemented")
 }
+func (UnimplementedMediaStorageServer) StoreMediaStream(MediaStorage_StoreMediaStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method StoreMediaStream not implemented")
+}
 func (UnimplementedMediaStorageServer) ReadMedia(context.Context, *ReadMediaRequest) (*ReadMediaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadMedia not implemented")
 }
+func (UnimplementedMediaStorageServer) CheckMedia(context.Context, *CheckMediaRequest) (*CheckMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckMedia not implemented")
+}
+func (UnimplementedMediaStorageServer) ReadMediaRange(*ReadMediaRangeRequest, MediaStorage_ReadMediaRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadMediaRange not implemented")
+}
+func (UnimplementedMediaStorageServer) DeleteMedia(context.Context, *DeleteMediaRequest) (*DeleteMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMedia not implemented")
+}
 func (UnimplementedMediaStorageServer) mustEmbedUnimplementedMediaStorageServer() {}
 
 // UnsafeMediaStorageServer may be embedded to opt out of forward compatibility for this service.
@@ -107,6 +215,32 @@ func _MediaStorage_StoreMedia_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaStorage_StoreMediaStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MediaStorageServer).StoreMediaStream(&mediaStorageStoreMediaStreamServer{stream})
+}
+
+type MediaStorage_StoreMediaStreamServer interface {
+	SendAndClose(*StoreMediaResponse) error
+	Recv() (*StoreMediaRequest, error)
+	grpc.ServerStream
+}
+
+type mediaStorageStoreMediaStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *mediaStorageStoreMediaStreamServer) SendAndClose(m *StoreMediaResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *mediaStorageStoreMediaStreamServer) Recv() (*StoreMediaRequest, error) {
+	m := new(StoreMediaRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _MediaStorage_ReadMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadMediaRequest)
 	if err := dec(in); err != nil {
@@ -125,6 +259,63 @@ func _MediaStorage_ReadMedia_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaStorage_CheckMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaStorageServer).CheckMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaStorage_CheckMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaStorageServer).CheckMedia(ctx, req.(*CheckMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaStorage_ReadMediaRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadMediaRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaStorageServer).ReadMediaRange(m, &mediaStorageReadMediaRangeServer{stream})
+}
+
+type MediaStorage_ReadMediaRangeServer interface {
+	Send(*ReadMediaRangeResponse) error
+	grpc.ServerStream
+}
+
+type mediaStorageReadMediaRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *mediaStorageReadMediaRangeServer) Send(m *ReadMediaRangeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MediaStorage_DeleteMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaStorageServer).DeleteMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaStorage_DeleteMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaStorageServer).DeleteMedia(ctx, req.(*DeleteMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MediaStorage_ServiceDesc is the grpc.ServiceDesc for MediaStorage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,7 +331,26 @@ var MediaStorage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadMedia",
 			Handler:    _MediaStorage_ReadMedia_Handler,
 		},
+		{
+			MethodName: "CheckMedia",
+			Handler:    _MediaStorage_CheckMedia_Handler,
+		},
+		{
+			MethodName: "DeleteMedia",
+			Handler:    _MediaStorage_DeleteMedia_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StoreMediaStream",
+			Handler:       _MediaStorage_StoreMediaStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReadMediaRange",
+			Handler:       _MediaStorage_ReadMediaRange_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/media/proto/media.proto",
 }
@@ -8,17 +8,17 @@ import (
 	"strconv"
 	"time"
 	"fmt"
-	"sync"
+	"io"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"math/rand"
 	"net"
 	"net/http"
 	"net/http/pprof"
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"socialnetworkk8/registry"
+	"socialnetworkk8/snowflake"
 	"socialnetworkk8/tune"
 	"socialnetworkk8/services/cacheclnt"
 	"socialnetworkk8/tls"
@@ -43,13 +43,13 @@ type MediaSrv struct {
 	uuid         string
 	cachec       *cacheclnt.CacheClnt
 	mongoCo      *mongo.Collection
+	mongoBlobCo  *mongo.Collection
+	mongoChunkCo *mongo.Collection
 	Registry     *registry.Client
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
-	sid          int32 // sid is a random number between 0 and 2^30
-	ucount       int32 //This server may overflow with over 2^31 medias
-    mu           sync.Mutex
+	idgen        *snowflake.Generator
 }
 
 func MakeMediaSrv() *MediaSrv {
@@ -102,15 +102,31 @@ func MakeMediaSrv() *MediaSrv {
 	indexModel := mongo.IndexModel{Keys: bson.D{{"mediaid", 1}}}
 	name, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
 	log.Info().Msgf("Name of index created: %v", name)
+	blobCollection := mongoClient.Database("socialnetwork").Collection("media-blob")
+	indexModel = mongo.IndexModel{
+		Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)}
+	name, err = blobCollection.Indexes().CreateOne(context.TODO(), indexModel)
+	log.Info().Msgf("Name of index created: %v", name)
+	chunkCollection := mongoClient.Database("socialnetwork").Collection("media-chunk")
+	indexModel = mongo.IndexModel{
+		Keys: bson.D{{Key: "hash", Value: 1}, {Key: "n", Value: 1}}, Options: options.Index().SetUnique(true)}
+	name, err = chunkCollection.Indexes().CreateOne(context.TODO(), indexModel)
+	log.Info().Msgf("Name of index created: %v", name)
 	log.Info().Msg("New mongo session successfull.")
-	return &MediaSrv{
+	msrv := &MediaSrv{
 		Port:         serv_port,
 		IpAddr:       serv_ip,
 		Tracer:       tracer,
 		Registry:     registry,
 		cachec:       cachec,
 		mongoCo:      collection,
+		mongoBlobCo:  blobCollection,
+		mongoChunkCo: chunkCollection,
+	}
+	if err := msrv.migrateLegacyMedia(); err != nil {
+		log.Panic().Msgf("cannot migrate legacy media: %v", err)
 	}
+	return msrv
 }
 
 // Run starts the server
@@ -122,7 +138,11 @@ func (msrv *MediaSrv) Run() error {
 	//zerolog.SetGlobalLevel(zerolog.ErrorLevel)
 	log.Info().Msg("Initializing gRPC Server...")
 	msrv.uuid = uuid.New().String()
-	msrv.sid = rand.Int31n(536870912) // 2^29
+	var err error
+	msrv.idgen, err = snowflake.MakeLeasedGenerator(msrv.Registry)
+	if err != nil {
+		return fmt.Errorf("cannot lease snowflake worker id: %v", err)
+	}
 	opts := []grpc.ServerOption{
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -133,6 +153,9 @@ func (msrv *MediaSrv) Run() error {
 		grpc.UnaryInterceptor(
 			otgrpc.OpenTracingServerInterceptor(msrv.Tracer),
 		),
+		grpc.StreamInterceptor(
+			otgrpc.OpenTracingStreamServerInterceptor(msrv.Tracer),
+		),
 	}
 	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
 		opts = append(opts, tlsopt)
@@ -160,15 +183,81 @@ func (msrv *MediaSrv) Run() error {
 
 func (msrv *MediaSrv) StoreMedia(
 		ctx context.Context, req *proto.StoreMediaRequest) (*proto.StoreMediaResponse, error){
+	return msrv.storeMedia(ctx, req.Mediatype, req.Mediadata)
+}
+
+// StoreMediaStream stores media too large for a single message.
+func (msrv *MediaSrv) StoreMediaStream(stream proto.MediaStorage_StoreMediaStreamServer) error {
+	var mediatype string
+	var mediadata []byte
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if mediatype == "" {
+			mediatype = req.Mediatype
+		}
+		if len(mediadata)+len(req.Mediadata) > MEDIA_MAX_SIZE {
+			return stream.SendAndClose(&proto.StoreMediaResponse{
+				Ok: fmt.Sprintf("Media is larger than %v bytes.", MEDIA_MAX_SIZE)})
+		}
+		mediadata = append(mediadata, req.Mediadata...)
+	}
+	res, err := msrv.storeMedia(stream.Context(), mediatype, mediadata)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(res)
+}
+
+func (msrv *MediaSrv) storeMedia(
+		ctx context.Context, mediatype string, mediadata []byte) (*proto.StoreMediaResponse, error) {
 	res := &proto.StoreMediaResponse{Ok: "No"}
-	mId := msrv.getNextMediaId()
-	media := &Media{mId, req.Mediatype, req.Mediadata}
+	if len(mediadata) == 0 {
+		res.Ok = "Cannot store empty media."
+		return res, nil
+	}
+	if len(mediadata) > MEDIA_MAX_SIZE {
+		res.Ok = fmt.Sprintf("Media is larger than %v bytes.", MEDIA_MAX_SIZE)
+		return res, nil
+	}
+	// trust the content, not the uploader
+	sniffedType := SniffMediaType(mediadata)
+	if !MEDIA_TYPES[sniffedType] {
+		res.Ok = fmt.Sprintf("Unsupported media type %v.", sniffedType)
+		return res, nil
+	}
+	if mediatype != "" && mediatype != sniffedType {
+		log.Debug().Msgf("Media declared as %v sniffed as %v", mediatype, sniffedType)
+	}
+	mId, err := msrv.getNextMediaId()
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return res, err
+	}
+	hash, err := msrv.refBlob(sniffedType, mediadata)
+	if err != nil {
+		log.Error().Msg(err.Error())
+		return res, err
+	}
+	media := &Media{
+		Mediaid: mId,
+		Hash: hash,
+		Type: sniffedType,
+		Size: int64(len(mediadata)),
+	}
 	if _, err := msrv.mongoCo.InsertOne(context.TODO(), media); err != nil {
 		log.Error().Msg(err.Error())
 		return res, err
 	}
 	res.Ok = MEDIA_QUERY_OK
 	res.Mediaid = mId
+	res.Mediatype = sniffedType
+	res.Hash = hash
 	return res, nil
 }
 
@@ -178,6 +267,7 @@ func (msrv *MediaSrv) ReadMedia(
 	mediatypes := make([]string, len(req.Mediaids))
 	mediadatas := make([][]byte, len(req.Mediaids))
 	missing := false
+	size := int64(0)
 	for idx, mediaid := range req.Mediaids {
 		media, err := msrv.getMedia(ctx, mediaid)
 		if err != nil {
@@ -186,9 +276,31 @@ func (msrv *MediaSrv) ReadMedia(
 		if media == nil {
 			missing = true
 			res.Ok = res.Ok + fmt.Sprintf(" Missing %v.", mediaid)
+		} else if !req.Thumbnail && size+media.Size > MEDIA_MAX_READ {
+			// refused rather than failing the whole reply on the message limit
+			missing = true
+			res.Ok = res.Ok + fmt.Sprintf(" Media %v too large, read it by range.", mediaid)
+		} else if req.Thumbnail {
+			blob, err := msrv.getBlob(ctx, media.Hash)
+			if err != nil {
+				return nil, err
+			}
+			mediatypes[idx] = media.Type
+			if blob != nil && blob.Thumbnail != nil {
+				mediatypes[idx] = THUMBNAIL_TYPE
+				mediadatas[idx] = blob.Thumbnail
+			}
 		} else {
+			size += media.Size
 			mediatypes[idx] = media.Type
-			mediadatas[idx] = media.Data
+			mediadatas[idx] = make([]byte, 0, media.Size)
+			err := msrv.readBlob(media.Hash, 0, media.Size, func(_ int64, data []byte) error {
+				mediadatas[idx] = append(mediadatas[idx], data...)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 	res.Mediatypes = mediatypes
@@ -199,6 +311,94 @@ func (msrv *MediaSrv) ReadMedia(
 	return res, nil
 }
 
+// CheckMedia reports which of the given media do not exist without reading
+// their content.
+func (msrv *MediaSrv) CheckMedia(
+		ctx context.Context, req *proto.CheckMediaRequest) (*proto.CheckMediaResponse, error){
+	res := &proto.CheckMediaResponse{Ok: "No"}
+	cursor, err := msrv.mongoCo.Find(
+		context.TODO(), &bson.M{"mediaid": bson.M{"$in": req.Mediaids}},
+		options.Find().SetProjection(bson.M{"mediaid": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var found []*Media
+	if err = cursor.All(context.TODO(), &found); err != nil {
+		return nil, err
+	}
+	exists := make(map[int64]bool, len(found))
+	for _, media := range found {
+		exists[media.Mediaid] = true
+	}
+	for _, mediaid := range req.Mediaids {
+		if !exists[mediaid] {
+			res.Missing = append(res.Missing, mediaid)
+			res.Ok = res.Ok + fmt.Sprintf(" Missing %v.", mediaid)
+		}
+	}
+	if len(res.Missing) == 0 {
+		res.Ok = MEDIA_QUERY_OK
+	}
+	return res, nil
+}
+
+func (msrv *MediaSrv) ReadMediaRange(
+		req *proto.ReadMediaRangeRequest, stream proto.MediaStorage_ReadMediaRangeServer) error {
+	ctx := stream.Context()
+	media, err := msrv.getMedia(ctx, req.Mediaid)
+	if err != nil {
+		return err
+	}
+	if media == nil {
+		return stream.Send(&proto.ReadMediaRangeResponse{Ok: fmt.Sprintf("No Missing %v.", req.Mediaid)})
+	}
+	if req.Offset < 0 || req.Length < 0 || req.Offset > media.Size {
+		return stream.Send(&proto.ReadMediaRangeResponse{Ok: fmt.Sprintf(
+			"Cannot read offset=%v length=%v of %v bytes", req.Offset, req.Length, media.Size)})
+	}
+	length := media.Size - req.Offset
+	if req.Length > 0 && req.Length < length {
+		length = req.Length
+	}
+	first := &proto.ReadMediaRangeResponse{
+		Ok: MEDIA_QUERY_OK, Mediatype: media.Type, Size: media.Size, Offset: req.Offset}
+	if length == 0 {
+		return stream.Send(first)
+	}
+	return msrv.readBlob(media.Hash, req.Offset, length, func(offset int64, data []byte) error {
+		res := &proto.ReadMediaRangeResponse{Ok: MEDIA_QUERY_OK, Offset: offset, Data: data}
+		if first != nil {
+			first.Data = data
+			res, first = first, nil
+		}
+		return stream.Send(res)
+	})
+}
+
+func (msrv *MediaSrv) DeleteMedia(
+		ctx context.Context, req *proto.DeleteMediaRequest) (*proto.DeleteMediaResponse, error){
+	res := &proto.DeleteMediaResponse{Ok: "No"}
+	media := &Media{}
+	err := msrv.mongoCo.FindOneAndDelete(context.TODO(), &bson.M{"mediaid": req.Mediaid}).Decode(media)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			res.Ok = fmt.Sprintf("No Missing %v.", req.Mediaid)
+			return res, nil
+		}
+		return nil, err
+	}
+	key := MEDIA_CACHE_PREFIX + strconv.FormatInt(req.Mediaid, 10)
+	if !msrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete media %v from cache", key)
+	}
+	if err := msrv.unrefBlob(ctx, media.Hash); err != nil {
+		return nil, err
+	}
+	res.Ok = MEDIA_QUERY_OK
+	return res, nil
+}
+
+// getMedia returns the metadata of a media; its content is in its blob.
 func (msrv *MediaSrv) getMedia(ctx context.Context, mediaid int64) (*Media, error) {
 	key := MEDIA_CACHE_PREFIX + strconv.FormatInt(mediaid, 10) 
 	media := &Media{}
@@ -229,18 +429,66 @@ func (msrv *MediaSrv) getMedia(ctx context.Context, mediaid int64) (*Media, erro
 }
 
 type Media struct {
-	Mediaid int64  `bson:mediaid`
-	Type    string `bson:type`
-	Data    []byte `bson:data`
+	Mediaid int64  `bson:"mediaid"`
+	Hash    string `bson:"hash"`
+	Type    string `bson:"type"`
+	Size    int64  `bson:"size"`
+}
+
+// LegacyMedia is a media as it was stored before blobs, with its content
+// inline.
+type LegacyMedia struct {
+	Mediaid int64  `bson:"mediaid"`
+	Type    string `bson:"type"`
+	Data    []byte `bson:"data"`
 }
 
-func (msrv *MediaSrv) incCountSafe() int32 {
-	msrv.mu.Lock()
-	defer msrv.mu.Unlock()
-	msrv.ucount++
-	return msrv.ucount
+// migrateLegacyMedia moves the inline content of old media into blobs. It
+// is idempotent, so replicas starting together may all run it: only the
+// replica whose update drops the inline content keeps its reference. It runs
+// before any cache server has registered, so it leaves the caches alone;
+// they start out empty.
+func (msrv *MediaSrv) migrateLegacyMedia() error {
+	cur, err := msrv.mongoCo.Find(context.TODO(), &bson.M{"data": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	defer cur.Close(context.TODO())
+	nmedia := 0
+	for cur.Next(context.TODO()) {
+		legacy := &LegacyMedia{}
+		if err := cur.Decode(legacy); err != nil {
+			return err
+		}
+		hash, err := msrv.refBlob(legacy.Type, legacy.Data)
+		if err != nil {
+			return err
+		}
+		updateRes, err := msrv.mongoCo.UpdateOne(
+			context.TODO(), &bson.M{"mediaid": legacy.Mediaid, "data": bson.M{"$exists": true}},
+			&bson.M{
+				"$set":   bson.M{"hash": hash, "size": len(legacy.Data)},
+				"$unset": bson.M{"data": "", "thumbnail": ""}})
+		if err != nil {
+			return err
+		}
+		if updateRes.ModifiedCount == 0 {
+			if err := msrv.unrefBlob(context.TODO(), hash); err != nil {
+				return err
+			}
+			continue
+		}
+		nmedia++
+	}
+	if err := cur.Err(); err != nil {
+		return err
+	}
+	if nmedia > 0 {
+		log.Info().Msgf("Migrated %v legacy media", nmedia)
+	}
+	return nil
 }
 
-func (msrv *MediaSrv) getNextMediaId() int64 {
-	return int64(msrv.sid)*1e10 + int64(msrv.incCountSafe())
+func (msrv *MediaSrv) getNextMediaId() (int64, error) {
+	return msrv.idgen.Next()
 }
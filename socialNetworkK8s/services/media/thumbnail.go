@@ -0,0 +1,99 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	MEDIA_MAX_SIZE = 8 << 20
+	THUMBNAIL_SIZE = 128
+	THUMBNAIL_TYPE = "image/jpeg"
+	// larger images are stored without a thumbnail rather than decoded, as
+	// decoding takes up to 4 bytes a pixel
+	THUMBNAIL_MAX_PIXELS = 16e6
+	// content returned by one ReadMedia, safely under the 4MB gRPC message
+	// limit; larger media are read with ReadMediaRange
+	MEDIA_MAX_READ = 3 << 20
+)
+
+// MEDIA_TYPES are the sniffed content types accepted for upload.
+var MEDIA_TYPES = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+	"image/bmp":  true,
+	"video/mp4":  true,
+	"video/webm": true,
+	"audio/mpeg": true,
+	"audio/wave": true,
+}
+
+// SniffMediaType returns the content type of data, ignoring whatever the
+// uploader claimed it to be.
+func SniffMediaType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// makeThumbnail scales an image down to fit in a THUMBNAIL_SIZE square. It
+// returns nil for content the standard library cannot decode.
+func makeThumbnail(data []byte) []byte {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > THUMBNAIL_MAX_PIXELS {
+		return nil
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return nil
+	}
+	tw, th := w, h
+	if w > THUMBNAIL_SIZE || h > THUMBNAIL_SIZE {
+		if w >= h {
+			tw, th = THUMBNAIL_SIZE, max(1, h*THUMBNAIL_SIZE/w)
+		} else {
+			tw, th = max(1, w*THUMBNAIL_SIZE/h), THUMBNAIL_SIZE
+		}
+	}
+	// average the source pixels each thumbnail pixel covers
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for ty := 0; ty < th; ty++ {
+		y0, y1 := ty*h/th, max((ty+1)*h/th, ty*h/th+1)
+		for tx := 0; tx < tw; tx++ {
+			x0, x1 := tx*w/tw, max((tx+1)*w/tw, tx*w/tw+1)
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					pr, pg, pb, pa := src.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			off := dst.PixOffset(tx, ty)
+			dst.Pix[off+0] = uint8(r / n >> 8)
+			dst.Pix[off+1] = uint8(g / n >> 8)
+			dst.Pix[off+2] = uint8(b / n >> 8)
+			dst.Pix[off+3] = uint8(a / n >> 8)
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,349 @@
+package notification
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/snowflake"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/notification/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+const (
+	NOTIFICATION_SRV_NAME = "srv-notification"
+	NOTIFICATION_QUERY_OK = "OK"
+	NOTIFICATION_CACHE_PREFIX = "notifunread_"
+)
+
+type NotificationSrv struct {
+	proto.UnimplementedNotificationServer
+	uuid         string
+	cachec       *cacheclnt.CacheClnt
+	mongoCo      *mongo.Collection
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	idgen        *snowflake.Generator
+	nCounter     *tracing.Counter
+	rCounter     *tracing.Counter
+	uCounter     *tracing.Counter
+	mCounter     *tracing.Counter
+}
+
+func MakeNotificationSrv() *NotificationSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["NotificationPort"])
+	serv_ip := result["NotificationIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "notification", *jaegeraddr)
+	tracer, err := tracing.Init("notification", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start cache and DB connections")
+	cachec := cacheclnt.MakeCacheClnt()
+
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	collection := mongoClient.Database("socialnetwork").Collection("notification")
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "userid", Value: 1}, {Key: "timestamp", Value: -1}}}
+	name, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
+	log.Info().Msgf("Name of index created: %v", name)
+	log.Info().Msg("New mongo session successfull...")
+
+	return &NotificationSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		cachec:       cachec,
+		mongoCo:      collection,
+		nCounter:     tracing.MakeCounter("Notify"),
+		rCounter:     tracing.MakeCounter("Read-Notifications"),
+		uCounter:     tracing.MakeCounter("Get-Unread-Count"),
+		mCounter:     tracing.MakeCounter("Mark-Read"),
+	}
+}
+
+// Run starts the server
+func (nsrv *NotificationSrv) Run() error {
+	if nsrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC Server...")
+	nsrv.uuid = uuid.New().String()
+	var err error
+	nsrv.idgen, err = snowflake.MakeLeasedGenerator(nsrv.Registry)
+	if err != nil {
+		return fmt.Errorf("cannot lease snowflake worker id: %v", err)
+	}
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(nsrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterNotificationServer(grpcSrv, nsrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", nsrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = nsrv.Registry.Register(NOTIFICATION_SRV_NAME, nsrv.uuid, nsrv.IpAddr, nsrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+func (nsrv *NotificationSrv) Notify(
+		ctx context.Context, req *proto.NotifyRequest) (*proto.NotifyResponse, error) {
+	t0 := time.Now()
+	defer nsrv.nCounter.AddTimeSince(t0)
+	res := &proto.NotifyResponse{Ok: "No"}
+	timestamp := time.Now().UnixNano()
+	notifs := make([]interface{}, 0, len(req.Notifications))
+	recipients := make(map[int64]bool)
+	for _, notif := range req.Notifications {
+		if notif.Userid == notif.Actorid {
+			continue
+		}
+		// keep the time of the event itself when the feeder knows it
+		notifTime := notif.Timestamp
+		if notifTime == 0 {
+			notifTime = timestamp
+		}
+		notifid, err := nsrv.getNextNotificationId()
+		if err != nil {
+			return nil, err
+		}
+		notifs = append(notifs, &NotificationBson{
+			Notificationid: notifid,
+			Userid: notif.Userid,
+			Notificationtype: int32(notif.Notificationtype),
+			Actorid: notif.Actorid,
+			Actoruname: notif.Actoruname,
+			Postid: notif.Postid,
+			Timestamp: notifTime,
+		})
+		recipients[notif.Userid] = true
+	}
+	if len(notifs) > 0 {
+		if _, err := nsrv.mongoCo.InsertMany(context.TODO(), notifs); err != nil {
+			return nil, err
+		}
+	}
+	for userid := range recipients {
+		nsrv.clearCache(ctx, userid)
+	}
+	res.Ok = NOTIFICATION_QUERY_OK
+	return res, nil
+}
+
+func (nsrv *NotificationSrv) ReadNotifications(
+		ctx context.Context, req *proto.ReadNotificationsRequest) (
+		*proto.ReadNotificationsResponse, error) {
+	t0 := time.Now()
+	defer nsrv.rCounter.AddTimeSince(t0)
+	res := &proto.ReadNotificationsResponse{Ok: "No"}
+	if req.Start < 0 || req.Start >= req.Stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v", req.Start, req.Stop)
+		return res, nil
+	}
+	filter := bson.M{"userid": req.Userid}
+	if req.Unreadonly {
+		filter["read"] = false
+	}
+	cursor, err := nsrv.mongoCo.Find(
+		context.TODO(), filter,
+		options.Find().
+			SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "notificationid", Value: -1}}).
+			SetSkip(int64(req.Start)).
+			SetLimit(int64(req.Stop-req.Start)))
+	if err != nil {
+		return nil, err
+	}
+	var notifs []*NotificationBson
+	if err = cursor.All(context.TODO(), &notifs); err != nil {
+		return nil, err
+	}
+	res.Notifications = make([]*proto.NotificationItem, len(notifs))
+	for idx, notif := range notifs {
+		res.Notifications[idx] = bsonToNotification(notif)
+	}
+	nunread, err := nsrv.getUnreadCount(ctx, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	res.Nunread = nunread
+	res.Ok = NOTIFICATION_QUERY_OK
+	return res, nil
+}
+
+func (nsrv *NotificationSrv) GetUnreadCount(
+		ctx context.Context, req *proto.GetUnreadCountRequest) (
+		*proto.GetUnreadCountResponse, error) {
+	t0 := time.Now()
+	defer nsrv.uCounter.AddTimeSince(t0)
+	res := &proto.GetUnreadCountResponse{Ok: "No"}
+	nunread, err := nsrv.getUnreadCount(ctx, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	res.Nunread = nunread
+	res.Ok = NOTIFICATION_QUERY_OK
+	return res, nil
+}
+
+func (nsrv *NotificationSrv) MarkRead(
+		ctx context.Context, req *proto.MarkReadRequest) (*proto.NotifyResponse, error) {
+	t0 := time.Now()
+	defer nsrv.mCounter.AddTimeSince(t0)
+	res := &proto.NotifyResponse{Ok: "No"}
+	filter := bson.M{"userid": req.Userid, "read": false}
+	if !req.All {
+		if len(req.Notificationids) == 0 {
+			res.Ok = "No notifications to mark."
+			return res, nil
+		}
+		filter["notificationid"] = bson.M{"$in": req.Notificationids}
+	}
+	_, err := nsrv.mongoCo.UpdateMany(
+		context.TODO(), filter, &bson.M{"$set": bson.M{"read": true}})
+	if err != nil {
+		return nil, err
+	}
+	nsrv.clearCache(ctx, req.Userid)
+	res.Ok = NOTIFICATION_QUERY_OK
+	return res, nil
+}
+
+func (nsrv *NotificationSrv) clearCache(ctx context.Context, userid int64) {
+	key := NOTIFICATION_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+	if !nsrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete unread count of %v", key)
+	}
+}
+
+func (nsrv *NotificationSrv) getUnreadCount(ctx context.Context, userid int64) (int64, error) {
+	key := NOTIFICATION_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+	if countItem, err := nsrv.cachec.Get(ctx, key); err != nil {
+		if err != memcache.ErrCacheMiss {
+			return 0, err
+		}
+		log.Debug().Msgf("Unread count %v cache miss", key)
+		count, err := nsrv.mongoCo.CountDocuments(
+			context.TODO(), &bson.M{"userid": userid, "read": false})
+		if err != nil {
+			return 0, err
+		}
+		nsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: []byte(strconv.FormatInt(count, 10))})
+		return count, nil
+	} else {
+		log.Debug().Msgf("Found unread count of %v in cache!", userid)
+		return strconv.ParseInt(string(countItem.Value), 10, 64)
+	}
+}
+
+func bsonToNotification(notif *NotificationBson) *proto.NotificationItem {
+	return &proto.NotificationItem{
+		Notificationid: notif.Notificationid,
+		Userid: notif.Userid,
+		Notificationtype: proto.NOTIFICATION_TYPE(notif.Notificationtype),
+		Actorid: notif.Actorid,
+		Actoruname: notif.Actoruname,
+		Postid: notif.Postid,
+		Timestamp: notif.Timestamp,
+		Read: notif.Read,
+	}
+}
+
+type NotificationBson struct {
+	Notificationid int64   `bson:"notificationid"`
+	Userid int64           `bson:"userid"`
+	Notificationtype int32 `bson:"notificationtype"`
+	Actorid int64          `bson:"actorid"`
+	Actoruname string      `bson:"actoruname"`
+	Postid int64           `bson:"postid"`
+	Timestamp int64        `bson:"timestamp"`
+	Read bool              `bson:"read"`
+}
+
+func (nsrv *NotificationSrv) getNextNotificationId() (int64, error) {
+	return nsrv.idgen.Next()
+}
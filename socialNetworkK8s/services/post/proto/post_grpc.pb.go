@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	PostStorage_StorePost_FullMethodName = "/post.PostStorage/StorePost"
-	PostStorage_ReadPosts_FullMethodName = "/post.PostStorage/ReadPosts"
+	PostStorage_StorePost_FullMethodName       = "/post.PostStorage/StorePost"
+	PostStorage_ReadPosts_FullMethodName       = "/post.PostStorage/ReadPosts"
+	PostStorage_DeletePost_FullMethodName      = "/post.PostStorage/DeletePost"
+	PostStorage_EditPost_FullMethodName        = "/post.PostStorage/EditPost"
+	PostStorage_ReadPostHistory_FullMethodName = "/post.PostStorage/ReadPostHistory"
+	PostStorage_GetThread_FullMethodName       = "/post.PostStorage/GetThread"
 )
 
 // PostStorageClient is the client API for PostStorage service.
@@ -29,6 +33,10 @@ const (
 type PostStorageClient interface {
 	StorePost(ctx context.Context, in *StorePostRequest, opts ...grpc.CallOption) (*StorePostResponse, error)
 	ReadPosts(ctx context.Context, in *ReadPostsRequest, opts ...grpc.CallOption) (*ReadPostsResponse, error)
+	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error)
+	EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*EditPostResponse, error)
+	ReadPostHistory(ctx context.Context, in *ReadPostHistoryRequest, opts ...grpc.CallOption) (*ReadPostHistoryResponse, error)
+	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*GetThreadResponse, error)
 }
 
 type postStorageClient struct {
@@ -57,12 +65,52 @@ func (c *postStorageClient) ReadPosts(ctx context.Context, in *ReadPostsRequest,
 	return out, nil
 }
 
+func (c *postStorageClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*DeletePostResponse, error) {
+	out := new(DeletePostResponse)
+	err := c.cc.Invoke(ctx, PostStorage_DeletePost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postStorageClient) EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*EditPostResponse, error) {
+	out := new(EditPostResponse)
+	err := c.cc.Invoke(ctx, PostStorage_EditPost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postStorageClient) ReadPostHistory(ctx context.Context, in *ReadPostHistoryRequest, opts ...grpc.CallOption) (*ReadPostHistoryResponse, error) {
+	out := new(ReadPostHistoryResponse)
+	err := c.cc.Invoke(ctx, PostStorage_ReadPostHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postStorageClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*GetThreadResponse, error) {
+	out := new(GetThreadResponse)
+	err := c.cc.Invoke(ctx, PostStorage_GetThread_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PostStorageServer is the server API for PostStorage service.
 // All implementations must embed UnimplementedPostStorageServer
 // for forward compatibility
 type PostStorageServer interface {
 	StorePost(context.Context, *StorePostRequest) (*StorePostResponse, error)
 	ReadPosts(context.Context, *ReadPostsRequest) (*ReadPostsResponse, error)
+	DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error)
+	EditPost(context.Context, *EditPostRequest) (*EditPostResponse, error)
+	ReadPostHistory(context.Context, *ReadPostHistoryRequest) (*ReadPostHistoryResponse, error)
+	GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error)
 	mustEmbedUnimplementedPostStorageServer()
 }
 
@@ -76,6 +124,18 @@ func (UnimplementedPostStorageServer) StorePost(context.Context, *StorePostReque
 func (UnimplementedPostStorageServer) ReadPosts(context.Context, *ReadPostsRequest) (*ReadPostsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadPosts not implemented")
 }
+func (UnimplementedPostStorageServer) DeletePost(context.Context, *DeletePostRequest) (*DeletePostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+}
+func (UnimplementedPostStorageServer) EditPost(context.Context, *EditPostRequest) (*EditPostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditPost not implemented")
+}
+func (UnimplementedPostStorageServer) ReadPostHistory(context.Context, *ReadPostHistoryRequest) (*ReadPostHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadPostHistory not implemented")
+}
+func (UnimplementedPostStorageServer) GetThread(context.Context, *GetThreadRequest) (*GetThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+}
 func (UnimplementedPostStorageServer) mustEmbedUnimplementedPostStorageServer() {}
 
 // UnsafePostStorageServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +185,78 @@ func _PostStorage_ReadPosts_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PostStorage_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostStorageServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostStorage_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostStorageServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostStorage_EditPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostStorageServer).EditPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostStorage_EditPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostStorageServer).EditPost(ctx, req.(*EditPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostStorage_ReadPostHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadPostHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostStorageServer).ReadPostHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostStorage_ReadPostHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostStorageServer).ReadPostHistory(ctx, req.(*ReadPostHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostStorage_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostStorageServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostStorage_GetThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostStorageServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PostStorage_ServiceDesc is the grpc.ServiceDesc for PostStorage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,6 +272,22 @@ var PostStorage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadPosts",
 			Handler:    _PostStorage_ReadPosts_Handler,
 		},
+		{
+			MethodName: "DeletePost",
+			Handler:    _PostStorage_DeletePost_Handler,
+		},
+		{
+			MethodName: "EditPost",
+			Handler:    _PostStorage_EditPost_Handler,
+		},
+		{
+			MethodName: "ReadPostHistory",
+			Handler:    _PostStorage_ReadPostHistory_Handler,
+		},
+		{
+			MethodName: "GetThread",
+			Handler:    _PostStorage_GetThread_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/post/proto/post.proto",
@@ -21,6 +21,13 @@ import (
 	"socialnetworkk8/services/cacheclnt"
 	"socialnetworkk8/tls"
 	"socialnetworkk8/services/post/proto"
+	"socialnetworkk8/services/profile"
+	profilepb "socialnetworkk8/services/profile/proto"
+	"socialnetworkk8/services/reaction"
+	reactionpb "socialnetworkk8/services/reaction/proto"
+	"socialnetworkk8/services/search"
+	searchpb "socialnetworkk8/services/search/proto"
+	"socialnetworkk8/dialer"
 	opentracing "github.com/opentracing/opentracing-go"
 	"socialnetworkk8/tracing"
 	"github.com/rs/zerolog/log"
@@ -35,6 +42,10 @@ const (
 	POST_SRV_NAME = "srv-post"
 	POST_QUERY_OK = "OK"
 	POST_CACHE_PREFIX = "post_"
+	// replies laid out per thread, oldest first; later ones are left out
+	THREAD_MAX_POSTS = 10000
+	// thread items returned per page
+	THREAD_MAX_PAGE = 100
 )
 
 type PostSrv struct {
@@ -42,12 +53,18 @@ type PostSrv struct {
 	uuid         string
 	cachec       *cacheclnt.CacheClnt
 	mongoCo      *mongo.Collection
+	reactionc    reactionpb.ReactionClient
+	searchc      searchpb.SearchClient
+	profilec     profilepb.ProfileClient
 	Registry     *registry.Client
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
 	sCounter     *tracing.Counter
 	rCounter     *tracing.Counter
+	dCounter     *tracing.Counter
+	eCounter     *tracing.Counter
+	tCounter     *tracing.Counter
 }
 
 func MakePostSrv() *PostSrv {
@@ -101,6 +118,9 @@ func MakePostSrv() *PostSrv {
 	indexModel := mongo.IndexModel{Keys: bson.D{{"postid", 1}}}
 	name, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
 	log.Info().Msgf("Name of index created: %v", name)
+	rootIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "rootid", Value: 1}}}
+	name, err = collection.Indexes().CreateOne(context.TODO(), rootIndexModel)
+	log.Info().Msgf("Name of index created: %v", name)
 	log.Info().Msg("New mongo session successfull...")
 
 	return &PostSrv{
@@ -112,6 +132,9 @@ func MakePostSrv() *PostSrv {
 		mongoCo:      collection,
 		sCounter:     tracing.MakeCounter("Store-Post"),
 		rCounter:     tracing.MakeCounter("Read-Post"),
+		dCounter:     tracing.MakeCounter("Delete-Post"),
+		eCounter:     tracing.MakeCounter("Edit-Post"),
+		tCounter:     tracing.MakeCounter("Get-Thread"),
 	}
 }
 
@@ -122,6 +145,32 @@ func (psrv *PostSrv) Run() error {
 	}
 
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC clients...")
+	reactionConn, err := dialer.Dial(
+		reaction.REACTION_SRV_NAME,
+		psrv.Registry.Client,
+		dialer.WithTracer(psrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	psrv.reactionc = reactionpb.NewReactionClient(reactionConn)
+	searchConn, err := dialer.Dial(
+		search.SEARCH_SRV_NAME,
+		psrv.Registry.Client,
+		dialer.WithTracer(psrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	psrv.searchc = searchpb.NewSearchClient(searchConn)
+	profileConn, err := dialer.Dial(
+		profile.PROFILE_SRV_NAME,
+		psrv.Registry.Client,
+		dialer.WithTracer(psrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	psrv.profilec = profilepb.NewProfileClient(profileConn)
+
 	log.Info().Msg("Initializing gRPC Server...")
 	psrv.uuid = uuid.New().String()
 	opts := []grpc.ServerOption{
@@ -165,11 +214,34 @@ func (psrv *PostSrv) StorePost(
 	defer psrv.sCounter.AddTimeSince(t0)
 	res := &proto.StorePostResponse{}
 	res.Ok = "No"
+	if req.Post.Posttype == proto.POST_TYPE_DM {
+		// DMs live in the dm service and must never be readable as posts
+		res.Ok = "Cannot store a DM as a post."
+		return res, nil
+	}
 	postBson := postToBson(req.Post)
-	if _, err := psrv.mongoCo.InsertOne(context.TODO(), postBson); err != nil {
+	// compose retries stores, so a post already stored is left as it is
+	upd, err := psrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": postBson.Postid},
+		&bson.M{"$setOnInsert": postBson}, options.Update().SetUpsert(true))
+	if err != nil {
 		log.Error().Msg(err.Error())
 		return res, err
 	}
+	if upd.UpsertedCount == 0 {
+		if postBson, err = psrv.getPost(ctx, postBson.Postid); err != nil {
+			return res, err
+		}
+		if postBson == nil || postBson.Deleted {
+			res.Ok += fmt.Sprintf(" Post %v was deleted.", req.Post.Postid)
+			return res, nil
+		}
+	} else if postBson.Parentid != 0 {
+		if err := psrv.updateParentCount(ctx, postBson, 1); err != nil {
+			return res, err
+		}
+	}
+	psrv.indexPost(ctx, postBson)
 	res.Ok = POST_QUERY_OK
 	return res, nil
 }
@@ -180,9 +252,9 @@ func (psrv *PostSrv) ReadPosts(
 	defer psrv.rCounter.AddTimeSince(t0)
 	res := &proto.ReadPostsResponse{}
 	res.Ok = "No."
-	posts := make([]*proto.Post, len(req.Postids))
+	posts := make([]*proto.Post, 0, len(req.Postids))
 	missing := false
-	for idx, postid := range req.Postids {
+	for _, postid := range req.Postids {
 		postBson, err := psrv.getPost(ctx, postid)
 		if err != nil {
 			return nil, err
@@ -190,10 +262,14 @@ func (psrv *PostSrv) ReadPosts(
 		if postBson == nil {
 			missing = true
 			res.Ok = res.Ok + fmt.Sprintf(" Missing %v.", postid)
-		} else {
-			posts[idx] = bsonToPost(postBson)
+		} else if !postBson.Deleted && proto.POST_TYPE(postBson.Posttype) != proto.POST_TYPE_DM {
+			// tombstoned posts and DMs stored before DMs had their own service
+			// are skipped rather than reported as missing
+			posts = append(posts, bsonToPost(postBson))
 		}
 	}
+	psrv.addReactions(ctx, posts)
+	psrv.addAuthors(ctx, posts)
 	res.Posts = posts
 	if !missing {
 		res.Ok = POST_QUERY_OK
@@ -201,6 +277,344 @@ func (psrv *PostSrv) ReadPosts(
 	return res, nil
 }
 
+func (psrv *PostSrv) DeletePost(
+		ctx context.Context, req *proto.DeletePostRequest) (*proto.DeletePostResponse, error) {
+	t0 := time.Now()
+	defer psrv.dCounter.AddTimeSince(t0)
+	res := &proto.DeletePostResponse{Ok: "No."}
+	postBson, err := psrv.getPost(ctx, req.Postid)
+	if err != nil {
+		return nil, err
+	}
+	if postBson == nil || postBson.Deleted {
+		res.Ok += fmt.Sprintf(" Missing %v.", req.Postid)
+		return res, nil
+	}
+	if postBson.Creator != req.Userid {
+		res.Ok += fmt.Sprintf(" User %v cannot delete post %v.", req.Userid, req.Postid)
+		return res, nil
+	}
+	// keep a tombstone so that stale timeline entries resolve to nothing
+	_, err = psrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": req.Postid},
+		&bson.M{"$set": bson.M{
+			"deleted": true, "text": "", "urls": []string{}, "medias": []int64{},
+			"hashtags": []string{}, "flags": []string{}, "history": []PostEditBson{}}})
+	if err != nil {
+		return nil, err
+	}
+	psrv.clearCache(ctx, req.Postid)
+	if postBson.Parentid != 0 {
+		if err := psrv.updateParentCount(ctx, postBson, -1); err != nil {
+			return nil, err
+		}
+	}
+	psrv.unindexPost(ctx, req.Postid)
+	res.Ok = POST_QUERY_OK
+	res.Post = bsonToPost(postBson)
+	return res, nil
+}
+
+func (psrv *PostSrv) EditPost(
+		ctx context.Context, req *proto.EditPostRequest) (*proto.EditPostResponse, error) {
+	t0 := time.Now()
+	defer psrv.eCounter.AddTimeSince(t0)
+	res := &proto.EditPostResponse{Ok: "No."}
+	postBson, err := psrv.getPost(ctx, req.Postid)
+	if err != nil {
+		return nil, err
+	}
+	if postBson == nil || postBson.Deleted {
+		res.Ok += fmt.Sprintf(" Missing %v.", req.Postid)
+		return res, nil
+	}
+	if postBson.Creator != req.Userid {
+		res.Ok += fmt.Sprintf(" User %v cannot edit post %v.", req.Userid, req.Postid)
+		return res, nil
+	}
+	if req.Text == "" {
+		res.Ok += " Cannot edit to empty text."
+		return res, nil
+	}
+	// the replaced version goes into the history, stamped with when it was written
+	prevTimestamp := postBson.EditTimestamp
+	if prevTimestamp == 0 {
+		prevTimestamp = postBson.Timestamp
+	}
+	prevEdit := PostEditBson{Text: postBson.Text, Timestamp: prevTimestamp}
+	editTimestamp := time.Now().UnixNano()
+	_, err = psrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": req.Postid},
+		&bson.M{
+			"$set": bson.M{
+				"text": req.Text,
+				"usermentions": req.Usermentions,
+				"urls": req.Urls,
+				"hashtags": req.Hashtags,
+				"flags": req.Flags,
+				"edittimestamp": editTimestamp},
+			"$push": bson.M{"history": prevEdit}})
+	if err != nil {
+		return nil, err
+	}
+	psrv.clearCache(ctx, req.Postid)
+	postBson.Text = req.Text
+	postBson.Usermentions = req.Usermentions
+	postBson.Urls = req.Urls
+	postBson.Hashtags = req.Hashtags
+	postBson.Flags = req.Flags
+	postBson.EditTimestamp = editTimestamp
+	psrv.indexPost(ctx, postBson)
+	res.Ok = POST_QUERY_OK
+	res.Post = bsonToPost(postBson)
+	return res, nil
+}
+
+func (psrv *PostSrv) ReadPostHistory(
+		ctx context.Context, req *proto.ReadPostHistoryRequest) (
+		*proto.ReadPostHistoryResponse, error) {
+	res := &proto.ReadPostHistoryResponse{Ok: "No."}
+	postBson, err := psrv.getPost(ctx, req.Postid)
+	if err != nil {
+		return nil, err
+	}
+	if postBson == nil || postBson.Deleted {
+		res.Ok += fmt.Sprintf(" Missing %v.", req.Postid)
+		return res, nil
+	}
+	res.Edits = make([]*proto.PostEdit, len(postBson.History))
+	for idx, edit := range postBson.History {
+		res.Edits[idx] = &proto.PostEdit{Text: edit.Text, Timestamp: edit.Timestamp}
+	}
+	res.Ok = POST_QUERY_OK
+	return res, nil
+}
+
+func (psrv *PostSrv) GetThread(
+		ctx context.Context, req *proto.GetThreadRequest) (*proto.GetThreadResponse, error) {
+	t0 := time.Now()
+	defer psrv.tCounter.AddTimeSince(t0)
+	res := &proto.GetThreadResponse{Ok: "No."}
+	postBson, err := psrv.getPost(ctx, req.Postid)
+	if err != nil {
+		return nil, err
+	}
+	if postBson == nil {
+		res.Ok += fmt.Sprintf(" Missing %v.", req.Postid)
+		return res, nil
+	}
+	rootid := postBson.Postid
+	if postBson.Rootid != 0 {
+		rootid = postBson.Rootid
+	}
+	// lay the thread out from the ids alone, then load the page's posts
+	cursor, err := psrv.mongoCo.Find(
+		context.TODO(),
+		&bson.M{"$or": []bson.M{{"postid": rootid}, {"rootid": rootid}}},
+		options.Find().
+			SetSort(bson.D{{Key: "timestamp", Value: 1}}).
+			SetLimit(THREAD_MAX_POSTS).
+			SetProjection(bson.D{
+				{Key: "postid", Value: 1}, {Key: "parentid", Value: 1}, {Key: "deleted", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*threadNodeBson
+	if err = cursor.All(context.TODO(), &nodes); err != nil {
+		return nil, err
+	}
+	slots := flattenThread(rootid, nodes)
+	res.Rootid = rootid
+	res.Nitems = int32(len(slots))
+	start, stop, nItems := req.Start, req.Stop, int32(len(slots))
+	if start < 0 || start >= nItems || start >= stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v for %v items", start, stop, nItems)
+		return res, nil
+	}
+	if stop > nItems {
+		stop = nItems
+	}
+	if stop - start > THREAD_MAX_PAGE {
+		stop = start + THREAD_MAX_PAGE
+	}
+	res.Items = make([]*proto.ThreadItem, 0, stop-start)
+	threadPosts := make([]*proto.Post, 0, stop-start)
+	for _, slot := range slots[start:stop] {
+		postBson, err := psrv.getPost(ctx, slot.postid)
+		if err != nil {
+			return nil, err
+		}
+		if postBson == nil || postBson.Deleted {
+			// deleted since the layout was read
+			continue
+		}
+		p := bsonToPost(postBson)
+		res.Items = append(res.Items, &proto.ThreadItem{Post: p, Depth: slot.depth})
+		threadPosts = append(threadPosts, p)
+	}
+	psrv.addReactions(ctx, threadPosts)
+	psrv.addAuthors(ctx, threadPosts)
+	res.Ok = POST_QUERY_OK
+	return res, nil
+}
+
+// threadNodeBson is the part of a post needed to lay out its thread.
+type threadNodeBson struct {
+	Postid   int64 `bson:"postid"`
+	Parentid int64 `bson:"parentid"`
+	Deleted  bool  `bson:"deleted"`
+}
+
+type threadSlot struct {
+	postid int64
+	depth  int32
+}
+
+// flattenThread orders the posts of a thread depth-first, replies sorted by
+// time. Tombstoned posts are left out, but their replies keep their place.
+func flattenThread(rootid int64, nodes []*threadNodeBson) []threadSlot {
+	children := make(map[int64][]*threadNodeBson)
+	var root *threadNodeBson
+	for _, node := range nodes {
+		if node.Postid == rootid {
+			root = node
+		} else {
+			children[node.Parentid] = append(children[node.Parentid], node)
+		}
+	}
+	slots := make([]threadSlot, 0, len(nodes))
+	if root == nil {
+		return slots
+	}
+	var visit func(node *threadNodeBson, depth int32)
+	visit = func(node *threadNodeBson, depth int32) {
+		if !node.Deleted {
+			slots = append(slots, threadSlot{postid: node.Postid, depth: depth})
+		}
+		for _, child := range children[node.Postid] {
+			visit(child, depth+1)
+		}
+	}
+	visit(root, 0)
+	return slots
+}
+
+// updateParentCount adjusts the reply or repost counter of the post that
+// child answers.
+func (psrv *PostSrv) updateParentCount(ctx context.Context, child *PostBson, delta int) error {
+	field := "nreplies"
+	if proto.POST_TYPE(child.Posttype) == proto.POST_TYPE_REPOST {
+		field = "nreposts"
+	}
+	_, err := psrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": child.Parentid}, &bson.M{"$inc": bson.M{field: delta}})
+	if err != nil {
+		log.Error().Msgf("cannot update %v of %v: %v", field, child.Parentid, err)
+		return err
+	}
+	psrv.clearCache(ctx, child.Parentid)
+	return nil
+}
+
+// indexPost makes the current text of a post searchable. Indexing is best
+// effort: the post is stored by then, so failures are logged rather than
+// reported, and the post is only missing from search results until its
+// next edit.
+func (psrv *PostSrv) indexPost(ctx context.Context, postBson *PostBson) {
+	searchRes, err := psrv.searchc.IndexPost(ctx, &searchpb.IndexPostRequest{
+		Postid: postBson.Postid,
+		Creator: postBson.Creator,
+		Timestamp: postBson.Timestamp,
+		Text: postBson.Text,
+	})
+	if err != nil {
+		log.Error().Msgf("Error indexing post %v: %v", postBson.Postid, err)
+	} else if searchRes.Ok != search.SEARCH_QUERY_OK {
+		log.Error().Msgf("Cannot index post %v: %v", postBson.Postid, searchRes.Ok)
+	}
+}
+
+// unindexPost takes a deleted post out of search, best effort like
+// indexPost. Search results are read back through ReadPosts, which skips
+// deleted posts, so a post left in the index is never shown.
+func (psrv *PostSrv) unindexPost(ctx context.Context, postid int64) {
+	searchRes, err := psrv.searchc.RemovePost(ctx, &searchpb.RemovePostRequest{Postid: postid})
+	if err != nil {
+		log.Error().Msgf("Error unindexing post %v: %v", postid, err)
+	} else if searchRes.Ok != search.SEARCH_QUERY_OK {
+		log.Error().Msgf("Cannot unindex post %v: %v", postid, searchRes.Ok)
+	}
+}
+
+// addReactions fills in reaction counts. They change far more often than
+// posts, so they are kept out of the post cache and fetched on every read.
+// Counts are best effort: if the reaction service fails, posts are still
+// returned, without them.
+func (psrv *PostSrv) addReactions(ctx context.Context, posts []*proto.Post) {
+	if len(posts) == 0 {
+		return
+	}
+	postids := make([]int64, len(posts))
+	for idx, post := range posts {
+		postids[idx] = post.Postid
+	}
+	reactionRes, err := psrv.reactionc.GetReactions(
+		ctx, &reactionpb.GetReactionsRequest{Postids: postids})
+	if err != nil {
+		log.Error().Msgf("Error reading reactions: %v", err)
+		return
+	}
+	if reactionRes.Ok != reaction.REACTION_QUERY_OK || len(reactionRes.Reactions) != len(posts) {
+		log.Error().Msgf("Cannot read reactions: %v", reactionRes.Ok)
+		return
+	}
+	for idx, reactions := range reactionRes.Reactions {
+		posts[idx].Nreactions = reactions.Total
+		posts[idx].Reactions = reactions.Counts
+	}
+}
+
+// addAuthors fills in the profile of each post's creator, so display names
+// and avatars follow profile updates instead of being copied into posts.
+// Creators without a profile keep only their id. Like reaction counts,
+// authors are best effort: if the profile service fails, posts are still
+// returned, without them.
+func (psrv *PostSrv) addAuthors(ctx context.Context, posts []*proto.Post) {
+	if len(posts) == 0 {
+		return
+	}
+	creators := make([]int64, 0, len(posts))
+	seen := make(map[int64]bool)
+	for _, post := range posts {
+		if !seen[post.Creator] {
+			seen[post.Creator] = true
+			creators = append(creators, post.Creator)
+		}
+	}
+	profileRes, err := psrv.profilec.GetUsers(ctx, &profilepb.GetUsersRequest{Userids: creators})
+	if err != nil {
+		log.Error().Msgf("Error reading authors: %v", err)
+		return
+	}
+	if profileRes.Ok != profile.PROFILE_QUERY_OK {
+		log.Debug().Msgf("Reading authors: %v", profileRes.Ok)
+	}
+	authors := make(map[int64]*profilepb.UserProfile, len(profileRes.Profiles))
+	for _, author := range profileRes.Profiles {
+		authors[author.Userid] = author
+	}
+	for _, post := range posts {
+		post.Author = authors[post.Creator]
+	}
+}
+
+func (psrv *PostSrv) clearCache(ctx context.Context, postid int64) {
+	key := POST_CACHE_PREFIX + strconv.FormatInt(postid, 10)
+	if !psrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete post %v", key)
+	}
+}
+
 func (psrv *PostSrv) getPost(ctx context.Context, postid int64) (*PostBson, error) {
 	key := POST_CACHE_PREFIX + strconv.FormatInt(postid, 10) 
 	postBson := &PostBson{}
@@ -241,6 +655,10 @@ func postToBson(post *proto.Post) *PostBson {
 		Usermentions: post.Usermentions,
 		Medias: post.Medias,
 		Urls: post.Urls,
+		Hashtags: post.Hashtags,
+		Flags: post.Flags,
+		Parentid: post.Parentid,
+		Rootid: post.Rootid,
 	}
 }
 
@@ -255,6 +673,13 @@ func bsonToPost(bson *PostBson) *proto.Post {
 		Usermentions: bson.Usermentions,
 		Medias: bson.Medias,
 		Urls: bson.Urls,
+		Hashtags: bson.Hashtags,
+		Flags: bson.Flags,
+		Edittimestamp: bson.EditTimestamp,
+		Parentid: bson.Parentid,
+		Rootid: bson.Rootid,
+		Nreplies: bson.Nreplies,
+		Nreposts: bson.Nreposts,
 	}
 }
 
@@ -268,6 +693,20 @@ type PostBson struct {
 	Usermentions []int64 `bson:usermentions`
 	Medias []int64       `bson:medias`
 	Urls []string        `bson:urls`
+	Hashtags []string    `bson:"hashtags"`
+	Flags []string       `bson:"flags"`
+	Deleted bool         `bson:"deleted"`
+	EditTimestamp int64  `bson:"edittimestamp"`
+	History []PostEditBson `bson:"history"`
+	Parentid int64       `bson:"parentid"`
+	Rootid int64         `bson:"rootid"`
+	Nreplies int32       `bson:"nreplies"`
+	Nreposts int32       `bson:"nreposts"`
+}
+
+type PostEditBson struct {
+	Text      string `bson:"text"`
+	Timestamp int64  `bson:"timestamp"`
 }
 
 
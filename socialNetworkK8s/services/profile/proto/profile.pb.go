@@ -25,17 +25,18 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
-type Request struct {
+type CreateProfileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HotelIds []string `protobuf:"bytes,1,rep,name=hotelIds,proto3" json:"hotelIds,omitempty"`
-	Locale   string   `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	Userid      int64  `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Username    string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Displayname string `protobuf:"bytes,3,opt,name=displayname,proto3" json:"displayname,omitempty"`
 }
 
-func (x *Request) Reset() {
-	*x = Request{}
+func (x *CreateProfileRequest) Reset() {
+	*x = CreateProfileRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_services_profile_proto_profile_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -43,13 +44,13 @@ func (x *Request) Reset() {
 	}
 }
 
-func (x *Request) String() string {
+func (x *CreateProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request) ProtoMessage() {}
+func (*CreateProfileRequest) ProtoMessage() {}
 
-func (x *Request) ProtoReflect() protoreflect.Message {
+func (x *CreateProfileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_services_profile_proto_profile_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -61,35 +62,42 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request.ProtoReflect.Descriptor instead.
-func (*Request) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateProfileRequest.ProtoReflect.Descriptor instead.
+func (*CreateProfileRequest) Descriptor() ([]byte, []int) {
 	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Request) GetHotelIds() []string {
+func (x *CreateProfileRequest) GetUserid() int64 {
 	if x != nil {
-		return x.HotelIds
+		return x.Userid
 	}
-	return nil
+	return 0
+}
+
+func (x *CreateProfileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
 
-func (x *Request) GetLocale() string {
+func (x *CreateProfileRequest) GetDisplayname() string {
 	if x != nil {
-		return x.Locale
+		return x.Displayname
 	}
 	return ""
 }
 
-type Result struct {
+type ProfileResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hotels []*Hotel `protobuf:"bytes,1,rep,name=hotels,proto3" json:"hotels,omitempty"`
+	Ok string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
 }
 
-func (x *Result) Reset() {
-	*x = Result{}
+func (x *ProfileResponse) Reset() {
+	*x = ProfileResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_services_profile_proto_profile_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -97,13 +105,13 @@ func (x *Result) Reset() {
 	}
 }
 
-func (x *Result) String() string {
+func (x *ProfileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Result) ProtoMessage() {}
+func (*ProfileResponse) ProtoMessage() {}
 
-func (x *Result) ProtoReflect() protoreflect.Message {
+func (x *ProfileResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_services_profile_proto_profile_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -115,33 +123,28 @@ func (x *Result) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Result.ProtoReflect.Descriptor instead.
-func (*Result) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProfileResponse.ProtoReflect.Descriptor instead.
+func (*ProfileResponse) Descriptor() ([]byte, []int) {
 	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Result) GetHotels() []*Hotel {
+func (x *ProfileResponse) GetOk() string {
 	if x != nil {
-		return x.Hotels
+		return x.Ok
 	}
-	return nil
+	return ""
 }
 
-type Hotel struct {
+type GetUsersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PhoneNumber string   `protobuf:"bytes,3,opt,name=phoneNumber,proto3" json:"phoneNumber,omitempty"`
-	Description string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Address     *Address `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
-	Images      []*Image `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Userids []int64 `protobuf:"varint,1,rep,packed,name=userids,proto3" json:"userids,omitempty"`
 }
 
-func (x *Hotel) Reset() {
-	*x = Hotel{}
+func (x *GetUsersRequest) Reset() {
+	*x = GetUsersRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_services_profile_proto_profile_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -149,13 +152,13 @@ func (x *Hotel) Reset() {
 	}
 }
 
-func (x *Hotel) String() string {
+func (x *GetUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Hotel) ProtoMessage() {}
+func (*GetUsersRequest) ProtoMessage() {}
 
-func (x *Hotel) ProtoReflect() protoreflect.Message {
+func (x *GetUsersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_services_profile_proto_profile_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -167,85 +170,106 @@ func (x *Hotel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Hotel.ProtoReflect.Descriptor instead.
-func (*Hotel) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUsersRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersRequest) Descriptor() ([]byte, []int) {
 	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Hotel) GetId() string {
+func (x *GetUsersRequest) GetUserids() []int64 {
 	if x != nil {
-		return x.Id
+		return x.Userids
 	}
-	return ""
+	return nil
 }
 
-func (x *Hotel) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Profiles are in the order of the requested userids; a missing user is
+// named in ok and gets a profile with only its userid set.
+type GetUsersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok       string         `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Profiles []*UserProfile `protobuf:"bytes,2,rep,name=profiles,proto3" json:"profiles,omitempty"`
 }
 
-func (x *Hotel) GetPhoneNumber() string {
-	if x != nil {
-		return x.PhoneNumber
+func (x *GetUsersResponse) Reset() {
+	*x = GetUsersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_profile_proto_profile_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Hotel) GetDescription() string {
-	if x != nil {
-		return x.Description
+func (x *GetUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersResponse) ProtoMessage() {}
+
+func (x *GetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_profile_proto_profile_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Hotel) GetAddress() *Address {
+// Deprecated: Use GetUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUsersResponse) GetOk() string {
 	if x != nil {
-		return x.Address
+		return x.Ok
 	}
-	return nil
+	return ""
 }
 
-func (x *Hotel) GetImages() []*Image {
+func (x *GetUsersResponse) GetProfiles() []*UserProfile {
 	if x != nil {
-		return x.Images
+		return x.Profiles
 	}
 	return nil
 }
 
-type Address struct {
+// UpdateProfile sets the listed fields, one of "displayname", "bio" and
+// "avatarmediaid", or all three if none are listed.
+type UpdateProfileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StreetNumber string  `protobuf:"bytes,1,opt,name=streetNumber,proto3" json:"streetNumber,omitempty"`
-	StreetName   string  `protobuf:"bytes,2,opt,name=streetName,proto3" json:"streetName,omitempty"`
-	City         string  `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
-	State        string  `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
-	Country      string  `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
-	PostalCode   string  `protobuf:"bytes,6,opt,name=postalCode,proto3" json:"postalCode,omitempty"`
-	Lat          float32 `protobuf:"fixed32,7,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lon          float32 `protobuf:"fixed32,8,opt,name=lon,proto3" json:"lon,omitempty"`
+	Userid        int64    `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Displayname   string   `protobuf:"bytes,2,opt,name=displayname,proto3" json:"displayname,omitempty"`
+	Bio           string   `protobuf:"bytes,3,opt,name=bio,proto3" json:"bio,omitempty"`
+	Avatarmediaid int64    `protobuf:"varint,4,opt,name=avatarmediaid,proto3" json:"avatarmediaid,omitempty"`
+	Fields        []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
 }
 
-func (x *Address) Reset() {
-	*x = Address{}
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_services_profile_proto_profile_proto_msgTypes[3]
+		mi := &file_services_profile_proto_profile_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Address) String() string {
+func (x *UpdateProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Address) ProtoMessage() {}
+func (*UpdateProfileRequest) ProtoMessage() {}
 
-func (x *Address) ProtoReflect() protoreflect.Message {
-	mi := &file_services_profile_proto_profile_proto_msgTypes[3]
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_profile_proto_profile_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -256,93 +280,76 @@ func (x *Address) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Address.ProtoReflect.Descriptor instead.
-func (*Address) Descriptor() ([]byte, []int) {
-	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *Address) GetStreetNumber() string {
-	if x != nil {
-		return x.StreetNumber
-	}
-	return ""
-}
-
-func (x *Address) GetStreetName() string {
-	if x != nil {
-		return x.StreetName
-	}
-	return ""
-}
-
-func (x *Address) GetCity() string {
-	if x != nil {
-		return x.City
-	}
-	return ""
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Address) GetState() string {
+func (x *UpdateProfileRequest) GetUserid() int64 {
 	if x != nil {
-		return x.State
+		return x.Userid
 	}
-	return ""
+	return 0
 }
 
-func (x *Address) GetCountry() string {
+func (x *UpdateProfileRequest) GetDisplayname() string {
 	if x != nil {
-		return x.Country
+		return x.Displayname
 	}
 	return ""
 }
 
-func (x *Address) GetPostalCode() string {
+func (x *UpdateProfileRequest) GetBio() string {
 	if x != nil {
-		return x.PostalCode
+		return x.Bio
 	}
 	return ""
 }
 
-func (x *Address) GetLat() float32 {
+func (x *UpdateProfileRequest) GetAvatarmediaid() int64 {
 	if x != nil {
-		return x.Lat
+		return x.Avatarmediaid
 	}
 	return 0
 }
 
-func (x *Address) GetLon() float32 {
+func (x *UpdateProfileRequest) GetFields() []string {
 	if x != nil {
-		return x.Lon
+		return x.Fields
 	}
-	return 0
+	return nil
 }
 
-type Image struct {
+type UserProfile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Url     string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	Default bool   `protobuf:"varint,2,opt,name=default,proto3" json:"default,omitempty"`
+	Userid        int64  `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Displayname   string `protobuf:"bytes,3,opt,name=displayname,proto3" json:"displayname,omitempty"`
+	Bio           string `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
+	Avatarmediaid int64  `protobuf:"varint,5,opt,name=avatarmediaid,proto3" json:"avatarmediaid,omitempty"`
+	Createdat     int64  `protobuf:"varint,6,opt,name=createdat,proto3" json:"createdat,omitempty"`
 }
 
-func (x *Image) Reset() {
-	*x = Image{}
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_services_profile_proto_profile_proto_msgTypes[4]
+		mi := &file_services_profile_proto_profile_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Image) String() string {
+func (x *UserProfile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Image) ProtoMessage() {}
+func (*UserProfile) ProtoMessage() {}
 
-func (x *Image) ProtoReflect() protoreflect.Message {
-	mi := &file_services_profile_proto_profile_proto_msgTypes[4]
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_services_profile_proto_profile_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,23 +360,51 @@ func (x *Image) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Image.ProtoReflect.Descriptor instead.
-func (*Image) Descriptor() ([]byte, []int) {
-	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_services_profile_proto_profile_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Image) GetUrl() string {
+func (x *UserProfile) GetUserid() int64 {
 	if x != nil {
-		return x.Url
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *UserProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-func (x *Image) GetDefault() bool {
+func (x *UserProfile) GetDisplayname() string {
 	if x != nil {
-		return x.Default
+		return x.Displayname
 	}
-	return false
+	return ""
+}
+
+func (x *UserProfile) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *UserProfile) GetAvatarmediaid() int64 {
+	if x != nil {
+		return x.Avatarmediaid
+	}
+	return 0
+}
+
+func (x *UserProfile) GetCreatedat() int64 {
+	if x != nil {
+		return x.Createdat
+	}
+	return 0
 }
 
 var File_services_profile_proto_profile_proto protoreflect.FileDescriptor
@@ -378,49 +413,62 @@ var file_services_profile_proto_profile_proto_rawDesc = []byte{
 	0x0a, 0x24, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x66, 0x69,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22,
-	0x3d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f,
-	0x74, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f,
-	0x74, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x22, 0x30,
-	0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x68, 0x6f, 0x74, 0x65,
-	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x2e, 0x48, 0x6f, 0x74, 0x65, 0x6c, 0x52, 0x06, 0x68, 0x6f, 0x74, 0x65, 0x6c, 0x73,
-	0x22, 0xc3, 0x01, 0x0a, 0x05, 0x48, 0x6f, 0x74, 0x65, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20,
-	0x0a, 0x0b, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x26,
-	0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x06,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65,
-	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f,
-	0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61,
-	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x6c, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x6c, 0x6f, 0x6e, 0x22, 0x33,
-	0x0a, 0x05, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61,
-	0x75, 0x6c, 0x74, 0x32, 0x3b, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x30,
-	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x2e,
-	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x42, 0x1a, 0x5a, 0x18, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x70,
-	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x21, 0x0a,
+	0x0f, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b,
+	0x22, 0x2b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x73, 0x22, 0x54, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x6b, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x6f, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x76, 0x61, 0x74,
+	0x61, 0x72, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0xb9, 0x01, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x62, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x6f, 0x12, 0x24,
+	0x0a, 0x0d, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x69, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x61,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x61, 0x74, 0x32, 0xde, 0x01, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x48,
+	0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x1a, 0x5a, 0x18, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x2f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -435,25 +483,28 @@ func file_services_profile_proto_profile_proto_rawDescGZIP() []byte {
 	return file_services_profile_proto_profile_proto_rawDescData
 }
 
-var file_services_profile_proto_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_services_profile_proto_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_services_profile_proto_profile_proto_goTypes = []interface{}{
-	(*Request)(nil), // 0: profile.Request
-	(*Result)(nil),  // 1: profile.Result
-	(*Hotel)(nil),   // 2: profile.Hotel
-	(*Address)(nil), // 3: profile.Address
-	(*Image)(nil),   // 4: profile.Image
+	(*CreateProfileRequest)(nil), // 0: profile.CreateProfileRequest
+	(*ProfileResponse)(nil),      // 1: profile.ProfileResponse
+	(*GetUsersRequest)(nil),      // 2: profile.GetUsersRequest
+	(*GetUsersResponse)(nil),     // 3: profile.GetUsersResponse
+	(*UpdateProfileRequest)(nil), // 4: profile.UpdateProfileRequest
+	(*UserProfile)(nil),          // 5: profile.UserProfile
 }
 var file_services_profile_proto_profile_proto_depIdxs = []int32{
-	2, // 0: profile.Result.hotels:type_name -> profile.Hotel
-	3, // 1: profile.Hotel.address:type_name -> profile.Address
-	4, // 2: profile.Hotel.images:type_name -> profile.Image
-	0, // 3: profile.Profile.GetProfiles:input_type -> profile.Request
-	1, // 4: profile.Profile.GetProfiles:output_type -> profile.Result
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 0: profile.GetUsersResponse.profiles:type_name -> profile.UserProfile
+	0, // 1: profile.Profile.CreateProfile:input_type -> profile.CreateProfileRequest
+	2, // 2: profile.Profile.GetUsers:input_type -> profile.GetUsersRequest
+	4, // 3: profile.Profile.UpdateProfile:input_type -> profile.UpdateProfileRequest
+	1, // 4: profile.Profile.CreateProfile:output_type -> profile.ProfileResponse
+	3, // 5: profile.Profile.GetUsers:output_type -> profile.GetUsersResponse
+	1, // 6: profile.Profile.UpdateProfile:output_type -> profile.ProfileResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_services_profile_proto_profile_proto_init() }
@@ -463,7 +514,7 @@ func file_services_profile_proto_profile_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_services_profile_proto_profile_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request); i {
+			switch v := v.(*CreateProfileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -475,7 +526,7 @@ func file_services_profile_proto_profile_proto_init() {
 			}
 		}
 		file_services_profile_proto_profile_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Result); i {
+			switch v := v.(*ProfileResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -487,7 +538,7 @@ func file_services_profile_proto_profile_proto_init() {
 			}
 		}
 		file_services_profile_proto_profile_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Hotel); i {
+			switch v := v.(*GetUsersRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -499,7 +550,7 @@ func file_services_profile_proto_profile_proto_init() {
 			}
 		}
 		file_services_profile_proto_profile_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Address); i {
+			switch v := v.(*GetUsersResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -511,7 +562,19 @@ func file_services_profile_proto_profile_proto_init() {
 			}
 		}
 		file_services_profile_proto_profile_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Image); i {
+			switch v := v.(*UpdateProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_profile_proto_profile_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserProfile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -529,7 +592,7 @@ func file_services_profile_proto_profile_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_profile_proto_profile_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,357 @@
+package profile
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"fmt"
+	"unicode/utf8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/dialer"
+	"socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/profile/proto"
+	"socialnetworkk8/services/media"
+	mediapb "socialnetworkk8/services/media/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+const (
+	PROFILE_SRV_NAME = "srv-profile"
+	PROFILE_QUERY_OK = "OK"
+	PROFILE_CACHE_PREFIX = "profile_"
+	DISPLAYNAME_MAX_LENGTH = 50
+	BIO_MAX_LENGTH = 160
+)
+
+type ProfileSrv struct {
+	proto.UnimplementedProfileServer
+	uuid         string
+	cachec       *cacheclnt.CacheClnt
+	mongoCo      *mongo.Collection
+	mediac       mediapb.MediaStorageClient
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	cCounter     *tracing.Counter
+	gCounter     *tracing.Counter
+	uCounter     *tracing.Counter
+}
+
+func MakeProfileSrv() *ProfileSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["ProfilePort"])
+	serv_ip := result["ProfileIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "profile", *jaegeraddr)
+	tracer, err := tracing.Init("profile", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start cache and DB connections")
+	cachec := cacheclnt.MakeCacheClnt()
+
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	collection := mongoClient.Database("socialnetwork").Collection("profile")
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "userid", Value: 1}}, Options: options.Index().SetUnique(true)}
+	name, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
+	log.Info().Msgf("Name of index created: %v", name)
+	log.Info().Msg("New mongo session successfull...")
+
+	return &ProfileSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		cachec:       cachec,
+		mongoCo:      collection,
+		cCounter:     tracing.MakeCounter("Create-Profile"),
+		gCounter:     tracing.MakeCounter("Get-Users"),
+		uCounter:     tracing.MakeCounter("Update-Profile"),
+	}
+}
+
+// Run starts the server
+func (psrv *ProfileSrv) Run() error {
+	if psrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC clients...")
+	conn, err := dialer.Dial(
+		media.MEDIA_SRV_NAME,
+		psrv.Registry.Client,
+		dialer.WithTracer(psrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	psrv.mediac = mediapb.NewMediaStorageClient(conn)
+
+	log.Info().Msg("Initializing gRPC Server...")
+	psrv.uuid = uuid.New().String()
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(psrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterProfileServer(grpcSrv, psrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", psrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = psrv.Registry.Register(PROFILE_SRV_NAME, psrv.uuid, psrv.IpAddr, psrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+func (psrv *ProfileSrv) CreateProfile(
+		ctx context.Context, req *proto.CreateProfileRequest) (*proto.ProfileResponse, error) {
+	t0 := time.Now()
+	defer psrv.cCounter.AddTimeSince(t0)
+	res := &proto.ProfileResponse{Ok: "No"}
+	if errStr := checkDisplayname(req.Displayname); errStr != "" {
+		res.Ok = errStr
+		return res, nil
+	}
+	profile := &ProfileBson{
+		Userid: req.Userid,
+		Username: req.Username,
+		Displayname: req.Displayname,
+		Createdat: time.Now().UnixNano(),
+	}
+	if _, err := psrv.mongoCo.InsertOne(context.TODO(), profile); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			res.Ok = fmt.Sprintf("Profile of %v already exists.", req.Userid)
+			return res, nil
+		}
+		return nil, err
+	}
+	psrv.clearCache(ctx, req.Userid)
+	res.Ok = PROFILE_QUERY_OK
+	return res, nil
+}
+
+func (psrv *ProfileSrv) GetUsers(
+		ctx context.Context, req *proto.GetUsersRequest) (*proto.GetUsersResponse, error) {
+	t0 := time.Now()
+	defer psrv.gCounter.AddTimeSince(t0)
+	res := &proto.GetUsersResponse{Ok: "No."}
+	res.Profiles = make([]*proto.UserProfile, len(req.Userids))
+	missing := false
+	for idx, userid := range req.Userids {
+		profile, err := psrv.getProfile(ctx, userid)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			missing = true
+			res.Ok += fmt.Sprintf(" Missing %v.", userid)
+			res.Profiles[idx] = &proto.UserProfile{Userid: userid}
+		} else {
+			res.Profiles[idx] = bsonToProfile(profile)
+		}
+	}
+	if !missing {
+		res.Ok = PROFILE_QUERY_OK
+	}
+	return res, nil
+}
+
+func (psrv *ProfileSrv) UpdateProfile(
+		ctx context.Context, req *proto.UpdateProfileRequest) (*proto.ProfileResponse, error) {
+	t0 := time.Now()
+	defer psrv.uCounter.AddTimeSince(t0)
+	res := &proto.ProfileResponse{Ok: "No"}
+	fields := req.Fields
+	if len(fields) == 0 {
+		fields = []string{"displayname", "bio", "avatarmediaid"}
+	}
+	update := bson.M{}
+	for _, field := range fields {
+		switch field {
+		case "displayname":
+			if errStr := checkDisplayname(req.Displayname); errStr != "" {
+				res.Ok = errStr
+				return res, nil
+			}
+			update["displayname"] = req.Displayname
+		case "bio":
+			if utf8.RuneCountInString(req.Bio) > BIO_MAX_LENGTH {
+				res.Ok = fmt.Sprintf("Bio is longer than %v characters.", BIO_MAX_LENGTH)
+				return res, nil
+			}
+			update["bio"] = req.Bio
+		case "avatarmediaid":
+			// 0 clears the avatar
+			if req.Avatarmediaid != 0 {
+				mediaRes, err := psrv.mediac.CheckMedia(
+					ctx, &mediapb.CheckMediaRequest{Mediaids: []int64{req.Avatarmediaid}})
+				if err != nil {
+					return nil, err
+				}
+				if mediaRes.Ok != media.MEDIA_QUERY_OK {
+					res.Ok = "Avatar Error: " + mediaRes.Ok
+					return res, nil
+				}
+			}
+			update["avatarmediaid"] = req.Avatarmediaid
+		default:
+			res.Ok = fmt.Sprintf("Unknown profile field %v.", field)
+			return res, nil
+		}
+	}
+	updateRes, err := psrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"userid": req.Userid}, &bson.M{"$set": update})
+	if err != nil {
+		return nil, err
+	}
+	if updateRes.MatchedCount == 0 {
+		res.Ok = fmt.Sprintf("No profile for user %v.", req.Userid)
+		return res, nil
+	}
+	psrv.clearCache(ctx, req.Userid)
+	res.Ok = PROFILE_QUERY_OK
+	return res, nil
+}
+
+func checkDisplayname(displayname string) string {
+	if strings.TrimSpace(displayname) == "" {
+		return "Display name cannot be empty."
+	}
+	if utf8.RuneCountInString(displayname) > DISPLAYNAME_MAX_LENGTH {
+		return fmt.Sprintf("Display name is longer than %v characters.", DISPLAYNAME_MAX_LENGTH)
+	}
+	return ""
+}
+
+func (psrv *ProfileSrv) clearCache(ctx context.Context, userid int64) {
+	key := PROFILE_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+	if !psrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete profile of %v", key)
+	}
+}
+
+func (psrv *ProfileSrv) getProfile(ctx context.Context, userid int64) (*ProfileBson, error) {
+	key := PROFILE_CACHE_PREFIX + strconv.FormatInt(userid, 10)
+	profile := &ProfileBson{}
+	if profileItem, err := psrv.cachec.Get(ctx, key); err != nil {
+		if err != memcache.ErrCacheMiss {
+			return nil, err
+		}
+		log.Debug().Msgf("Profile %v cache miss", key)
+		err = psrv.mongoCo.FindOne(context.TODO(), &bson.M{"userid": userid}).Decode(profile)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, nil
+			}
+			return nil, err
+		}
+		log.Debug().Msgf("Found profile %v in DB: %v", userid, profile)
+		encodedProfile, err := json.Marshal(profile)
+		if err != nil {
+			log.Error().Msg(err.Error())
+			return nil, err
+		}
+		psrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedProfile})
+	} else {
+		log.Debug().Msgf("Found profile %v in cache!", userid)
+		json.Unmarshal(profileItem.Value, profile)
+	}
+	return profile, nil
+}
+
+func bsonToProfile(profile *ProfileBson) *proto.UserProfile {
+	return &proto.UserProfile{
+		Userid: profile.Userid,
+		Username: profile.Username,
+		Displayname: profile.Displayname,
+		Bio: profile.Bio,
+		Avatarmediaid: profile.Avatarmediaid,
+		Createdat: profile.Createdat,
+	}
+}
+
+type ProfileBson struct {
+	Userid        int64  `bson:"userid"`
+	Username      string `bson:"username"`
+	Displayname   string `bson:"displayname"`
+	Bio           string `bson:"bio"`
+	Avatarmediaid int64  `bson:"avatarmediaid"`
+	Createdat     int64  `bson:"createdat"`
+}
@@ -0,0 +1,901 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.20.0
+// 	protoc        v3.12.4
+// source: services/reaction/proto/reaction.proto
+
+package proto
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type REACTION_TYPE int32
+
+const (
+	REACTION_TYPE_ANY   REACTION_TYPE = 0
+	REACTION_TYPE_LIKE  REACTION_TYPE = 1
+	REACTION_TYPE_LOVE  REACTION_TYPE = 2
+	REACTION_TYPE_LAUGH REACTION_TYPE = 3
+	REACTION_TYPE_WOW   REACTION_TYPE = 4
+	REACTION_TYPE_SAD   REACTION_TYPE = 5
+	REACTION_TYPE_ANGRY REACTION_TYPE = 6
+)
+
+// Enum value maps for REACTION_TYPE.
+var (
+	REACTION_TYPE_name = map[int32]string{
+		0: "ANY",
+		1: "LIKE",
+		2: "LOVE",
+		3: "LAUGH",
+		4: "WOW",
+		5: "SAD",
+		6: "ANGRY",
+	}
+	REACTION_TYPE_value = map[string]int32{
+		"ANY":   0,
+		"LIKE":  1,
+		"LOVE":  2,
+		"LAUGH": 3,
+		"WOW":   4,
+		"SAD":   5,
+		"ANGRY": 6,
+	}
+)
+
+func (x REACTION_TYPE) Enum() *REACTION_TYPE {
+	p := new(REACTION_TYPE)
+	*p = x
+	return p
+}
+
+func (x REACTION_TYPE) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (REACTION_TYPE) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_reaction_proto_reaction_proto_enumTypes[0].Descriptor()
+}
+
+func (REACTION_TYPE) Type() protoreflect.EnumType {
+	return &file_services_reaction_proto_reaction_proto_enumTypes[0]
+}
+
+func (x REACTION_TYPE) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use REACTION_TYPE.Descriptor instead.
+func (REACTION_TYPE) EnumDescriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{0}
+}
+
+// React sets the reaction of a user to a post, replacing any earlier one.
+type ReactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid       int64         `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Postid       int64         `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+	Reactiontype REACTION_TYPE `protobuf:"varint,3,opt,name=reactiontype,proto3,enum=reaction.REACTION_TYPE" json:"reactiontype,omitempty"`
+	Postcreator  int64         `protobuf:"varint,4,opt,name=postcreator,proto3" json:"postcreator,omitempty"` // notified of new reactions when set
+	Username     string        `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *ReactRequest) Reset() {
+	*x = ReactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactRequest) ProtoMessage() {}
+
+func (x *ReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactRequest.ProtoReflect.Descriptor instead.
+func (*ReactRequest) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReactRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *ReactRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *ReactRequest) GetReactiontype() REACTION_TYPE {
+	if x != nil {
+		return x.Reactiontype
+	}
+	return REACTION_TYPE_ANY
+}
+
+func (x *ReactRequest) GetPostcreator() int64 {
+	if x != nil {
+		return x.Postcreator
+	}
+	return 0
+}
+
+func (x *ReactRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type UnreactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Postid int64 `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+}
+
+func (x *UnreactRequest) Reset() {
+	*x = UnreactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnreactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreactRequest) ProtoMessage() {}
+
+func (x *UnreactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreactRequest.ProtoReflect.Descriptor instead.
+func (*UnreactRequest) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UnreactRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *UnreactRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+type ReactResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok string `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+}
+
+func (x *ReactResponse) Reset() {
+	*x = ReactResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactResponse) ProtoMessage() {}
+
+func (x *ReactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactResponse.ProtoReflect.Descriptor instead.
+func (*ReactResponse) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReactResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+type GetReactionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postids []int64 `protobuf:"varint,1,rep,packed,name=postids,proto3" json:"postids,omitempty"`
+}
+
+func (x *GetReactionsRequest) Reset() {
+	*x = GetReactionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReactionsRequest) ProtoMessage() {}
+
+func (x *GetReactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReactionsRequest.ProtoReflect.Descriptor instead.
+func (*GetReactionsRequest) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetReactionsRequest) GetPostids() []int64 {
+	if x != nil {
+		return x.Postids
+	}
+	return nil
+}
+
+type GetReactionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok        string           `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Reactions []*PostReactions `protobuf:"bytes,2,rep,name=reactions,proto3" json:"reactions,omitempty"`
+}
+
+func (x *GetReactionsResponse) Reset() {
+	*x = GetReactionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReactionsResponse) ProtoMessage() {}
+
+func (x *GetReactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReactionsResponse.ProtoReflect.Descriptor instead.
+func (*GetReactionsResponse) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetReactionsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *GetReactionsResponse) GetReactions() []*PostReactions {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+// ListReactors lists who reacted to a post, most recent first. ANY lists
+// reactions of every type.
+type ListReactorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postid       int64         `protobuf:"varint,1,opt,name=postid,proto3" json:"postid,omitempty"`
+	Reactiontype REACTION_TYPE `protobuf:"varint,2,opt,name=reactiontype,proto3,enum=reaction.REACTION_TYPE" json:"reactiontype,omitempty"`
+	Start        int32         `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	Stop         int32         `protobuf:"varint,4,opt,name=stop,proto3" json:"stop,omitempty"`
+}
+
+func (x *ListReactorsRequest) Reset() {
+	*x = ListReactorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReactorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactorsRequest) ProtoMessage() {}
+
+func (x *ListReactorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactorsRequest.ProtoReflect.Descriptor instead.
+func (*ListReactorsRequest) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListReactorsRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *ListReactorsRequest) GetReactiontype() REACTION_TYPE {
+	if x != nil {
+		return x.Reactiontype
+	}
+	return REACTION_TYPE_ANY
+}
+
+func (x *ListReactorsRequest) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ListReactorsRequest) GetStop() int32 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+type ListReactorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok            string          `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Userids       []int64         `protobuf:"varint,2,rep,packed,name=userids,proto3" json:"userids,omitempty"`
+	Reactiontypes []REACTION_TYPE `protobuf:"varint,3,rep,packed,name=reactiontypes,proto3,enum=reaction.REACTION_TYPE" json:"reactiontypes,omitempty"`
+}
+
+func (x *ListReactorsResponse) Reset() {
+	*x = ListReactorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReactorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactorsResponse) ProtoMessage() {}
+
+func (x *ListReactorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactorsResponse.ProtoReflect.Descriptor instead.
+func (*ListReactorsResponse) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListReactorsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *ListReactorsResponse) GetUserids() []int64 {
+	if x != nil {
+		return x.Userids
+	}
+	return nil
+}
+
+func (x *ListReactorsResponse) GetReactiontypes() []REACTION_TYPE {
+	if x != nil {
+		return x.Reactiontypes
+	}
+	return nil
+}
+
+type PostReactions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Postid int64            `protobuf:"varint,1,opt,name=postid,proto3" json:"postid,omitempty"`
+	Total  int64            `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Counts []*ReactionCount `protobuf:"bytes,3,rep,name=counts,proto3" json:"counts,omitempty"`
+}
+
+func (x *PostReactions) Reset() {
+	*x = PostReactions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostReactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostReactions) ProtoMessage() {}
+
+func (x *PostReactions) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostReactions.ProtoReflect.Descriptor instead.
+func (*PostReactions) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PostReactions) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
+func (x *PostReactions) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PostReactions) GetCounts() []*ReactionCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type ReactionCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reactiontype REACTION_TYPE `protobuf:"varint,1,opt,name=reactiontype,proto3,enum=reaction.REACTION_TYPE" json:"reactiontype,omitempty"`
+	Count        int64         `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ReactionCount) Reset() {
+	*x = ReactionCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_reaction_proto_reaction_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReactionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionCount) ProtoMessage() {}
+
+func (x *ReactionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_services_reaction_proto_reaction_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionCount.ProtoReflect.Descriptor instead.
+func (*ReactionCount) Descriptor() ([]byte, []int) {
+	return file_services_reaction_proto_reaction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReactionCount) GetReactiontype() REACTION_TYPE {
+	if x != nil {
+		return x.Reactiontype
+	}
+	return REACTION_TYPE_ANY
+}
+
+func (x *ReactionCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_services_reaction_proto_reaction_proto protoreflect.FileDescriptor
+
+var file_services_reaction_proto_reaction_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x72, 0x65, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0xb9, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73,
+	0x74, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x45, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x40,
+	0x0a, 0x0e, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64,
+	0x22, 0x1f, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x6b, 0x22, 0x2f, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x69,
+	0x64, 0x73, 0x22, 0x5d, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x35, 0x0a, 0x09, 0x72, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x94, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73,
+	0x74, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69,
+	0x64, 0x12, 0x3b, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x52, 0x45, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x52, 0x0c, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x22, 0x7f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b,
+	0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x45, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x52, 0x0d, 0x72, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x0d, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f,
+	0x73, 0x74, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0d, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x72, 0x65,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x17, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x45, 0x41, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0x54, 0x0a,
+	0x0d, 0x52, 0x45, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x12, 0x07,
+	0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49, 0x4b, 0x45, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x56, 0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4c,
+	0x41, 0x55, 0x47, 0x48, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x57, 0x4f, 0x57, 0x10, 0x04, 0x12,
+	0x07, 0x0a, 0x03, 0x53, 0x41, 0x44, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4e, 0x47, 0x52,
+	0x59, 0x10, 0x06, 0x32, 0xa0, 0x02, 0x0a, 0x08, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x38, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x63, 0x74, 0x12, 0x16, 0x2e, 0x72, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x55, 0x6e,
+	0x72, 0x65, 0x61, 0x63, 0x74, 0x12, 0x18, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1b, 0x5a, 0x19, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2f, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_services_reaction_proto_reaction_proto_rawDescOnce sync.Once
+	file_services_reaction_proto_reaction_proto_rawDescData = file_services_reaction_proto_reaction_proto_rawDesc
+)
+
+func file_services_reaction_proto_reaction_proto_rawDescGZIP() []byte {
+	file_services_reaction_proto_reaction_proto_rawDescOnce.Do(func() {
+		file_services_reaction_proto_reaction_proto_rawDescData = protoimpl.X.CompressGZIP(file_services_reaction_proto_reaction_proto_rawDescData)
+	})
+	return file_services_reaction_proto_reaction_proto_rawDescData
+}
+
+var file_services_reaction_proto_reaction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_services_reaction_proto_reaction_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_services_reaction_proto_reaction_proto_goTypes = []interface{}{
+	(REACTION_TYPE)(0),           // 0: reaction.REACTION_TYPE
+	(*ReactRequest)(nil),         // 1: reaction.ReactRequest
+	(*UnreactRequest)(nil),       // 2: reaction.UnreactRequest
+	(*ReactResponse)(nil),        // 3: reaction.ReactResponse
+	(*GetReactionsRequest)(nil),  // 4: reaction.GetReactionsRequest
+	(*GetReactionsResponse)(nil), // 5: reaction.GetReactionsResponse
+	(*ListReactorsRequest)(nil),  // 6: reaction.ListReactorsRequest
+	(*ListReactorsResponse)(nil), // 7: reaction.ListReactorsResponse
+	(*PostReactions)(nil),        // 8: reaction.PostReactions
+	(*ReactionCount)(nil),        // 9: reaction.ReactionCount
+}
+var file_services_reaction_proto_reaction_proto_depIdxs = []int32{
+	0,  // 0: reaction.ReactRequest.reactiontype:type_name -> reaction.REACTION_TYPE
+	8,  // 1: reaction.GetReactionsResponse.reactions:type_name -> reaction.PostReactions
+	0,  // 2: reaction.ListReactorsRequest.reactiontype:type_name -> reaction.REACTION_TYPE
+	0,  // 3: reaction.ListReactorsResponse.reactiontypes:type_name -> reaction.REACTION_TYPE
+	9,  // 4: reaction.PostReactions.counts:type_name -> reaction.ReactionCount
+	0,  // 5: reaction.ReactionCount.reactiontype:type_name -> reaction.REACTION_TYPE
+	1,  // 6: reaction.Reaction.React:input_type -> reaction.ReactRequest
+	2,  // 7: reaction.Reaction.Unreact:input_type -> reaction.UnreactRequest
+	4,  // 8: reaction.Reaction.GetReactions:input_type -> reaction.GetReactionsRequest
+	6,  // 9: reaction.Reaction.ListReactors:input_type -> reaction.ListReactorsRequest
+	3,  // 10: reaction.Reaction.React:output_type -> reaction.ReactResponse
+	3,  // 11: reaction.Reaction.Unreact:output_type -> reaction.ReactResponse
+	5,  // 12: reaction.Reaction.GetReactions:output_type -> reaction.GetReactionsResponse
+	7,  // 13: reaction.Reaction.ListReactors:output_type -> reaction.ListReactorsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_services_reaction_proto_reaction_proto_init() }
+func file_services_reaction_proto_reaction_proto_init() {
+	if File_services_reaction_proto_reaction_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_services_reaction_proto_reaction_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnreactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReactionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReactionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReactorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReactorsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostReactions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_reaction_proto_reaction_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReactionCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_services_reaction_proto_reaction_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_services_reaction_proto_reaction_proto_goTypes,
+		DependencyIndexes: file_services_reaction_proto_reaction_proto_depIdxs,
+		EnumInfos:         file_services_reaction_proto_reaction_proto_enumTypes,
+		MessageInfos:      file_services_reaction_proto_reaction_proto_msgTypes,
+	}.Build()
+	File_services_reaction_proto_reaction_proto = out.File
+	file_services_reaction_proto_reaction_proto_rawDesc = nil
+	file_services_reaction_proto_reaction_proto_goTypes = nil
+	file_services_reaction_proto_reaction_proto_depIdxs = nil
+}
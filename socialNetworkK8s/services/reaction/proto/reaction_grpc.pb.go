@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: services/reaction/proto/reaction.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Reaction_React_FullMethodName        = "/reaction.Reaction/React"
+	Reaction_Unreact_FullMethodName      = "/reaction.Reaction/Unreact"
+	Reaction_GetReactions_FullMethodName = "/reaction.Reaction/GetReactions"
+	Reaction_ListReactors_FullMethodName = "/reaction.Reaction/ListReactors"
+)
+
+// ReactionClient is the client API for Reaction service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReactionClient interface {
+	React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error)
+	Unreact(ctx context.Context, in *UnreactRequest, opts ...grpc.CallOption) (*ReactResponse, error)
+	GetReactions(ctx context.Context, in *GetReactionsRequest, opts ...grpc.CallOption) (*GetReactionsResponse, error)
+	ListReactors(ctx context.Context, in *ListReactorsRequest, opts ...grpc.CallOption) (*ListReactorsResponse, error)
+}
+
+type reactionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReactionClient(cc grpc.ClientConnInterface) ReactionClient {
+	return &reactionClient{cc}
+}
+
+func (c *reactionClient) React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error) {
+	out := new(ReactResponse)
+	err := c.cc.Invoke(ctx, Reaction_React_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionClient) Unreact(ctx context.Context, in *UnreactRequest, opts ...grpc.CallOption) (*ReactResponse, error) {
+	out := new(ReactResponse)
+	err := c.cc.Invoke(ctx, Reaction_Unreact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionClient) GetReactions(ctx context.Context, in *GetReactionsRequest, opts ...grpc.CallOption) (*GetReactionsResponse, error) {
+	out := new(GetReactionsResponse)
+	err := c.cc.Invoke(ctx, Reaction_GetReactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionClient) ListReactors(ctx context.Context, in *ListReactorsRequest, opts ...grpc.CallOption) (*ListReactorsResponse, error) {
+	out := new(ListReactorsResponse)
+	err := c.cc.Invoke(ctx, Reaction_ListReactors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReactionServer is the server API for Reaction service.
+// All implementations must embed UnimplementedReactionServer
+// for forward compatibility
+type ReactionServer interface {
+	React(context.Context, *ReactRequest) (*ReactResponse, error)
+	Unreact(context.Context, *UnreactRequest) (*ReactResponse, error)
+	GetReactions(context.Context, *GetReactionsRequest) (*GetReactionsResponse, error)
+	ListReactors(context.Context, *ListReactorsRequest) (*ListReactorsResponse, error)
+	mustEmbedUnimplementedReactionServer()
+}
+
+// UnimplementedReactionServer must be embedded to have forward compatible implementations.
+type UnimplementedReactionServer struct {
+}
+
+func (UnimplementedReactionServer) React(context.Context, *ReactRequest) (*ReactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method React not implemented")
+}
+func (UnimplementedReactionServer) Unreact(context.Context, *UnreactRequest) (*ReactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unreact not implemented")
+}
+func (UnimplementedReactionServer) GetReactions(context.Context, *GetReactionsRequest) (*GetReactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReactions not implemented")
+}
+func (UnimplementedReactionServer) ListReactors(context.Context, *ListReactorsRequest) (*ListReactorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReactors not implemented")
+}
+func (UnimplementedReactionServer) mustEmbedUnimplementedReactionServer() {}
+
+// UnsafeReactionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReactionServer will
+// result in compilation errors.
+type UnsafeReactionServer interface {
+	mustEmbedUnimplementedReactionServer()
+}
+
+func RegisterReactionServer(s grpc.ServiceRegistrar, srv ReactionServer) {
+	s.RegisterService(&Reaction_ServiceDesc, srv)
+}
+
+func _Reaction_React_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServer).React(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reaction_React_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServer).React(ctx, req.(*ReactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reaction_Unreact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnreactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServer).Unreact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reaction_Unreact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServer).Unreact(ctx, req.(*UnreactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reaction_GetReactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServer).GetReactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reaction_GetReactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServer).GetReactions(ctx, req.(*GetReactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reaction_ListReactors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReactorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServer).ListReactors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reaction_ListReactors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServer).ListReactors(ctx, req.(*ListReactorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Reaction_ServiceDesc is the grpc.ServiceDesc for Reaction service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Reaction_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reaction.Reaction",
+	HandlerType: (*ReactionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "React",
+			Handler:    _Reaction_React_Handler,
+		},
+		{
+			MethodName: "Unreact",
+			Handler:    _Reaction_Unreact_Handler,
+		},
+		{
+			MethodName: "GetReactions",
+			Handler:    _Reaction_GetReactions_Handler,
+		},
+		{
+			MethodName: "ListReactors",
+			Handler:    _Reaction_ListReactors_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services/reaction/proto/reaction.proto",
+}
@@ -0,0 +1,402 @@
+package reaction
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/dialer"
+	"socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/reaction/proto"
+	"socialnetworkk8/services/notification"
+	notifpb "socialnetworkk8/services/notification/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+const (
+	REACTION_SRV_NAME = "srv-reaction"
+	REACTION_QUERY_OK = "OK"
+	REACTION_CACHE_PREFIX = "reaction_"
+)
+
+// ReactionSrv stores at most one reaction per user and post, and keeps
+// per-post counters next to them so reads never have to scan reactions.
+type ReactionSrv struct {
+	proto.UnimplementedReactionServer
+	uuid         string
+	cachec       *cacheclnt.CacheClnt
+	mongoCo      *mongo.Collection
+	mongoCountCo *mongo.Collection
+	notifc       notifpb.NotificationClient
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	rCounter     *tracing.Counter
+	uCounter     *tracing.Counter
+	gCounter     *tracing.Counter
+	lCounter     *tracing.Counter
+}
+
+func MakeReactionSrv() *ReactionSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["ReactionPort"])
+	serv_ip := result["ReactionIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "reaction", *jaegeraddr)
+	tracer, err := tracing.Init("reaction", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start cache and DB connections")
+	cachec := cacheclnt.MakeCacheClnt()
+
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	collection := mongoClient.Database("socialnetwork").Collection("reaction")
+	countCollection := mongoClient.Database("socialnetwork").Collection("reaction-count")
+	// one reaction per user and post, even when reactions race
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "postid", Value: 1}, {Key: "userid", Value: 1}},
+		Options: options.Index().SetUnique(true)}
+	name1, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
+	if err != nil {
+		log.Panic().Msgf("cannot create reaction index: %v", err)
+	}
+	log.Info().Msgf("Name of index created for reactions: %v", name1)
+	countIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "postid", Value: 1}}}
+	name2, _ := countCollection.Indexes().CreateOne(context.TODO(), countIndexModel)
+	log.Info().Msgf("Name of index created for reaction counts: %v", name2)
+	log.Info().Msg("New mongo session successfull...")
+
+	return &ReactionSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		cachec:       cachec,
+		mongoCo:      collection,
+		mongoCountCo: countCollection,
+		rCounter:     tracing.MakeCounter("React"),
+		uCounter:     tracing.MakeCounter("Unreact"),
+		gCounter:     tracing.MakeCounter("Get-Reactions"),
+		lCounter:     tracing.MakeCounter("List-Reactors"),
+	}
+}
+
+// Run starts the server
+func (rsrv *ReactionSrv) Run() error {
+	if rsrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC clients...")
+	conn, err := dialer.Dial(
+		notification.NOTIFICATION_SRV_NAME,
+		rsrv.Registry.Client,
+		dialer.WithTracer(rsrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	rsrv.notifc = notifpb.NewNotificationClient(conn)
+
+	log.Info().Msg("Initializing gRPC Server...")
+	rsrv.uuid = uuid.New().String()
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(rsrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterReactionServer(grpcSrv, rsrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", rsrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = rsrv.Registry.Register(REACTION_SRV_NAME, rsrv.uuid, rsrv.IpAddr, rsrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+func (rsrv *ReactionSrv) React(
+		ctx context.Context, req *proto.ReactRequest) (*proto.ReactResponse, error) {
+	t0 := time.Now()
+	defer rsrv.rCounter.AddTimeSince(t0)
+	res := &proto.ReactResponse{Ok: "No."}
+	if _, ok := proto.REACTION_TYPE_name[int32(req.Reactiontype)];
+			!ok || req.Reactiontype == proto.REACTION_TYPE_ANY {
+		res.Ok = fmt.Sprintf("Invalid reaction type %v.", req.Reactiontype)
+		return res, nil
+	}
+	prev := &ReactionBson{}
+	err := rsrv.mongoCo.FindOneAndUpdate(
+		context.TODO(), &bson.M{"postid": req.Postid, "userid": req.Userid},
+		&bson.M{"$set": bson.M{
+			"reactiontype": int32(req.Reactiontype), "timestamp": time.Now().UnixNano()}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)).Decode(prev)
+	if mongo.IsDuplicateKeyError(err) {
+		// a racing request of the same user inserted the reaction first
+		res.Ok = REACTION_QUERY_OK
+		return res, nil
+	}
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		prev = nil
+	}
+	if prev != nil && prev.Reactiontype == int32(req.Reactiontype) {
+		res.Ok = REACTION_QUERY_OK
+		return res, nil
+	}
+	// a changed reaction moves one count from the old type to the new one
+	inc := bson.M{countField(int32(req.Reactiontype)): 1}
+	if prev != nil {
+		inc[countField(prev.Reactiontype)] = -1
+	}
+	if err := rsrv.updateCounts(ctx, req.Postid, inc); err != nil {
+		return nil, err
+	}
+	// changing the type of an existing reaction is not news to the creator
+	if prev == nil && req.Postcreator != 0 {
+		rsrv.notifyReaction(ctx, req)
+	}
+	res.Ok = REACTION_QUERY_OK
+	return res, nil
+}
+
+// notifyReaction tells the creator of a post about a new reaction. It is best
+// effort and never fails the reaction.
+func (rsrv *ReactionSrv) notifyReaction(ctx context.Context, req *proto.ReactRequest) {
+	notifReq := &notifpb.NotifyRequest{Notifications: []*notifpb.NotificationItem{{
+		Userid: req.Postcreator,
+		Notificationtype: notifpb.NOTIFICATION_TYPE_LIKE,
+		Actorid: req.Userid,
+		Actoruname: req.Username,
+		Postid: req.Postid,
+		Timestamp: time.Now().UnixNano()}}}
+	notifRes, err := rsrv.notifc.Notify(ctx, notifReq)
+	if err != nil {
+		log.Error().Msgf("Error notifying about reaction to %v: %v", req.Postid, err)
+	} else if notifRes.Ok != notification.NOTIFICATION_QUERY_OK {
+		log.Error().Msgf("Cannot notify about reaction to %v: %v", req.Postid, notifRes.Ok)
+	}
+}
+
+func (rsrv *ReactionSrv) Unreact(
+		ctx context.Context, req *proto.UnreactRequest) (*proto.ReactResponse, error) {
+	t0 := time.Now()
+	defer rsrv.uCounter.AddTimeSince(t0)
+	res := &proto.ReactResponse{Ok: "No."}
+	prev := &ReactionBson{}
+	err := rsrv.mongoCo.FindOneAndDelete(
+		context.TODO(), &bson.M{"postid": req.Postid, "userid": req.Userid}).Decode(prev)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			res.Ok = fmt.Sprintf("User %v has not reacted to post %v.", req.Userid, req.Postid)
+			return res, nil
+		}
+		return nil, err
+	}
+	if err := rsrv.updateCounts(ctx, req.Postid, bson.M{countField(prev.Reactiontype): -1}); err != nil {
+		return nil, err
+	}
+	res.Ok = REACTION_QUERY_OK
+	return res, nil
+}
+
+func (rsrv *ReactionSrv) GetReactions(
+		ctx context.Context, req *proto.GetReactionsRequest) (*proto.GetReactionsResponse, error) {
+	t0 := time.Now()
+	defer rsrv.gCounter.AddTimeSince(t0)
+	res := &proto.GetReactionsResponse{Ok: "No."}
+	reactions := make([]*proto.PostReactions, len(req.Postids))
+	for idx, postid := range req.Postids {
+		counts, err := rsrv.getCounts(ctx, postid)
+		if err != nil {
+			return nil, err
+		}
+		reactions[idx] = countsToProto(counts)
+	}
+	res.Reactions = reactions
+	res.Ok = REACTION_QUERY_OK
+	return res, nil
+}
+
+func (rsrv *ReactionSrv) ListReactors(
+		ctx context.Context, req *proto.ListReactorsRequest) (*proto.ListReactorsResponse, error) {
+	t0 := time.Now()
+	defer rsrv.lCounter.AddTimeSince(t0)
+	res := &proto.ListReactorsResponse{Ok: "No."}
+	if req.Start < 0 || req.Start >= req.Stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v", req.Start, req.Stop)
+		return res, nil
+	}
+	filter := bson.M{"postid": req.Postid}
+	if req.Reactiontype != proto.REACTION_TYPE_ANY {
+		filter["reactiontype"] = int32(req.Reactiontype)
+	}
+	cursor, err := rsrv.mongoCo.Find(
+		context.TODO(), filter,
+		options.Find().
+			SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+			SetSkip(int64(req.Start)).
+			SetLimit(int64(req.Stop-req.Start)))
+	if err != nil {
+		return nil, err
+	}
+	var reactions []*ReactionBson
+	if err = cursor.All(context.TODO(), &reactions); err != nil {
+		return nil, err
+	}
+	res.Userids = make([]int64, len(reactions))
+	res.Reactiontypes = make([]proto.REACTION_TYPE, len(reactions))
+	for idx, reaction := range reactions {
+		res.Userids[idx] = reaction.Userid
+		res.Reactiontypes[idx] = proto.REACTION_TYPE(reaction.Reactiontype)
+	}
+	res.Ok = REACTION_QUERY_OK
+	return res, nil
+}
+
+func (rsrv *ReactionSrv) updateCounts(ctx context.Context, postid int64, inc bson.M) error {
+	_, err := rsrv.mongoCountCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": postid}, &bson.M{"$inc": inc},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	key := REACTION_CACHE_PREFIX + strconv.FormatInt(postid, 10)
+	if !rsrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete reaction counts of %v", key)
+	}
+	return nil
+}
+
+func (rsrv *ReactionSrv) getCounts(ctx context.Context, postid int64) (*ReactionCountBson, error) {
+	key := REACTION_CACHE_PREFIX + strconv.FormatInt(postid, 10)
+	counts := &ReactionCountBson{Postid: postid, Counts: make(map[string]int64)}
+	if countItem, err := rsrv.cachec.Get(ctx, key); err != nil {
+		if err != memcache.ErrCacheMiss {
+			return nil, err
+		}
+		log.Debug().Msgf("Reaction counts %v cache miss", key)
+		err = rsrv.mongoCountCo.FindOne(context.TODO(), &bson.M{"postid": postid}).Decode(counts)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		// posts nobody reacted to are cached too, as empty counts
+		encodedCounts, err := json.Marshal(counts)
+		if err != nil {
+			log.Error().Msg(err.Error())
+			return nil, err
+		}
+		rsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: encodedCounts})
+	} else {
+		log.Debug().Msgf("Found reaction counts of %v in cache!", postid)
+		json.Unmarshal(countItem.Value, counts)
+	}
+	return counts, nil
+}
+
+func countField(reactiontype int32) string {
+	return "counts." + proto.REACTION_TYPE_name[reactiontype]
+}
+
+// countsToProto lists the non-zero counts in reaction type order.
+func countsToProto(counts *ReactionCountBson) *proto.PostReactions {
+	reactions := &proto.PostReactions{Postid: counts.Postid}
+	for t := int32(proto.REACTION_TYPE_LIKE); t <= int32(proto.REACTION_TYPE_ANGRY); t++ {
+		if count := counts.Counts[proto.REACTION_TYPE_name[t]]; count > 0 {
+			reactions.Total += count
+			reactions.Counts = append(reactions.Counts,
+				&proto.ReactionCount{Reactiontype: proto.REACTION_TYPE(t), Count: count})
+		}
+	}
+	return reactions
+}
+
+type ReactionBson struct {
+	Postid int64       `bson:"postid"`
+	Userid int64       `bson:"userid"`
+	Reactiontype int32 `bson:"reactiontype"`
+	Timestamp int64    `bson:"timestamp"`
+}
+
+type ReactionCountBson struct {
+	Postid int64            `bson:"postid"`
+	Counts map[string]int64 `bson:"counts"`
+}
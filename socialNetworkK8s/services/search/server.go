@@ -0,0 +1,405 @@
+package search
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"socialnetworkk8/registry"
+	"socialnetworkk8/tune"
+	"socialnetworkk8/tls"
+	"socialnetworkk8/services/search/proto"
+	opentracing "github.com/opentracing/opentracing-go"
+	"socialnetworkk8/tracing"
+	"github.com/rs/zerolog/log"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	SEARCH_SRV_NAME = "srv-search"
+	SEARCH_QUERY_OK = "OK"
+	// postings read per query term, newest posts first; older matches are
+	// left out of the results
+	SEARCH_MAX_POSTINGS = 10000
+	// postids returned per page
+	SEARCH_MAX_PAGE = 100
+)
+
+// SearchSrv keeps an inverted index of post text in Mongo: one posting per
+// term and post, holding the positions of the term in the post, plus one
+// document per post with what the filters and the ranking need.
+type SearchSrv struct {
+	proto.UnimplementedSearchServer
+	uuid         string
+	mongoTermCo  *mongo.Collection
+	mongoDocCo   *mongo.Collection
+	Registry     *registry.Client
+	Tracer       opentracing.Tracer
+	Port         int
+	IpAddr       string
+	iCounter     *tracing.Counter
+	dCounter     *tracing.Counter
+	sCounter     *tracing.Counter
+}
+
+func MakeSearchSrv() *SearchSrv {
+	tune.Init()
+	log.Info().Msg("Reading config...")
+	jsonFile, err := os.Open("config.json")
+	if err != nil {
+		log.Error().Msgf("Got error while reading config: %v", err)
+	}
+	defer jsonFile.Close()
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	var result map[string]string
+	json.Unmarshal([]byte(byteValue), &result)
+	log.Info().Msg("Successfull")
+
+	serv_port, _ := strconv.Atoi(result["SearchPort"])
+	serv_ip := result["SearchIP"]
+	log.Info().Msgf("Read target port: %v", serv_port)
+	log.Info().Msgf("Read consul address: %v", result["consulAddress"])
+	log.Info().Msgf("Read jaeger address: %v", result["jaegerAddress"])
+	var (
+		jaegeraddr = flag.String("jaegeraddr", result["jaegerAddress"], "Jaeger address")
+		consuladdr = flag.String("consuladdr", result["consulAddress"], "Consul address")
+	)
+	flag.Parse()
+
+	log.Info().Msgf("Initializing jaeger [service name: %v | host: %v]...", "search", *jaegeraddr)
+	tracer, err := tracing.Init("search", *jaegeraddr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing jaeger agent: %v", err)
+	}
+	log.Info().Msg("Jaeger agent initialized")
+
+	log.Info().Msgf("Initializing consul agent [host: %v]...", *consuladdr)
+	registry, err := registry.NewClient(*consuladdr)
+	if err != nil {
+		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
+	}
+	log.Info().Msg("Consul agent initialized")
+	log.Info().Msg("Start DB connections")
+
+	mongoUrl := "mongodb://" + result["MongoAddress"]
+	log.Info().Msgf("Read database URL: %v", mongoUrl)
+	mongoClient, err := mongo.Connect(
+		context.Background(), options.Client().ApplyURI(mongoUrl).SetMaxPoolSize(2048))
+	if err != nil {
+		log.Panic().Msg(err.Error())
+	}
+	termCo := mongoClient.Database("socialnetwork").Collection("search-term")
+	docCo := mongoClient.Database("socialnetwork").Collection("search-doc")
+	termIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "term", Value: 1}, {Key: "postid", Value: -1}}}
+	name1, _ := termCo.Indexes().CreateOne(context.TODO(), termIndexModel)
+	log.Info().Msgf("Name of index created for terms: %v", name1)
+	termPostIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "postid", Value: 1}}}
+	name2, _ := termCo.Indexes().CreateOne(context.TODO(), termPostIndexModel)
+	log.Info().Msgf("Name of index created for term posts: %v", name2)
+	docIndexModel := mongo.IndexModel{Keys: bson.D{{Key: "postid", Value: 1}}}
+	name3, _ := docCo.Indexes().CreateOne(context.TODO(), docIndexModel)
+	log.Info().Msgf("Name of index created for docs: %v", name3)
+	log.Info().Msg("New mongo session successfull...")
+
+	return &SearchSrv{
+		Port:         serv_port,
+		IpAddr:       serv_ip,
+		Tracer:       tracer,
+		Registry:     registry,
+		mongoTermCo:  termCo,
+		mongoDocCo:   docCo,
+		iCounter:     tracing.MakeCounter("Index-Post"),
+		dCounter:     tracing.MakeCounter("Remove-Post-Index"),
+		sCounter:     tracing.MakeCounter("Search-Posts"),
+	}
+}
+
+// Run starts the server
+func (ssrv *SearchSrv) Run() error {
+	if ssrv.Port == 0 {
+		return fmt.Errorf("server port must be set")
+	}
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	log.Info().Msg("Initializing gRPC Server...")
+	ssrv.uuid = uuid.New().String()
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Timeout: 120 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			PermitWithoutStream: true,
+		}),
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(ssrv.Tracer),
+		),
+	}
+	if tlsopt := tls.GetServerOpt(); tlsopt != nil {
+		opts = append(opts, tlsopt)
+	}
+	grpcSrv := grpc.NewServer(opts...)
+	proto.RegisterSearchServer(grpcSrv, ssrv)
+
+	// listener
+	log.Info().Msg("Initializing request listener ...")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", ssrv.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	http.Handle("/pprof/cpu", http.HandlerFunc(pprof.Profile))
+	go func() {
+		log.Error().Msgf("Error ListenAndServe: %v", http.ListenAndServe(":5000", nil))
+	}()
+	err = ssrv.Registry.Register(SEARCH_SRV_NAME, ssrv.uuid, ssrv.IpAddr, ssrv.Port)
+	if err != nil {
+		return fmt.Errorf("failed register: %v", err)
+	}
+	log.Info().Msg("Successfully registered in consul")
+	return grpcSrv.Serve(lis)
+}
+
+func (ssrv *SearchSrv) IndexPost(
+		ctx context.Context, req *proto.IndexPostRequest) (*proto.IndexPostResponse, error) {
+	t0 := time.Now()
+	defer ssrv.iCounter.AddTimeSince(t0)
+	res := &proto.IndexPostResponse{Ok: "No"}
+	if err := ssrv.removePostings(req.Postid); err != nil {
+		return nil, err
+	}
+	tokens := Tokenize(req.Text)
+	positions := make(map[string][]int32)
+	terms := make([]string, 0)
+	for _, token := range tokens {
+		if _, ok := positions[token.Term]; !ok {
+			terms = append(terms, token.Term)
+		}
+		positions[token.Term] = append(positions[token.Term], int32(token.Position))
+	}
+	if len(terms) > 0 {
+		postings := make([]interface{}, len(terms))
+		for idx, term := range terms {
+			postings[idx] = &PostingBson{Term: term, Postid: req.Postid, Positions: positions[term]}
+		}
+		if _, err := ssrv.mongoTermCo.InsertMany(context.TODO(), postings); err != nil {
+			return nil, err
+		}
+	}
+	_, err := ssrv.mongoDocCo.UpdateOne(
+		context.TODO(), &bson.M{"postid": req.Postid},
+		&bson.M{"$set": bson.M{
+			"creator": req.Creator, "timestamp": req.Timestamp, "length": int32(len(tokens))}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	res.Ok = SEARCH_QUERY_OK
+	return res, nil
+}
+
+func (ssrv *SearchSrv) RemovePost(
+		ctx context.Context, req *proto.RemovePostRequest) (*proto.IndexPostResponse, error) {
+	t0 := time.Now()
+	defer ssrv.dCounter.AddTimeSince(t0)
+	res := &proto.IndexPostResponse{Ok: "No"}
+	if err := ssrv.removePostings(req.Postid); err != nil {
+		return nil, err
+	}
+	if _, err := ssrv.mongoDocCo.DeleteOne(context.TODO(), &bson.M{"postid": req.Postid}); err != nil {
+		return nil, err
+	}
+	res.Ok = SEARCH_QUERY_OK
+	return res, nil
+}
+
+func (ssrv *SearchSrv) SearchPosts(
+		ctx context.Context, req *proto.SearchPostsRequest) (*proto.SearchPostsResponse, error) {
+	t0 := time.Now()
+	defer ssrv.sCounter.AddTimeSince(t0)
+	res := &proto.SearchPostsResponse{Ok: "No."}
+	if req.Start < 0 || req.Start >= req.Stop {
+		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v", req.Start, req.Stop)
+		return res, nil
+	}
+	keywords, phrases := parseQuery(req.Query)
+	if len(keywords) == 0 {
+		res.Ok = "Query has no searchable words."
+		return res, nil
+	}
+
+	// load the newest postings of every query term, grouped by post
+	var postings []*PostingBson
+	for _, keyword := range keywords {
+		cursor, err := ssrv.mongoTermCo.Find(
+			context.TODO(), &bson.M{"term": keyword},
+			options.Find().SetSort(bson.D{{Key: "postid", Value: -1}}).SetLimit(SEARCH_MAX_POSTINGS))
+		if err != nil {
+			return nil, err
+		}
+		var termPostings []*PostingBson
+		if err = cursor.All(context.TODO(), &termPostings); err != nil {
+			return nil, err
+		}
+		postings = append(postings, termPostings...)
+	}
+	df := make(map[string]int)
+	postPositions := make(map[int64]map[string][]int32)
+	for _, posting := range postings {
+		df[posting.Term]++
+		if postPositions[posting.Postid] == nil {
+			postPositions[posting.Postid] = make(map[string][]int32)
+		}
+		postPositions[posting.Postid][posting.Term] = posting.Positions
+	}
+	candidates := make([]int64, 0, len(postPositions))
+	for postid, positions := range postPositions {
+		if matchesPhrases(positions, phrases) {
+			candidates = append(candidates, postid)
+		}
+	}
+
+	// apply the filters and rank what is left
+	filter := bson.M{"postid": bson.M{"$in": candidates}}
+	if len(req.Creators) > 0 {
+		filter["creator"] = bson.M{"$in": req.Creators}
+	}
+	timeFilter := bson.M{}
+	if req.Since != 0 {
+		timeFilter["$gte"] = req.Since
+	}
+	if req.Until != 0 {
+		timeFilter["$lte"] = req.Until
+	}
+	if len(timeFilter) > 0 {
+		filter["timestamp"] = timeFilter
+	}
+	cursor, err := ssrv.mongoDocCo.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	var docs []*DocBson
+	if err = cursor.All(context.TODO(), &docs); err != nil {
+		return nil, err
+	}
+	nDocs, err := ssrv.mongoDocCo.EstimatedDocumentCount(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[int64]float64, len(docs))
+	for _, doc := range docs {
+		scores[doc.Postid] = score(postPositions[doc.Postid], df, nDocs, doc.Length)
+	}
+	// best match first, newest first among equals
+	sort.Slice(docs, func(i, j int) bool {
+		si, sj := scores[docs[i].Postid], scores[docs[j].Postid]
+		if si != sj {
+			return si > sj
+		}
+		return docs[i].Timestamp > docs[j].Timestamp
+	})
+
+	start, stop, nHits := req.Start, req.Stop, int32(len(docs))
+	res.Nhits = nHits
+	if start > nHits {
+		start = nHits
+	}
+	if stop > nHits {
+		stop = nHits
+	}
+	if stop - start > SEARCH_MAX_PAGE {
+		stop = start + SEARCH_MAX_PAGE
+	}
+	res.Postids = make([]int64, stop-start)
+	res.Scores = make([]float64, stop-start)
+	for i := start; i < stop; i++ {
+		res.Postids[i-start] = docs[i].Postid
+		res.Scores[i-start] = scores[docs[i].Postid]
+	}
+	res.Ok = SEARCH_QUERY_OK
+	return res, nil
+}
+
+func (ssrv *SearchSrv) removePostings(postid int64) error {
+	_, err := ssrv.mongoTermCo.DeleteMany(context.TODO(), &bson.M{"postid": postid})
+	return err
+}
+
+// matchesPhrases reports whether every phrase occurs in a post, given the
+// positions of the query terms in it.
+func matchesPhrases(positions map[string][]int32, phrases [][]Token) bool {
+	for _, phrase := range phrases {
+		if !matchesPhrase(positions, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesPhrase(positions map[string][]int32, phrase []Token) bool {
+	first := phrase[0]
+	for _, pos := range positions[first.Term] {
+		found := true
+		for _, token := range phrase[1:] {
+			want := pos + int32(token.Position-first.Position)
+			if !containsPosition(positions[token.Term], want) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPosition(positions []int32, want int32) bool {
+	for _, pos := range positions {
+		if pos == want {
+			return true
+		}
+	}
+	return false
+}
+
+// score is a tf-idf sum over the query terms found in a post, damped by the
+// length of the post so that long posts do not win by size alone.
+func score(positions map[string][]int32, df map[string]int, nDocs int64, length int32) float64 {
+	s := 0.0
+	for term, termPositions := range positions {
+		idf := math.Log(1 + float64(nDocs)/float64(df[term]))
+		s += float64(len(termPositions)) * idf
+	}
+	if length > 0 {
+		s /= math.Sqrt(float64(length))
+	}
+	return s
+}
+
+type PostingBson struct {
+	Term      string  `bson:"term"`
+	Postid    int64   `bson:"postid"`
+	Positions []int32 `bson:"positions"`
+}
+
+type DocBson struct {
+	Postid    int64 `bson:"postid"`
+	Creator   int64 `bson:"creator"`
+	Timestamp int64 `bson:"timestamp"`
+	Length    int32 `bson:"length"`
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopwords are too common to be worth indexing. They still take up a
+// position, so phrases spanning them keep matching.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"but": true, "by": true, "for": true, "if": true, "in": true, "into": true, "is": true,
+	"it": true, "no": true, "not": true, "of": true, "on": true, "or": true, "such": true,
+	"that": true, "the": true, "their": true, "then": true, "there": true, "these": true,
+	"they": true, "this": true, "to": true, "was": true, "will": true, "with": true,
+	"http": true, "https": true,
+}
+
+type Token struct {
+	Term     string
+	Position int
+}
+
+// Tokenize splits text into lower-cased words of letters, digits and '_',
+// dropping stopwords.
+func Tokenize(text string) []Token {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	tokens := make([]Token, 0, len(words))
+	for pos, word := range words {
+		if !stopwords[word] {
+			tokens = append(tokens, Token{Term: word, Position: pos})
+		}
+	}
+	return tokens
+}
+
+// parseQuery returns the keywords of a query and its double-quoted phrases.
+// Phrase terms are keywords too, so they contribute to the ranking.
+func parseQuery(query string) ([]string, [][]Token) {
+	var keywords []string
+	var phrases [][]Token
+	seen := make(map[string]bool)
+	addKeywords := func(tokens []Token) {
+		for _, token := range tokens {
+			if !seen[token.Term] {
+				seen[token.Term] = true
+				keywords = append(keywords, token.Term)
+			}
+		}
+	}
+	for idx, part := range strings.Split(query, "\"") {
+		tokens := Tokenize(part)
+		// odd parts sit between quotes; an unbalanced quote is read as closed
+		// at the end of the query
+		if idx%2 == 1 && len(tokens) > 0 {
+			phrases = append(phrases, tokens)
+		}
+		addKeywords(tokens)
+	}
+	return keywords, phrases
+}
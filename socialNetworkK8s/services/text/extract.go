@@ -0,0 +1,181 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"socialnetworkk8/services/text/proto"
+)
+
+// Entity is a mention, url or hashtag found in a text. Start and End are
+// byte offsets, End exclusive. Value is the username of a mention, the url
+// as written, or the lower-cased tag of a hashtag.
+type Entity struct {
+	Type       proto.ENTITY_TYPE
+	Start, End int
+	Value      string
+}
+
+// characters ending a url outright
+const urlStop = "<>\"`"
+// punctuation more likely to close the sentence than the url
+const urlTrailing = ".,;:!?'\"*"
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc)
+}
+
+func isUsernameRune(r rune) bool {
+	return r == '-' || isWordRune(r)
+}
+
+// isEmailLocalRune says whether r may end the local part of an email address.
+func isEmailLocalRune(r rune) bool {
+	return strings.ContainsRune(".+-%", r) || isWordRune(r)
+}
+
+// lastRune returns the rune of text ending at byte i, or 0 at the start.
+func lastRune(text string, i int) rune {
+	if i == 0 {
+		return 0
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return r
+}
+
+// scanUrl returns the end of the url starting at i, or -1 if none does.
+func scanUrl(text string, i int) int {
+	rest := text[i:]
+	var scheme int
+	switch {
+	case len(rest) >= 8 && strings.EqualFold(rest[:8], "https://"):
+		scheme = 8
+	case len(rest) >= 7 && strings.EqualFold(rest[:7], "http://"):
+		scheme = 7
+	default:
+		return -1
+	}
+	end := i + scheme
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(urlStop, r) {
+			break
+		}
+		end += size
+	}
+	// trailing punctuation and unbalanced closing brackets belong to the
+	// sentence: "(see http://x.com/a)." ends the url at "a"
+	for end > i+scheme {
+		r, size := utf8.DecodeLastRuneInString(text[:end])
+		if strings.ContainsRune(urlTrailing, r) {
+			end -= size
+			continue
+		}
+		open := map[rune]rune{')': '(', ']': '[', '}': '{'}[r]
+		if open != 0 && strings.Count(text[i:end], string(open)) < strings.Count(text[i:end], string(r)) {
+			end -= size
+			continue
+		}
+		break
+	}
+	host := text[i+scheme : end]
+	if idx := strings.IndexAny(host, "/?#"); idx >= 0 {
+		host = host[:idx]
+	}
+	if strings.IndexFunc(host, isWordRune) < 0 {
+		return -1
+	}
+	return end
+}
+
+// scanEmail returns the end of the email address whose '@' is at i, or -1
+// if what follows is no domain. A domain has at least two labels and ends
+// in an alphabetic one.
+func scanEmail(text string, i int) int {
+	end, labels, lastAlpha := i+1, 0, false
+	for {
+		start, alpha := end, true
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			alpha = alpha && unicode.IsLetter(r)
+			end += size
+		}
+		if end == start {
+			// a '.' leading nowhere ends the sentence, not the domain
+			if labels > 0 {
+				end--
+			}
+			break
+		}
+		labels++
+		lastAlpha = alpha
+		if end < len(text) && text[end] == '.' {
+			end++
+			continue
+		}
+		break
+	}
+	if labels < 2 || !lastAlpha {
+		return -1
+	}
+	return end
+}
+
+// Extract finds the mentions, urls and hashtags of text, in order. Nothing
+// inside a url or an email address is a mention or hashtag, and neither is
+// an '@' or '#' glued to a preceding word, as in "C#x" (though "@a@b"
+// mentions both a and b).
+func Extract(text string) []Entity {
+	var entities []Entity
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		prev := lastRune(text, i)
+		switch {
+		case (r == 'h' || r == 'H') && !isWordRune(prev):
+			if end := scanUrl(text, i); end >= 0 {
+				entities = append(entities, Entity{Type: proto.ENTITY_TYPE_URL, Start: i, End: end, Value: text[i:end]})
+				i = end
+				continue
+			}
+		case r == '@':
+			if prev != 0 && isEmailLocalRune(prev) {
+				if end := scanEmail(text, i); end >= 0 {
+					i = end
+					continue
+				}
+			}
+			end := i + size
+			for end < len(text) {
+				next, nsize := utf8.DecodeRuneInString(text[end:])
+				if !isUsernameRune(next) {
+					break
+				}
+				end += nsize
+			}
+			if end > i+size {
+				entities = append(entities, Entity{Type: proto.ENTITY_TYPE_MENTION, Start: i, End: end, Value: text[i+size : end]})
+				i = end
+				continue
+			}
+		case r == '#' && !isWordRune(prev) && prev != '/':
+			end := i + size
+			for end < len(text) {
+				next, nsize := utf8.DecodeRuneInString(text[end:])
+				if !isWordRune(next) {
+					break
+				}
+				end += nsize
+			}
+			if end > i+size {
+				entities = append(entities, Entity{Type: proto.ENTITY_TYPE_HASHTAG, Start: i, End: end, Value: strings.ToLower(text[i+size : end])})
+				i = end
+				continue
+			}
+		}
+		i += size
+	}
+	return entities
+}
@@ -0,0 +1,70 @@
+package text
+
+import (
+	"testing"
+	"unicode/utf8"
+	"github.com/stretchr/testify/assert"
+	"socialnetworkk8/services/text/proto"
+)
+
+const (
+	MENTION = proto.ENTITY_TYPE_MENTION
+	URL = proto.ENTITY_TYPE_URL
+	HASHTAG = proto.ENTITY_TYPE_HASHTAG
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []Entity
+	}{
+		{"empty", "", nil},
+		{"plain text", "nothing to see here", nil},
+		{"mention", "hi @user_1!", []Entity{{MENTION, 3, 10, "user_1"}}},
+		{"mention with dash", "@a-b c", []Entity{{MENTION, 0, 4, "a-b"}}},
+		{"lone at", "meet @ noon", nil},
+		{"chained mentions", "@a@b", []Entity{{MENTION, 0, 2, "a"}, {MENTION, 2, 4, "b"}}},
+		{"hashtag lower-cased", "so #GoLang.", []Entity{{HASHTAG, 3, 10, "golang"}}},
+		{"hashtag glued to word", "C#x and #ok", []Entity{{HASHTAG, 8, 11, "ok"}}},
+		{"lone hash", "# 1", nil},
+		{"url", "see http://x.com/a b", []Entity{{URL, 4, 18, "http://x.com/a"}}},
+		{"url case-insensitive scheme", "HTTPS://X.com", []Entity{{URL, 0, 13, "HTTPS://X.com"}}},
+		{"url trailing punctuation", "go to https://x.com/a?b=c.", []Entity{{URL, 6, 25, "https://x.com/a?b=c"}}},
+		{"url trailing quote and bang", "'http://x.com'!", []Entity{{URL, 1, 13, "http://x.com"}}},
+		{"url in parentheses", "(see http://x.com/a).", []Entity{{URL, 5, 19, "http://x.com/a"}}},
+		{"url with balanced parentheses", "http://x.com/wiki/A_(b)", []Entity{{URL, 0, 23, "http://x.com/wiki/A_(b)"}}},
+		{"url without host", "http:// and https://...", nil},
+		{"url glued to word", "xhttp://x.com", nil},
+		{"no entities inside url", "http://x.com/@a#b", []Entity{{URL, 0, 17, "http://x.com/@a#b"}}},
+		{"url fragment is no hashtag", "http://x.com/#top", []Entity{{URL, 0, 17, "http://x.com/#top"}}},
+		{"email", "mail a.b+c@example.com now", nil},
+		{"email ending a sentence", "mail me@example.com.", nil},
+		{"email needs two labels", "x@localhost", []Entity{{MENTION, 1, 11, "localhost"}}},
+		{"email needs alphabetic tld", "x@1.2", []Entity{{MENTION, 1, 3, "1"}}},
+		{"mention after email", "me@x.org @you", []Entity{{MENTION, 9, 13, "you"}}},
+		{"unicode mention", "@José!", []Entity{{MENTION, 0, 6, "José"}}},
+		{"unicode hashtag", "#Ünïcode", []Entity{{HASHTAG, 0, 10, "ünïcode"}}},
+		{"combining marks", "#cafe\u0301 x", []Entity{{HASHTAG, 0, 7, "cafe\u0301"}}},
+		{"non-latin hashtag", "#東京 #мир", []Entity{{HASHTAG, 0, 7, "東京"}, {HASHTAG, 8, 15, "мир"}}},
+		// offsets count bytes, not runes: "é" takes two bytes
+		{"byte offsets after multibyte runes", "ééé @a #b http://x.co",
+			[]Entity{{MENTION, 7, 9, "a"}, {HASHTAG, 10, 12, "b"}, {URL, 13, 24, "http://x.co"}}},
+		{"emoji before entity", "😀@a", []Entity{{MENTION, 4, 6, "a"}}},
+		{"hashtag ends at emoji", "#go😀", []Entity{{HASHTAG, 0, 3, "go"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entities := Extract(test.text)
+			assert.Equal(t, test.entities, entities)
+			for _, entity := range entities {
+				// offsets fall on rune boundaries and cover the entity as written
+				assert.True(t, utf8.ValidString(test.text[:entity.Start]))
+				assert.True(t, utf8.ValidString(test.text[entity.Start:entity.End]))
+				if entity.Type != HASHTAG {
+					assert.Contains(t, test.text[entity.Start:entity.End], entity.Value)
+				}
+			}
+		})
+	}
+}
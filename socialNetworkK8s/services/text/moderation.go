@@ -0,0 +1,316 @@
+package text
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"golang.org/x/text/unicode/norm"
+	"socialnetworkk8/services/text/proto"
+)
+
+const (
+	POLICY_REJECT = "reject"
+	POLICY_MASK = "mask"
+	POLICY_FLAG = "flag"
+	MASK_RUNE = '*'
+)
+
+// letters that look like latin ones, folded before matching terms
+var confusables = map[rune]rune{
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p',
+	'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p',
+	'τ': 't', 'υ': 'u', 'χ': 'x', 'ı': 'i', 'ł': 'l', 'ø': 'o',
+}
+
+// digits and symbols standing in for letters inside a word
+var leetspeak = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't',
+}
+
+// TermList is a named list of banned words or phrases and what to do about
+// them.
+type TermList struct {
+	Name   string   `json:"name"`
+	Terms  []string `json:"terms"`
+	Policy string   `json:"policy"`
+}
+
+// ModerationConfig is read from the file named by "ModerationConfig" in
+// config.json. A Maxlength of 0 means no limit. The shipped moderation.json
+// has no rules; moderation.example.json shows what a policy looks like.
+type ModerationConfig struct {
+	Maxlength       int        `json:"maxlength"`
+	Maxlengthpolicy string     `json:"maxlengthpolicy"`
+	Termlists       []TermList `json:"termlists"`
+	Blockeddomains  []string   `json:"blockeddomains"`
+	Domainpolicy    string     `json:"domainpolicy"`
+}
+
+type termList struct {
+	name   string
+	terms  [][]rune
+	action proto.MODERATION_ACTION
+}
+
+// Moderator checks texts against a ModerationConfig.
+type Moderator struct {
+	maxlength     int
+	lengthAction  proto.MODERATION_ACTION
+	termlists     []termList
+	domains       []string
+	domainAction  proto.MODERATION_ACTION
+}
+
+// Moderation is the outcome of moderating a text. Text has the masks
+// applied, unless Action is REJECT.
+type Moderation struct {
+	Action  proto.MODERATION_ACTION
+	Reasons []string
+	Text    string
+}
+
+func policyAction(policy string) (proto.MODERATION_ACTION, error) {
+	switch policy {
+	case POLICY_REJECT:
+		return proto.MODERATION_ACTION_REJECT, nil
+	case POLICY_MASK:
+		return proto.MODERATION_ACTION_MASK, nil
+	case POLICY_FLAG:
+		return proto.MODERATION_ACTION_FLAG, nil
+	}
+	return proto.MODERATION_ACTION_ALLOW, fmt.Errorf("unknown moderation policy %q", policy)
+}
+
+// LoadModerator reads a ModerationConfig from path.
+func LoadModerator(path string) (*Moderator, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &ModerationConfig{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, err
+	}
+	return MakeModerator(config)
+}
+
+func MakeModerator(config *ModerationConfig) (*Moderator, error) {
+	m := &Moderator{maxlength: config.Maxlength}
+	var err error
+	if m.maxlength > 0 {
+		if m.lengthAction, err = policyAction(config.Maxlengthpolicy); err != nil {
+			return nil, err
+		}
+	}
+	for _, list := range config.Termlists {
+		action, err := policyAction(list.Policy)
+		if err != nil {
+			return nil, fmt.Errorf("term list %v: %v", list.Name, err)
+		}
+		compiled := termList{name: list.Name, action: action}
+		for _, term := range list.Terms {
+			normalized := make([]rune, 0, len(term))
+			for _, nr := range normalize(term) {
+				normalized = append(normalized, nr.r)
+			}
+			if len(normalized) > 0 {
+				compiled.terms = append(compiled.terms, normalized)
+			}
+		}
+		m.termlists = append(m.termlists, compiled)
+	}
+	if len(config.Blockeddomains) > 0 {
+		if m.domainAction, err = policyAction(config.Domainpolicy); err != nil {
+			return nil, err
+		}
+		for _, domain := range config.Blockeddomains {
+			m.domains = append(m.domains, strings.ToLower(strings.TrimPrefix(domain, ".")))
+		}
+	}
+	return m, nil
+}
+
+// normRune is a rune of the normalized text and the bytes of the original
+// text it stands for.
+type normRune struct {
+	r          rune
+	start, end int
+}
+
+// normalize folds text for matching: compatibility forms and accents are
+// undone, case, confusable letters and leetspeak inside words are folded,
+// and every run of other characters becomes one space.
+func normalize(text string) []normRune {
+	var out []normRune
+	space := func(start, end int) {
+		if len(out) == 0 {
+			return
+		}
+		if last := &out[len(out)-1]; last.r == ' ' {
+			last.end = end
+		} else {
+			out = append(out, normRune{r: ' ', start: start, end: end})
+		}
+	}
+	for start, r := range text {
+		end := start + utf8.RuneLen(r)
+		if leet, ok := leetspeak[r]; ok {
+			// "sh!t" and "h3llo" are words, but "hi!" and "100" stay as they are
+			next, _ := utf8.DecodeRuneInString(text[end:])
+			inWord := unicode.IsLetter(next)
+			if unicode.IsDigit(r) && len(out) > 0 && unicode.IsLetter(out[len(out)-1].r) {
+				inWord = true
+			}
+			if inWord {
+				out = append(out, normRune{r: leet, start: start, end: end})
+				continue
+			}
+		}
+		folded := false
+		for _, d := range norm.NFKD.String(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			}
+			d = unicode.ToLower(d)
+			if c, ok := confusables[d]; ok {
+				d = c
+			}
+			if unicode.IsLetter(d) || unicode.IsDigit(d) {
+				out = append(out, normRune{r: d, start: start, end: end})
+				folded = true
+			}
+		}
+		if !folded && !unicode.Is(unicode.Mn, r) {
+			space(start, end)
+		}
+	}
+	if len(out) > 0 && out[len(out)-1].r == ' ' {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// findTerm returns the spans of the original text where term occurs in
+// normalized as whole words.
+func findTerm(normalized []normRune, term []rune) [][2]int {
+	var spans [][2]int
+	for i := 0; i+len(term) <= len(normalized); i++ {
+		if i > 0 && normalized[i-1].r != ' ' {
+			continue
+		}
+		j := i + len(term)
+		if j < len(normalized) && normalized[j].r != ' ' {
+			continue
+		}
+		match := true
+		for k, r := range term {
+			if normalized[i+k].r != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			spans = append(spans, [2]int{normalized[i].start, normalized[j-1].end})
+		}
+	}
+	return spans
+}
+
+// urlHost returns the lower-cased host of an extracted url.
+func urlHost(u string) string {
+	host := u[strings.Index(u, "://")+3:]
+	if idx := strings.IndexAny(host, "/?#:"); idx >= 0 {
+		host = host[:idx]
+	}
+	return strings.ToLower(host)
+}
+
+func (m *Moderator) blockedDomain(host string) (string, bool) {
+	for _, domain := range m.domains {
+		if host == domain || strings.HasSuffix(host, "." + domain) {
+			return domain, true
+		}
+	}
+	return "", false
+}
+
+// Moderate applies every rule to text. The action taken is the strongest
+// of the rules that fired, and each of them gives a reason.
+func (m *Moderator) Moderate(text string, urlIndices [][]int) *Moderation {
+	mod := &Moderation{Action: proto.MODERATION_ACTION_ALLOW, Text: text}
+	var masks [][2]int
+	fire := func(action proto.MODERATION_ACTION, reason string, spans [][2]int) {
+		if action > mod.Action {
+			mod.Action = action
+		}
+		mod.Reasons = append(mod.Reasons, strings.ToLower(action.String()) + ": " + reason)
+		if action == proto.MODERATION_ACTION_MASK {
+			masks = append(masks, spans...)
+		}
+	}
+	normalized := normalize(text)
+	for _, list := range m.termlists {
+		var spans [][2]int
+		for _, term := range list.terms {
+			spans = append(spans, findTerm(normalized, term)...)
+		}
+		if len(spans) > 0 {
+			fire(list.action, "term list " + list.name, spans)
+		}
+	}
+	for _, loc := range urlIndices {
+		if domain, ok := m.blockedDomain(urlHost(text[loc[0]:loc[1]])); ok {
+			fire(m.domainAction, "blocked domain " + domain, [][2]int{{loc[0], loc[1]}})
+		}
+	}
+	length := utf8.RuneCountInString(text)
+	if m.maxlength > 0 && length > m.maxlength {
+		fire(m.lengthAction, fmt.Sprintf("length %v over %v", length, m.maxlength), nil)
+	}
+	if mod.Action == proto.MODERATION_ACTION_REJECT {
+		return mod
+	}
+	mod.Text = mask(text, masks)
+	if m.maxlength > 0 && m.lengthAction == proto.MODERATION_ACTION_MASK {
+		// masking an overlong text cuts it down to size
+		if runes := []rune(mod.Text); len(runes) > m.maxlength {
+			mod.Text = string(runes[:m.maxlength])
+		}
+	}
+	return mod
+}
+
+// mask replaces every non-space rune of text inside spans with MASK_RUNE.
+func mask(text string, spans [][2]int) string {
+	if len(spans) == 0 {
+		return text
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	var b strings.Builder
+	prev := 0
+	for _, span := range spans {
+		if span[1] <= prev {
+			continue
+		}
+		if span[0] < prev {
+			span[0] = prev
+		}
+		b.WriteString(text[prev:span[0]])
+		for _, r := range text[span[0]:span[1]] {
+			if unicode.IsSpace(r) {
+				b.WriteRune(r)
+			} else {
+				b.WriteRune(MASK_RUNE)
+			}
+		}
+		prev = span[1]
+	}
+	b.WriteString(text[prev:])
+	return b.String()
+}
@@ -25,12 +25,118 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type ENTITY_TYPE int32
+
+const (
+	ENTITY_TYPE_MENTION ENTITY_TYPE = 0
+	ENTITY_TYPE_URL     ENTITY_TYPE = 1
+	ENTITY_TYPE_HASHTAG ENTITY_TYPE = 2
+)
+
+// Enum value maps for ENTITY_TYPE.
+var (
+	ENTITY_TYPE_name = map[int32]string{
+		0: "MENTION",
+		1: "URL",
+		2: "HASHTAG",
+	}
+	ENTITY_TYPE_value = map[string]int32{
+		"MENTION": 0,
+		"URL":     1,
+		"HASHTAG": 2,
+	}
+)
+
+func (x ENTITY_TYPE) Enum() *ENTITY_TYPE {
+	p := new(ENTITY_TYPE)
+	*p = x
+	return p
+}
+
+func (x ENTITY_TYPE) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ENTITY_TYPE) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_text_proto_text_proto_enumTypes[0].Descriptor()
+}
+
+func (ENTITY_TYPE) Type() protoreflect.EnumType {
+	return &file_services_text_proto_text_proto_enumTypes[0]
+}
+
+func (x ENTITY_TYPE) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ENTITY_TYPE.Descriptor instead.
+func (ENTITY_TYPE) EnumDescriptor() ([]byte, []int) {
+	return file_services_text_proto_text_proto_rawDescGZIP(), []int{0}
+}
+
+// Actions are ordered by strength. MASK replaces the offending parts of the
+// text with '*'; FLAG lets the text through but marks it for review.
+type MODERATION_ACTION int32
+
+const (
+	MODERATION_ACTION_ALLOW  MODERATION_ACTION = 0
+	MODERATION_ACTION_FLAG   MODERATION_ACTION = 1
+	MODERATION_ACTION_MASK   MODERATION_ACTION = 2
+	MODERATION_ACTION_REJECT MODERATION_ACTION = 3
+)
+
+// Enum value maps for MODERATION_ACTION.
+var (
+	MODERATION_ACTION_name = map[int32]string{
+		0: "ALLOW",
+		1: "FLAG",
+		2: "MASK",
+		3: "REJECT",
+	}
+	MODERATION_ACTION_value = map[string]int32{
+		"ALLOW":  0,
+		"FLAG":   1,
+		"MASK":   2,
+		"REJECT": 3,
+	}
+)
+
+func (x MODERATION_ACTION) Enum() *MODERATION_ACTION {
+	p := new(MODERATION_ACTION)
+	*p = x
+	return p
+}
+
+func (x MODERATION_ACTION) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MODERATION_ACTION) Descriptor() protoreflect.EnumDescriptor {
+	return file_services_text_proto_text_proto_enumTypes[1].Descriptor()
+}
+
+func (MODERATION_ACTION) Type() protoreflect.EnumType {
+	return &file_services_text_proto_text_proto_enumTypes[1]
+}
+
+func (x MODERATION_ACTION) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MODERATION_ACTION.Descriptor instead.
+func (MODERATION_ACTION) EnumDescriptor() ([]byte, []int) {
+	return file_services_text_proto_text_proto_rawDescGZIP(), []int{1}
+}
+
+// Mentions of users who block or mute userid, or whom userid blocks, are
+// dropped from usermentions.
 type ProcessTextRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Text   string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Userid int64  `protobuf:"varint,2,opt,name=userid,proto3" json:"userid,omitempty"`
 }
 
 func (x *ProcessTextRequest) Reset() {
@@ -72,15 +178,30 @@ func (x *ProcessTextRequest) GetText() string {
 	return ""
 }
 
+func (x *ProcessTextRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+// moderation is the strongest action taken by the moderation rules that
+// fired on the text, and moderationreasons says which rules fired and what
+// each did. A rejected text is not processed further and ok says why.
+// entities are the mentions, urls and hashtags of text, in order.
 type ProcessTextResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ok           string   `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Text         string   `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
-	Usermentions []int64  `protobuf:"varint,3,rep,packed,name=usermentions,proto3" json:"usermentions,omitempty"`
-	Urls         []string `protobuf:"bytes,4,rep,name=urls,proto3" json:"urls,omitempty"`
+	Ok                string            `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Text              string            `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Usermentions      []int64           `protobuf:"varint,3,rep,packed,name=usermentions,proto3" json:"usermentions,omitempty"`
+	Urls              []string          `protobuf:"bytes,4,rep,name=urls,proto3" json:"urls,omitempty"`
+	Hashtags          []string          `protobuf:"bytes,5,rep,name=hashtags,proto3" json:"hashtags,omitempty"`
+	Moderation        MODERATION_ACTION `protobuf:"varint,6,opt,name=moderation,proto3,enum=text.MODERATION_ACTION" json:"moderation,omitempty"`
+	Moderationreasons []string          `protobuf:"bytes,7,rep,name=moderationreasons,proto3" json:"moderationreasons,omitempty"`
+	Entities          []*TextEntity     `protobuf:"bytes,8,rep,name=entities,proto3" json:"entities,omitempty"`
 }
 
 func (x *ProcessTextResponse) Reset() {
@@ -143,28 +264,199 @@ func (x *ProcessTextResponse) GetUrls() []string {
 	return nil
 }
 
+func (x *ProcessTextResponse) GetHashtags() []string {
+	if x != nil {
+		return x.Hashtags
+	}
+	return nil
+}
+
+func (x *ProcessTextResponse) GetModeration() MODERATION_ACTION {
+	if x != nil {
+		return x.Moderation
+	}
+	return MODERATION_ACTION_ALLOW
+}
+
+func (x *ProcessTextResponse) GetModerationreasons() []string {
+	if x != nil {
+		return x.Moderationreasons
+	}
+	return nil
+}
+
+func (x *ProcessTextResponse) GetEntities() []*TextEntity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+// start and end locate the entity in the processed text, in bytes and in
+// runes, end exclusive. value is the username of a mention, the short url
+// of a url (which is what the text holds) or the lower-cased tag of a
+// hashtag. userid is the mentioned user, or -1 if there is no such user;
+// expandedurl is the url as written.
+type TextEntity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        ENTITY_TYPE `protobuf:"varint,1,opt,name=type,proto3,enum=text.ENTITY_TYPE" json:"type,omitempty"`
+	Value       string      `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Start       int32       `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End         int32       `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	Runestart   int32       `protobuf:"varint,5,opt,name=runestart,proto3" json:"runestart,omitempty"`
+	Runeend     int32       `protobuf:"varint,6,opt,name=runeend,proto3" json:"runeend,omitempty"`
+	Userid      int64       `protobuf:"varint,7,opt,name=userid,proto3" json:"userid,omitempty"`
+	Expandedurl string      `protobuf:"bytes,8,opt,name=expandedurl,proto3" json:"expandedurl,omitempty"`
+}
+
+func (x *TextEntity) Reset() {
+	*x = TextEntity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_text_proto_text_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TextEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextEntity) ProtoMessage() {}
+
+func (x *TextEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_services_text_proto_text_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextEntity.ProtoReflect.Descriptor instead.
+func (*TextEntity) Descriptor() ([]byte, []int) {
+	return file_services_text_proto_text_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TextEntity) GetType() ENTITY_TYPE {
+	if x != nil {
+		return x.Type
+	}
+	return ENTITY_TYPE_MENTION
+}
+
+func (x *TextEntity) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TextEntity) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TextEntity) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *TextEntity) GetRunestart() int32 {
+	if x != nil {
+		return x.Runestart
+	}
+	return 0
+}
+
+func (x *TextEntity) GetRuneend() int32 {
+	if x != nil {
+		return x.Runeend
+	}
+	return 0
+}
+
+func (x *TextEntity) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *TextEntity) GetExpandedurl() string {
+	if x != nil {
+		return x.Expandedurl
+	}
+	return ""
+}
+
 var File_services_text_proto_text_proto protoreflect.FileDescriptor
 
 var file_services_text_proto_text_proto_rawDesc = []byte{
 	0x0a, 0x1e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x74, 0x65, 0x78, 0x74, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x28, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x12, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x40, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
 	0x73, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
 	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
-	0x22, 0x71, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x75,
-	0x73, 0x65, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x03, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75,
-	0x72, 0x6c, 0x73, 0x32, 0x4a, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x42, 0x0a, 0x0b, 0x50,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x12, 0x18, 0x2e, 0x74, 0x65, 0x78,
-	0x74, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
-	0x17, 0x5a, 0x15, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x74, 0x65,
-	0x78, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x22, 0xa2, 0x02, 0x0a, 0x13, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x6d, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72,
+	0x6d, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x68, 0x61, 0x73, 0x68, 0x74, 0x61, 0x67, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x74,
+	0x65, 0x78, 0x74, 0x2e, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2c, 0x0a, 0x11, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x6f,
+	0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12,
+	0x2c, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xe3, 0x01,
+	0x0a, 0x0a, 0x54, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x78,
+	0x74, 0x2e, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65, 0x6e,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x75, 0x6e, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x65, 0x65, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x72, 0x75, 0x6e, 0x65, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69,
+	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x75, 0x72, 0x6c,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64,
+	0x75, 0x72, 0x6c, 0x2a, 0x30, 0x0a, 0x0b, 0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x45, 0x4e, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12,
+	0x07, 0x0a, 0x03, 0x55, 0x52, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x48, 0x41, 0x53, 0x48,
+	0x54, 0x41, 0x47, 0x10, 0x02, 0x2a, 0x3e, 0x0a, 0x11, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4c,
+	0x4c, 0x4f, 0x57, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x4c, 0x41, 0x47, 0x10, 0x01, 0x12,
+	0x08, 0x0a, 0x04, 0x4d, 0x41, 0x53, 0x4b, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4a,
+	0x45, 0x43, 0x54, 0x10, 0x03, 0x32, 0x4a, 0x0a, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x42, 0x0a,
+	0x0b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x12, 0x18, 0x2e, 0x74,
+	0x65, 0x78, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x17, 0x5a, 0x15, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f,
+	0x74, 0x65, 0x78, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -179,19 +471,26 @@ func file_services_text_proto_text_proto_rawDescGZIP() []byte {
 	return file_services_text_proto_text_proto_rawDescData
 }
 
-var file_services_text_proto_text_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_services_text_proto_text_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_services_text_proto_text_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_services_text_proto_text_proto_goTypes = []interface{}{
-	(*ProcessTextRequest)(nil),  // 0: text.ProcessTextRequest
-	(*ProcessTextResponse)(nil), // 1: text.ProcessTextResponse
+	(ENTITY_TYPE)(0),            // 0: text.ENTITY_TYPE
+	(MODERATION_ACTION)(0),      // 1: text.MODERATION_ACTION
+	(*ProcessTextRequest)(nil),  // 2: text.ProcessTextRequest
+	(*ProcessTextResponse)(nil), // 3: text.ProcessTextResponse
+	(*TextEntity)(nil),          // 4: text.TextEntity
 }
 var file_services_text_proto_text_proto_depIdxs = []int32{
-	0, // 0: text.Text.ProcessText:input_type -> text.ProcessTextRequest
-	1, // 1: text.Text.ProcessText:output_type -> text.ProcessTextResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: text.ProcessTextResponse.moderation:type_name -> text.MODERATION_ACTION
+	4, // 1: text.ProcessTextResponse.entities:type_name -> text.TextEntity
+	0, // 2: text.TextEntity.type:type_name -> text.ENTITY_TYPE
+	2, // 3: text.Text.ProcessText:input_type -> text.ProcessTextRequest
+	3, // 4: text.Text.ProcessText:output_type -> text.ProcessTextResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_services_text_proto_text_proto_init() }
@@ -224,19 +523,32 @@ func file_services_text_proto_text_proto_init() {
 				return nil
 			}
 		}
+		file_services_text_proto_text_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TextEntity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_text_proto_text_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_services_text_proto_text_proto_goTypes,
 		DependencyIndexes: file_services_text_proto_text_proto_depIdxs,
+		EnumInfos:         file_services_text_proto_text_proto_enumTypes,
 		MessageInfos:      file_services_text_proto_text_proto_msgTypes,
 	}.Build()
 	File_services_text_proto_text_proto = out.File
@@ -7,9 +7,10 @@ import (
 	"os"
 	"strconv"
 	"time"
-	"regexp"
 	"fmt"
+	"strings"
 	"sync"
+	"unicode/utf8"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -21,6 +22,8 @@ import (
 	"socialnetworkk8/dialer"
 	"socialnetworkk8/services/user"
 	"socialnetworkk8/services/url"
+	"socialnetworkk8/services/graph"
+	graphpb "socialnetworkk8/services/graph/proto"
 	"socialnetworkk8/services/text/proto"
 	userpb "socialnetworkk8/services/user/proto"
 	urlpb "socialnetworkk8/services/url/proto"
@@ -36,20 +39,21 @@ import (
 const (
 	TEXT_SRV_NAME = "srv-text"
 	TEXT_QUERY_OK = "OK"
+	// text that moderation rejects; the reasons are in Moderationreasons
+	TEXT_QUERY_REJECTED = "Rejected by moderation."
 )
 
-var mentionRegex = regexp.MustCompile("@[a-zA-Z0-9-_]+") 
-var urlRegex = regexp.MustCompile("(http://|https://)([a-zA-Z0-9_!~*'().&=+$%-/]+)")
-
 type TextSrv struct {
 	proto.UnimplementedTextServer 
 	uuid         string
 	Registry     *registry.Client
 	userc        userpb.UserClient
 	urlc         urlpb.UrlClient
+	graphc       graphpb.GraphClient
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
+	moderator    *Moderator
 	pCounter     *tracing.Counter
 }
 
@@ -90,11 +94,20 @@ func MakeTextSrv() *TextSrv {
 		log.Panic().Msgf("Got error while initializing consul agent: %v", err)
 	}
 	log.Info().Msg("Consul agent initialized")
+	moderator, err := MakeModerator(&ModerationConfig{})
+	if path := result["ModerationConfig"]; path != "" {
+		log.Info().Msgf("Read moderation config: %v", path)
+		moderator, err = LoadModerator(path)
+	}
+	if err != nil {
+		log.Panic().Msgf("Got error while loading moderation config: %v", err)
+	}
 	return &TextSrv{
 		Port:         serv_port,
 		IpAddr:       serv_ip,
 		Tracer:       tracer,
 		Registry:     registry,
+		moderator:    moderator,
 		pCounter:     tracing.MakeCounter("Process Text"),
 	}
 }
@@ -123,6 +136,14 @@ func (tsrv *TextSrv) Run() error {
 		return fmt.Errorf("dialer error: %v", err)
 	}
 	tsrv.urlc = urlpb.NewUrlClient(urlConn)
+	graphConn, err := dialer.Dial(
+		graph.GRAPH_SRV_NAME,
+		tsrv.Registry.Client,
+		dialer.WithTracer(tsrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	tsrv.graphc = graphpb.NewGraphClient(graphConn)
 
 	log.Info().Msg("Initializing gRPC Server...")
 	tsrv.uuid = uuid.New().String()
@@ -171,22 +192,29 @@ func (tsrv *TextSrv) ProcessText(
 		res.Ok = "Cannot process empty text." 
 		return res, nil
 	}
-	// find mentions and urls
-	mentions := mentionRegex.FindAllString(req.Text, -1)
-	mentionsL := len(mentions)
-	usernames := make([]string, mentionsL)
-	for idx, mention := range mentions {
-		usernames[idx] = mention[1:]
+	mod := tsrv.moderator.Moderate(req.Text, urlIndices(Extract(req.Text)))
+	res.Moderation, res.Moderationreasons = mod.Action, mod.Reasons
+	if mod.Action == proto.MODERATION_ACTION_REJECT {
+		res.Ok = TEXT_QUERY_REJECTED
+		return res, nil
+	}
+	// find mentions and urls in what moderation left of the text
+	text := mod.Text
+	entities := Extract(text)
+	var usernames, extendedUrls []string
+	for _, entity := range entities {
+		switch entity.Type {
+		case proto.ENTITY_TYPE_MENTION:
+			usernames = append(usernames, entity.Value)
+		case proto.ENTITY_TYPE_URL:
+			extendedUrls = append(extendedUrls, entity.Value)
+		}
 	}
+	mentionsL := len(usernames)
 	userArg := &userpb.CheckUserRequest{Usernames: usernames}
 	userRes := &userpb.CheckUserResponse{}
 
-	urlIndices := urlRegex.FindAllStringIndex(req.Text, -1)
-	urlIndicesL := len(urlIndices)
-	extendedUrls := make([]string, urlIndicesL)
-	for idx, loc := range urlIndices {
-		extendedUrls[idx] = req.Text[loc[0]:loc[1]]
-	}
+	urlIndicesL := len(extendedUrls)
 	urlArg := &urlpb.ComposeUrlsRequest{Extendedurls: extendedUrls}
 	urlRes := &urlpb.ComposeUrlsResponse{}
 
@@ -208,10 +236,17 @@ func (tsrv *TextSrv) ProcessText(
 		}()
 	}
 	wg.Wait()
-	res.Text = req.Text
+	res.Text = text
 	if userErr != nil || urlErr != nil {
 		return nil, fmt.Errorf("%w; %w", userErr, urlErr)
 	} 
+	seen := make(map[string]bool)
+	for _, entity := range entities {
+		if entity.Type == proto.ENTITY_TYPE_HASHTAG && !seen[entity.Value] {
+			seen[entity.Value] = true
+			res.Hashtags = append(res.Hashtags, entity.Value)
+		}
+	}
 
 	// process mentions
 	for idx, userid := range userRes.Userids {
@@ -221,6 +256,13 @@ func (tsrv *TextSrv) ProcessText(
 			log.Warn().Msgf("User %v does not exist!", usernames[idx])
 		}
 	}
+	if len(res.Usermentions) > 0 {
+		mentions, err := tsrv.filterMentions(ctx, req.Userid, res.Usermentions)
+		if err != nil {
+			return nil, err
+		}
+		res.Usermentions = mentions
+	}
 
 	// process urls and text
 	if urlIndicesL > 0 { 
@@ -228,17 +270,84 @@ func (tsrv *TextSrv) ProcessText(
 			log.Warn().Msgf("cannot process urls %v!", extendedUrls)
 			res.Ok += urlRes.Ok
 			return res, nil
-		} else {
-			res.Urls = urlRes.Shorturls
-			res.Text = ""
-			prevLoc := 0
-			for idx, loc := range urlIndices {
-				res.Text += req.Text[prevLoc : loc[0]] + urlRes.Shorturls[idx]
-				prevLoc = loc[1]
-			}
-			res.Text += req.Text[urlIndices[urlIndicesL-1][1]:]
 		}
+		res.Urls = urlRes.Shorturls
 	}
+	res.Text, res.Entities = rewrite(text, entities, userRes.Userids, urlRes.Shorturls)
 	res.Ok = TEXT_QUERY_OK
 	return res, nil
-} 
+}
+
+// urlIndices returns the byte spans of the urls among entities.
+func urlIndices(entities []Entity) [][]int {
+	var indices [][]int
+	for _, entity := range entities {
+		if entity.Type == proto.ENTITY_TYPE_URL {
+			indices = append(indices, []int{entity.Start, entity.End})
+		}
+	}
+	return indices
+}
+
+// rewrite replaces the urls of text by their short forms and locates every
+// entity in the result. userids and shortUrls follow the order of the
+// mentions and urls among entities.
+func rewrite(text string, entities []Entity, userids []int64,
+		shortUrls []string) (string, []*proto.TextEntity) {
+	var b strings.Builder
+	prev, runes := 0, 0
+	textEntities := make([]*proto.TextEntity, 0, len(entities))
+	for _, entity := range entities {
+		runes += utf8.RuneCountInString(text[prev:entity.Start])
+		b.WriteString(text[prev:entity.Start])
+		te := &proto.TextEntity{Type: entity.Type, Value: entity.Value, Userid: -1}
+		written := text[entity.Start:entity.End]
+		switch entity.Type {
+		case proto.ENTITY_TYPE_MENTION:
+			if len(userids) > 0 {
+				te.Userid, userids = userids[0], userids[1:]
+			}
+		case proto.ENTITY_TYPE_URL:
+			te.Expandedurl = entity.Value
+			written, shortUrls = shortUrls[0], shortUrls[1:]
+			te.Value = written
+		}
+		te.Start, te.Runestart = int32(b.Len()), int32(runes)
+		b.WriteString(written)
+		runes += utf8.RuneCountInString(written)
+		te.End, te.Runeend = int32(b.Len()), int32(runes)
+		textEntities = append(textEntities, te)
+		prev = entity.End
+	}
+	b.WriteString(text[prev:])
+	return b.String(), textEntities
+}
+
+// filterMentions drops the mentioned users who do not want to hear from the
+// author, or whom the author blocks. The mention stays in the text.
+func (tsrv *TextSrv) filterMentions(
+		ctx context.Context, authorid int64, mentions []int64) ([]int64, error) {
+	hiddenRes, err := tsrv.graphc.GetHidden(ctx, &graphpb.GetHiddenRequest{Userids: mentions})
+	if err != nil {
+		return nil, err
+	}
+	if hiddenRes.Ok != graph.GRAPH_QUERY_OK {
+		return nil, fmt.Errorf("cannot read hidden users: %v", hiddenRes.Ok)
+	}
+	filtered := make([]int64, 0, len(mentions))
+	for idx, userid := range mentions {
+		hidden := false
+		for _, other := range hiddenRes.Hidden[idx].Userids {
+			if other == authorid {
+				hidden = true
+				break
+			}
+		}
+		if hidden {
+			log.Debug().Msgf("Dropping mention of %v by %v", userid, authorid)
+		} else {
+			filtered = append(filtered, userid)
+		}
+	}
+	return filtered, nil
+}
@@ -90,6 +90,61 @@ func (x *WriteTimelineRequest) GetTimestamp() int64 {
 	return 0
 }
 
+type RemoveTimelineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Userid int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Postid int64 `protobuf:"varint,2,opt,name=postid,proto3" json:"postid,omitempty"`
+}
+
+func (x *RemoveTimelineRequest) Reset() {
+	*x = RemoveTimelineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_timeline_proto_timeline_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTimelineRequest) ProtoMessage() {}
+
+func (x *RemoveTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_services_timeline_proto_timeline_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTimelineRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RemoveTimelineRequest) GetUserid() int64 {
+	if x != nil {
+		return x.Userid
+	}
+	return 0
+}
+
+func (x *RemoveTimelineRequest) GetPostid() int64 {
+	if x != nil {
+		return x.Postid
+	}
+	return 0
+}
+
 type WriteTimelineResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -101,7 +156,7 @@ type WriteTimelineResponse struct {
 func (x *WriteTimelineResponse) Reset() {
 	*x = WriteTimelineResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_services_timeline_proto_timeline_proto_msgTypes[1]
+		mi := &file_services_timeline_proto_timeline_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -114,7 +169,7 @@ func (x *WriteTimelineResponse) String() string {
 func (*WriteTimelineResponse) ProtoMessage() {}
 
 func (x *WriteTimelineResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_timeline_proto_timeline_proto_msgTypes[1]
+	mi := &file_services_timeline_proto_timeline_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -127,7 +182,7 @@ func (x *WriteTimelineResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteTimelineResponse.ProtoReflect.Descriptor instead.
 func (*WriteTimelineResponse) Descriptor() ([]byte, []int) {
-	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{1}
+	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *WriteTimelineResponse) GetOk() string {
@@ -137,20 +192,25 @@ func (x *WriteTimelineResponse) GetOk() string {
 	return ""
 }
 
+// With hasviewer set, ReadTimeline leaves out posts of users hidden from
+// viewerid (see graph.GetHidden). Home timelines are always read by their
+// owner.
 type ReadTimelineRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Userid int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
-	Start  int32 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	Stop   int32 `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+	Userid    int64 `protobuf:"varint,1,opt,name=userid,proto3" json:"userid,omitempty"`
+	Start     int32 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Stop      int32 `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+	Viewerid  int64 `protobuf:"varint,4,opt,name=viewerid,proto3" json:"viewerid,omitempty"`
+	Hasviewer bool  `protobuf:"varint,5,opt,name=hasviewer,proto3" json:"hasviewer,omitempty"`
 }
 
 func (x *ReadTimelineRequest) Reset() {
 	*x = ReadTimelineRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_services_timeline_proto_timeline_proto_msgTypes[2]
+		mi := &file_services_timeline_proto_timeline_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -163,7 +223,7 @@ func (x *ReadTimelineRequest) String() string {
 func (*ReadTimelineRequest) ProtoMessage() {}
 
 func (x *ReadTimelineRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_services_timeline_proto_timeline_proto_msgTypes[2]
+	mi := &file_services_timeline_proto_timeline_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -176,7 +236,7 @@ func (x *ReadTimelineRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadTimelineRequest.ProtoReflect.Descriptor instead.
 func (*ReadTimelineRequest) Descriptor() ([]byte, []int) {
-	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{2}
+	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ReadTimelineRequest) GetUserid() int64 {
@@ -200,6 +260,20 @@ func (x *ReadTimelineRequest) GetStop() int32 {
 	return 0
 }
 
+func (x *ReadTimelineRequest) GetViewerid() int64 {
+	if x != nil {
+		return x.Viewerid
+	}
+	return 0
+}
+
+func (x *ReadTimelineRequest) GetHasviewer() bool {
+	if x != nil {
+		return x.Hasviewer
+	}
+	return false
+}
+
 type ReadTimelineResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -212,7 +286,7 @@ type ReadTimelineResponse struct {
 func (x *ReadTimelineResponse) Reset() {
 	*x = ReadTimelineResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_services_timeline_proto_timeline_proto_msgTypes[3]
+		mi := &file_services_timeline_proto_timeline_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -225,7 +299,7 @@ func (x *ReadTimelineResponse) String() string {
 func (*ReadTimelineResponse) ProtoMessage() {}
 
 func (x *ReadTimelineResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_services_timeline_proto_timeline_proto_msgTypes[3]
+	mi := &file_services_timeline_proto_timeline_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,7 +312,7 @@ func (x *ReadTimelineResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadTimelineResponse.ProtoReflect.Descriptor instead.
 func (*ReadTimelineResponse) Descriptor() ([]byte, []int) {
-	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{3}
+	return file_services_timeline_proto_timeline_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ReadTimelineResponse) GetOk() string {
@@ -269,33 +343,46 @@ var file_services_timeline_proto_timeline_proto_rawDesc = []byte{
 	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
 	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x27, 0x0a, 0x15, 0x57, 0x72, 0x69, 0x74,
-	0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
-	0x6b, 0x22, 0x57, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x22, 0x48, 0x0a, 0x14, 0x52, 0x65,
-	0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x6f, 0x6b, 0x12, 0x20, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x70,
-	0x6f, 0x73, 0x74, 0x73, 0x32, 0xab, 0x01, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x12, 0x50, 0x0a, 0x0d, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
-	0x6e, 0x65, 0x12, 0x1e, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c,
-	0x69, 0x6e, 0x65, 0x12, 0x1d, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x47, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x73,
+	0x74, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x69,
+	0x64, 0x22, 0x27, 0x0a, 0x15, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x22, 0x91, 0x01, 0x0a, 0x13, 0x52,
 	0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65,
-	0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x1b, 0x5a, 0x19, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
-	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x73, 0x74, 0x6f, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x69, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x61, 0x73, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x22, 0x48,
+	0x0a, 0x14, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x20, 0x0a, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x50, 0x6f, 0x73,
+	0x74, 0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x32, 0xff, 0x01, 0x0a, 0x08, 0x54, 0x69, 0x6d,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x50, 0x0a, 0x0d, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1e, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1d, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1f, 0x2e, 0x74, 0x69, 0x6d, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x69, 0x6d, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1b, 0x5a, 0x19, 0x2e, 0x2f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -310,22 +397,25 @@ func file_services_timeline_proto_timeline_proto_rawDescGZIP() []byte {
 	return file_services_timeline_proto_timeline_proto_rawDescData
 }
 
-var file_services_timeline_proto_timeline_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_services_timeline_proto_timeline_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_services_timeline_proto_timeline_proto_goTypes = []interface{}{
 	(*WriteTimelineRequest)(nil),  // 0: timeline.WriteTimelineRequest
-	(*WriteTimelineResponse)(nil), // 1: timeline.WriteTimelineResponse
-	(*ReadTimelineRequest)(nil),   // 2: timeline.ReadTimelineRequest
-	(*ReadTimelineResponse)(nil),  // 3: timeline.ReadTimelineResponse
-	(*proto1.Post)(nil),           // 4: post.Post
+	(*RemoveTimelineRequest)(nil), // 1: timeline.RemoveTimelineRequest
+	(*WriteTimelineResponse)(nil), // 2: timeline.WriteTimelineResponse
+	(*ReadTimelineRequest)(nil),   // 3: timeline.ReadTimelineRequest
+	(*ReadTimelineResponse)(nil),  // 4: timeline.ReadTimelineResponse
+	(*proto1.Post)(nil),           // 5: post.Post
 }
 var file_services_timeline_proto_timeline_proto_depIdxs = []int32{
-	4, // 0: timeline.ReadTimelineResponse.posts:type_name -> post.Post
+	5, // 0: timeline.ReadTimelineResponse.posts:type_name -> post.Post
 	0, // 1: timeline.Timeline.WriteTimeline:input_type -> timeline.WriteTimelineRequest
-	2, // 2: timeline.Timeline.ReadTimeline:input_type -> timeline.ReadTimelineRequest
-	1, // 3: timeline.Timeline.WriteTimeline:output_type -> timeline.WriteTimelineResponse
-	3, // 4: timeline.Timeline.ReadTimeline:output_type -> timeline.ReadTimelineResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
+	3, // 2: timeline.Timeline.ReadTimeline:input_type -> timeline.ReadTimelineRequest
+	1, // 3: timeline.Timeline.RemoveTimeline:input_type -> timeline.RemoveTimelineRequest
+	2, // 4: timeline.Timeline.WriteTimeline:output_type -> timeline.WriteTimelineResponse
+	4, // 5: timeline.Timeline.ReadTimeline:output_type -> timeline.ReadTimelineResponse
+	2, // 6: timeline.Timeline.RemoveTimeline:output_type -> timeline.WriteTimelineResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -350,7 +440,7 @@ func file_services_timeline_proto_timeline_proto_init() {
 			}
 		}
 		file_services_timeline_proto_timeline_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteTimelineResponse); i {
+			switch v := v.(*RemoveTimelineRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -362,7 +452,7 @@ func file_services_timeline_proto_timeline_proto_init() {
 			}
 		}
 		file_services_timeline_proto_timeline_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadTimelineRequest); i {
+			switch v := v.(*WriteTimelineResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -374,6 +464,18 @@ func file_services_timeline_proto_timeline_proto_init() {
 			}
 		}
 		file_services_timeline_proto_timeline_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadTimelineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_services_timeline_proto_timeline_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReadTimelineResponse); i {
 			case 0:
 				return &v.state
@@ -392,7 +494,7 @@ func file_services_timeline_proto_timeline_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_timeline_proto_timeline_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Timeline_WriteTimeline_FullMethodName = "/timeline.Timeline/WriteTimeline"
-	Timeline_ReadTimeline_FullMethodName  = "/timeline.Timeline/ReadTimeline"
+	Timeline_WriteTimeline_FullMethodName  = "/timeline.Timeline/WriteTimeline"
+	Timeline_ReadTimeline_FullMethodName   = "/timeline.Timeline/ReadTimeline"
+	Timeline_RemoveTimeline_FullMethodName = "/timeline.Timeline/RemoveTimeline"
 )
 
 // TimelineClient is the client API for Timeline service.
@@ -29,6 +30,7 @@ const (
 type TimelineClient interface {
 	WriteTimeline(ctx context.Context, in *WriteTimelineRequest, opts ...grpc.CallOption) (*WriteTimelineResponse, error)
 	ReadTimeline(ctx context.Context, in *ReadTimelineRequest, opts ...grpc.CallOption) (*ReadTimelineResponse, error)
+	RemoveTimeline(ctx context.Context, in *RemoveTimelineRequest, opts ...grpc.CallOption) (*WriteTimelineResponse, error)
 }
 
 type timelineClient struct {
@@ -57,12 +59,22 @@ func (c *timelineClient) ReadTimeline(ctx context.Context, in *ReadTimelineReque
 	return out, nil
 }
 
+func (c *timelineClient) RemoveTimeline(ctx context.Context, in *RemoveTimelineRequest, opts ...grpc.CallOption) (*WriteTimelineResponse, error) {
+	out := new(WriteTimelineResponse)
+	err := c.cc.Invoke(ctx, Timeline_RemoveTimeline_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TimelineServer is the server API for Timeline service.
 // All implementations must embed UnimplementedTimelineServer
 // for forward compatibility
 type TimelineServer interface {
 	WriteTimeline(context.Context, *WriteTimelineRequest) (*WriteTimelineResponse, error)
 	ReadTimeline(context.Context, *ReadTimelineRequest) (*ReadTimelineResponse, error)
+	RemoveTimeline(context.Context, *RemoveTimelineRequest) (*WriteTimelineResponse, error)
 	mustEmbedUnimplementedTimelineServer()
 }
 
@@ -76,6 +88,9 @@ func (UnimplementedTimelineServer) WriteTimeline(context.Context, *WriteTimeline
 func (UnimplementedTimelineServer) ReadTimeline(context.Context, *ReadTimelineRequest) (*ReadTimelineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadTimeline not implemented")
 }
+func (UnimplementedTimelineServer) RemoveTimeline(context.Context, *RemoveTimelineRequest) (*WriteTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTimeline not implemented")
+}
 func (UnimplementedTimelineServer) mustEmbedUnimplementedTimelineServer() {}
 
 // UnsafeTimelineServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +140,24 @@ func _Timeline_ReadTimeline_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Timeline_RemoveTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimelineServer).RemoveTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Timeline_RemoveTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimelineServer).RemoveTimeline(ctx, req.(*RemoveTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Timeline_ServiceDesc is the grpc.ServiceDesc for Timeline service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,6 +173,10 @@ var Timeline_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadTimeline",
 			Handler:    _Timeline_ReadTimeline_Handler,
 		},
+		{
+			MethodName: "RemoveTimeline",
+			Handler:    _Timeline_RemoveTimeline_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/timeline/proto/timeline.proto",
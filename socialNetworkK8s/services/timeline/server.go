@@ -21,6 +21,8 @@ import (
 	"socialnetworkk8/services/cacheclnt"
 	"socialnetworkk8/tls"
 	"socialnetworkk8/services/post"
+	"socialnetworkk8/services/graph"
+	graphpb "socialnetworkk8/services/graph/proto"
 	"socialnetworkk8/dialer"
 	"socialnetworkk8/services/timeline/proto"
 	postpb "socialnetworkk8/services/post/proto"
@@ -37,6 +39,9 @@ import (
 const (
 	TIMELINE_SRV_NAME = "srv-timeline"
 	TIMELINE_QUERY_OK = "OK"
+	// a page with nothing on it: the timeline is empty or the page is past
+	// its end
+	TIMELINE_QUERY_EMPTY = "No timeline item"
 	TIMELINE_CACHE_PREFIX = "timeline_"
 )
 
@@ -46,12 +51,14 @@ type TimelineSrv struct {
 	cachec       *cacheclnt.CacheClnt
 	mongoCo      *mongo.Collection
 	postc        postpb.PostStorageClient
+	graphc       graphpb.GraphClient
 	Registry     *registry.Client
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
 	wCounter     *tracing.Counter
 	rCounter     *tracing.Counter
+	dCounter     *tracing.Counter
 }
 
 func MakeTimelineSrv() *TimelineSrv {
@@ -102,8 +109,20 @@ func MakeTimelineSrv() *TimelineSrv {
 		log.Panic().Msg(err.Error())
 	}
 	collection := mongoClient.Database("socialnetwork").Collection("timeline")
-	indexModel := mongo.IndexModel{Keys: bson.D{{"userid", 1}}}
+	// one timeline per user, so that writes can upsert on a filter
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "userid", Value: 1}}, Options: options.Index().SetUnique(true)}
 	name, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
+	if err != nil {
+		// the index from before timelines were unique has the same name
+		log.Info().Msgf("Replacing timeline index: %v", err)
+		if _, err := collection.Indexes().DropOne(context.TODO(), "userid_1"); err != nil {
+			log.Panic().Msgf("cannot drop timeline index: %v", err)
+		}
+		if name, err = collection.Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			log.Panic().Msgf("cannot create timeline index: %v", err)
+		}
+	}
 	log.Info().Msgf("Name of index created: %v", name)
 	log.Info().Msg("New mongo session successfull...")
 
@@ -116,6 +135,7 @@ func MakeTimelineSrv() *TimelineSrv {
 		mongoCo:      collection,
 		wCounter:     tracing.MakeCounter("Write-Timeline"),
 		rCounter:     tracing.MakeCounter("Read-Timeline"),
+		dCounter:     tracing.MakeCounter("Remove-Timeline"),
 	}
 }
 
@@ -135,6 +155,14 @@ func (tlsrv *TimelineSrv) Run() error {
 		return fmt.Errorf("dialer error: %v", err)
 	}
 	tlsrv.postc = postpb.NewPostStorageClient(conn)
+	graphConn, err := dialer.Dial(
+		graph.GRAPH_SRV_NAME,
+		tlsrv.Registry.Client,
+		dialer.WithTracer(tlsrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	tlsrv.graphc = graphpb.NewGraphClient(graphConn)
 
 	log.Info().Msg("Initializing gRPC Server...")
 	tlsrv.uuid = uuid.New().String()
@@ -179,10 +207,54 @@ func (tlsrv *TimelineSrv) WriteTimeline(
 	t0 := time.Now()
 	defer tlsrv.wCounter.AddTimeSince(t0)
 	res := &proto.WriteTimelineResponse{Ok: "No"}
-	_, err := tlsrv.mongoCo.UpdateOne(
-		context.TODO(), &bson.M{"userid": req.Userid}, 
-		&bson.M{"$push": bson.M{"postids": req.Postid, "timestamps": req.Timestamp}},
-		options.Update().SetUpsert(true))
+	// compose retries writes, so the filter only matches a timeline without
+	// the post. Where the post is already there, the upsert tries to insert a
+	// second timeline and fails on the unique index, as does a racing insert
+	// of the first one; tried again, the update finds the timeline in the
+	// latter case only.
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err = tlsrv.mongoCo.UpdateOne(
+			context.TODO(), &bson.M{"userid": req.Userid, "postids": bson.M{"$ne": req.Postid}},
+			&bson.M{"$push": bson.M{"postids": req.Postid, "timestamps": req.Timestamp}},
+			options.Update().SetUpsert(true))
+		if !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		res.Ok = TIMELINE_QUERY_OK
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.Ok = TIMELINE_QUERY_OK
+	key := TIMELINE_CACHE_PREFIX + strconv.FormatInt(req.Userid, 10)
+	if !tlsrv.cachec.Delete(ctx, key) {
+		log.Error().Msgf("cannot delete timeline of %v", key)
+	}
+	return res, nil
+}
+
+func (tlsrv *TimelineSrv) RemoveTimeline(
+		ctx context.Context, req *proto.RemoveTimelineRequest) (
+		*proto.WriteTimelineResponse, error) {
+	t0 := time.Now()
+	defer tlsrv.dCounter.AddTimeSince(t0)
+	res := &proto.WriteTimelineResponse{Ok: "No"}
+	timeline, err := tlsrv.getUserTimeline(ctx, req.Userid)
+	if err != nil {
+		return nil, err
+	}
+	// removing a post that is not there succeeds, so that deletes can be retried
+	if timeline == nil || !timeline.Remove(req.Postid) {
+		res.Ok = TIMELINE_QUERY_OK
+		return res, nil
+	}
+	_, err = tlsrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"userid": req.Userid},
+		&bson.M{"$set": bson.M{"postids": timeline.Postids, "timestamps": timeline.Timestamps}})
 	if err != nil {
 		return nil, err
 	}
@@ -205,14 +277,18 @@ func (tlsrv *TimelineSrv) ReadTimeline(
 		return nil, err
 	}
 	if timeline == nil {
-		res.Ok = "No timeline item"
+		res.Ok = TIMELINE_QUERY_EMPTY
 		return res, nil
 	}
 	start, stop, nItems := req.Start, req.Stop, int32(len(timeline.Postids))
-	if start >= int32(nItems) || start >= stop {
+	if start < 0 || start > stop {
 		res.Ok = fmt.Sprintf("Cannot process start=%v end=%v for %v items", start, stop, nItems)
 		return res, nil
-	}	
+	}
+	if start >= nItems || start == stop {
+		res.Ok = TIMELINE_QUERY_EMPTY
+		return res, nil
+	}
 	if stop > nItems {
 		stop = nItems
 	}
@@ -227,9 +303,45 @@ func (tlsrv *TimelineSrv) ReadTimeline(
 	}
 	res.Ok = readPostRes.Ok
 	res.Posts = readPostRes.Posts
+	if req.Hasviewer {
+		res.Posts, err = FilterHidden(ctx, tlsrv.graphc, req.Viewerid, res.Posts)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return res, nil
 }
 
+// FilterHidden drops the posts of users hidden from viewerid: those the viewer
+// blocks or mutes and those blocking the viewer.
+func FilterHidden(ctx context.Context, graphc graphpb.GraphClient, viewerid int64,
+		posts []*postpb.Post) ([]*postpb.Post, error) {
+	if len(posts) == 0 {
+		return posts, nil
+	}
+	hiddenRes, err := graphc.GetHidden(ctx, &graphpb.GetHiddenRequest{Userids: []int64{viewerid}})
+	if err != nil {
+		return nil, err
+	}
+	if hiddenRes.Ok != graph.GRAPH_QUERY_OK {
+		return nil, fmt.Errorf("cannot read hidden users of %v: %v", viewerid, hiddenRes.Ok)
+	}
+	if len(hiddenRes.Hidden[0].Userids) == 0 {
+		return posts, nil
+	}
+	hidden := make(map[int64]bool, len(hiddenRes.Hidden[0].Userids))
+	for _, userid := range hiddenRes.Hidden[0].Userids {
+		hidden[userid] = true
+	}
+	visible := make([]*postpb.Post, 0, len(posts))
+	for _, post := range posts {
+		if !hidden[post.Creator] {
+			visible = append(visible, post)
+		}
+	}
+	return visible, nil
+}
+
 func (tlsrv *TimelineSrv) getUserTimeline(ctx context.Context, userid int64) (*Timeline, error) {
 	key := TIMELINE_CACHE_PREFIX + strconv.FormatInt(userid, 10) 
 	timeline := &Timeline{}
@@ -265,3 +377,33 @@ type Timeline struct {
 	Timestamps []int64 `bson:timestamps`
 }
 
+// Remove drops every entry of postid from the timeline and reports whether
+// any entry was found.
+func (tl *Timeline) Remove(postid int64) bool {
+	found := false
+	postids := make([]int64, 0, len(tl.Postids))
+	timestamps := make([]int64, 0, len(tl.Timestamps))
+	for idx, pid := range tl.Postids {
+		if pid == postid {
+			found = true
+			continue
+		}
+		postids = append(postids, pid)
+		timestamps = append(timestamps, tl.Timestamps[idx])
+	}
+	tl.Postids, tl.Timestamps = postids, timestamps
+	return found
+}
+
+
+// ContainsRecent reports whether postid is among the last n entries of the
+// timeline. Retried writes land shortly after the first, so looking that
+// far back is enough to catch them without scanning the whole timeline.
+func (tl *Timeline) ContainsRecent(postid int64, n int) bool {
+	for idx := len(tl.Postids) - 1; idx >= 0 && idx >= len(tl.Postids)-n; idx-- {
+		if tl.Postids[idx] == postid {
+			return true
+		}
+	}
+	return false
+}
@@ -127,12 +127,14 @@ func (x *ComposeUrlsResponse) GetShorturls() []string {
 	return nil
 }
 
+// With click set, each resolved url counts as one visit of its short url.
 type GetUrlsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Shorturls []string `protobuf:"bytes,1,rep,name=shorturls,proto3" json:"shorturls,omitempty"`
+	Click     bool     `protobuf:"varint,2,opt,name=click,proto3" json:"click,omitempty"`
 }
 
 func (x *GetUrlsRequest) Reset() {
@@ -174,6 +176,13 @@ func (x *GetUrlsRequest) GetShorturls() []string {
 	return nil
 }
 
+func (x *GetUrlsRequest) GetClick() bool {
+	if x != nil {
+		return x.Click
+	}
+	return false
+}
+
 type GetUrlsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -229,6 +238,69 @@ func (x *GetUrlsResponse) GetExtendedurls() []string {
 	return nil
 }
 
+type GetUrlStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok           string   `protobuf:"bytes,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Extendedurls []string `protobuf:"bytes,2,rep,name=extendedurls,proto3" json:"extendedurls,omitempty"`
+	Clicks       []int64  `protobuf:"varint,3,rep,packed,name=clicks,proto3" json:"clicks,omitempty"`
+}
+
+func (x *GetUrlStatsResponse) Reset() {
+	*x = GetUrlStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_services_url_proto_url_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUrlStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUrlStatsResponse) ProtoMessage() {}
+
+func (x *GetUrlStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_services_url_proto_url_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUrlStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetUrlStatsResponse) Descriptor() ([]byte, []int) {
+	return file_services_url_proto_url_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUrlStatsResponse) GetOk() string {
+	if x != nil {
+		return x.Ok
+	}
+	return ""
+}
+
+func (x *GetUrlStatsResponse) GetExtendedurls() []string {
+	if x != nil {
+		return x.Extendedurls
+	}
+	return nil
+}
+
+func (x *GetUrlStatsResponse) GetClicks() []int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return nil
+}
+
 var File_services_url_proto_url_proto protoreflect.FileDescriptor
 
 var file_services_url_proto_url_proto_rawDesc = []byte{
@@ -242,24 +314,35 @@ var file_services_url_proto_url_proto_rawDesc = []byte{
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x02, 0x6f, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x75, 0x72, 0x6c,
 	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x75, 0x72,
-	0x6c, 0x73, 0x22, 0x2e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71,
+	0x6c, 0x73, 0x22, 0x44, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x75, 0x72, 0x6c,
 	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x75, 0x72,
-	0x6c, 0x73, 0x22, 0x45, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65,
-	0x64, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x74,
-	0x65, 0x6e, 0x64, 0x65, 0x64, 0x75, 0x72, 0x6c, 0x73, 0x32, 0x7d, 0x0a, 0x03, 0x55, 0x72, 0x6c,
-	0x12, 0x40, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x12,
-	0x17, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x55, 0x72, 0x6c,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x13, 0x2e,
-	0x75, 0x72, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x75, 0x72, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x69, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x63, 0x6c, 0x69, 0x63, 0x6b, 0x22, 0x45, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55,
+	0x72, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x75, 0x72, 0x6c, 0x73, 0x22,
+	0x61, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c,
+	0x69, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x63,
+	0x6b, 0x73, 0x32, 0xbb, 0x01, 0x0a, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x40, 0x0a, 0x0b, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x17, 0x2e, 0x75, 0x72, 0x6c, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65,
+	0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07,
+	0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x13, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x47, 0x65,
+	0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x75,
+	0x72, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x13, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x72, 0x6c, 0x2e, 0x47, 0x65, 0x74,
+	0x55, 0x72, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x75,
+	0x72, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -274,20 +357,23 @@ func file_services_url_proto_url_proto_rawDescGZIP() []byte {
 	return file_services_url_proto_url_proto_rawDescData
 }
 
-var file_services_url_proto_url_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_services_url_proto_url_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_services_url_proto_url_proto_goTypes = []interface{}{
 	(*ComposeUrlsRequest)(nil),  // 0: url.ComposeUrlsRequest
 	(*ComposeUrlsResponse)(nil), // 1: url.ComposeUrlsResponse
 	(*GetUrlsRequest)(nil),      // 2: url.GetUrlsRequest
 	(*GetUrlsResponse)(nil),     // 3: url.GetUrlsResponse
+	(*GetUrlStatsResponse)(nil), // 4: url.GetUrlStatsResponse
 }
 var file_services_url_proto_url_proto_depIdxs = []int32{
 	0, // 0: url.Url.ComposeUrls:input_type -> url.ComposeUrlsRequest
 	2, // 1: url.Url.GetUrls:input_type -> url.GetUrlsRequest
-	1, // 2: url.Url.ComposeUrls:output_type -> url.ComposeUrlsResponse
-	3, // 3: url.Url.GetUrls:output_type -> url.GetUrlsResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	2, // 2: url.Url.GetUrlStats:input_type -> url.GetUrlsRequest
+	1, // 3: url.Url.ComposeUrls:output_type -> url.ComposeUrlsResponse
+	3, // 4: url.Url.GetUrls:output_type -> url.GetUrlsResponse
+	4, // 5: url.Url.GetUrlStats:output_type -> url.GetUrlStatsResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -347,6 +433,18 @@ func file_services_url_proto_url_proto_init() {
 				return nil
 			}
 		}
+		file_services_url_proto_url_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUrlStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -354,7 +452,7 @@ func file_services_url_proto_url_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_services_url_proto_url_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Url_ComposeUrls_FullMethodName = "/url.Url/ComposeUrls"
 	Url_GetUrls_FullMethodName     = "/url.Url/GetUrls"
+	Url_GetUrlStats_FullMethodName = "/url.Url/GetUrlStats"
 )
 
 // UrlClient is the client API for Url service.
@@ -29,6 +30,7 @@ const (
 type UrlClient interface {
 	ComposeUrls(ctx context.Context, in *ComposeUrlsRequest, opts ...grpc.CallOption) (*ComposeUrlsResponse, error)
 	GetUrls(ctx context.Context, in *GetUrlsRequest, opts ...grpc.CallOption) (*GetUrlsResponse, error)
+	GetUrlStats(ctx context.Context, in *GetUrlsRequest, opts ...grpc.CallOption) (*GetUrlStatsResponse, error)
 }
 
 type urlClient struct {
@@ -57,12 +59,22 @@ func (c *urlClient) GetUrls(ctx context.Context, in *GetUrlsRequest, opts ...grp
 	return out, nil
 }
 
+func (c *urlClient) GetUrlStats(ctx context.Context, in *GetUrlsRequest, opts ...grpc.CallOption) (*GetUrlStatsResponse, error) {
+	out := new(GetUrlStatsResponse)
+	err := c.cc.Invoke(ctx, Url_GetUrlStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UrlServer is the server API for Url service.
 // All implementations must embed UnimplementedUrlServer
 // for forward compatibility
 type UrlServer interface {
 	ComposeUrls(context.Context, *ComposeUrlsRequest) (*ComposeUrlsResponse, error)
 	GetUrls(context.Context, *GetUrlsRequest) (*GetUrlsResponse, error)
+	GetUrlStats(context.Context, *GetUrlsRequest) (*GetUrlStatsResponse, error)
 	mustEmbedUnimplementedUrlServer()
 }
 
@@ -76,6 +88,9 @@ func (UnimplementedUrlServer) ComposeUrls(context.Context, *ComposeUrlsRequest)
 func (UnimplementedUrlServer) GetUrls(context.Context, *GetUrlsRequest) (*GetUrlsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUrls not implemented")
 }
+func (UnimplementedUrlServer) GetUrlStats(context.Context, *GetUrlsRequest) (*GetUrlStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUrlStats not implemented")
+}
 func (UnimplementedUrlServer) mustEmbedUnimplementedUrlServer() {}
 
 // UnsafeUrlServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +140,24 @@ func _Url_GetUrls_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Url_GetUrlStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUrlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UrlServer).GetUrlStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Url_GetUrlStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UrlServer).GetUrlStats(ctx, req.(*GetUrlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Url_ServiceDesc is the grpc.ServiceDesc for Url service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,6 +173,10 @@ var Url_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUrls",
 			Handler:    _Url_GetUrls_Handler,
 		},
+		{
+			MethodName: "GetUrlStats",
+			Handler:    _Url_GetUrlStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services/url/proto/url.proto",
@@ -1,6 +1,8 @@
 package url
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"math/rand"
 	"flag"
@@ -37,8 +39,10 @@ const (
 	URL_SRV_NAME = "srv-url"
 	URL_QUERY_OK = "OK"
 	URL_CACHE_PREFIX = "url_"
+	URL_REV_CACHE_PREFIX = "urlrev_"
 	URL_HOSTNAME = "http://short-url/"
 	URL_LENGTH = 10
+	URL_MAX_TRIES = 5
 )
 
 var urlPrefixL = len(URL_HOSTNAME)
@@ -67,6 +71,7 @@ type UrlSrv struct {
 	Port         int
 	IpAddr       string
 	cCounter     *tracing.Counter
+	gCounter     *tracing.Counter
 }
 
 func MakeUrlSrv() *UrlSrv {
@@ -117,8 +122,28 @@ func MakeUrlSrv() *UrlSrv {
 		log.Panic().Msg(err.Error())
 	}
 	collection := mongoClient.Database("socialnetwork").Collection("url")
-	indexModel := mongo.IndexModel{Keys: bson.D{{"shorturl", 1}}}
+	// unique indexes make code collisions and racing composes of the same
+	// url fail on insert instead of creating a second mapping
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "shorturl", Value: 1}}, Options: options.Index().SetUnique(true)}
 	name, err := collection.Indexes().CreateOne(context.TODO(), indexModel)
+	if err != nil {
+		// the index from before short urls were unique has the same name
+		log.Info().Msgf("Replacing url index: %v", err)
+		if _, err := collection.Indexes().DropOne(context.TODO(), "shorturl_1"); err != nil {
+			log.Panic().Msgf("cannot drop url index: %v", err)
+		}
+		if name, err = collection.Indexes().CreateOne(context.TODO(), indexModel); err != nil {
+			log.Panic().Msgf("cannot create url index: %v", err)
+		}
+	}
+	log.Info().Msgf("Name of index created: %v", name)
+	indexModel = mongo.IndexModel{
+		Keys: bson.D{{Key: "extendedurl", Value: 1}}, Options: options.Index().SetUnique(true)}
+	name, err = collection.Indexes().CreateOne(context.TODO(), indexModel)
+	if err != nil {
+		log.Panic().Msgf("cannot create url index: %v", err)
+	}
 	log.Info().Msgf("Name of index created: %v", name)
 	log.Info().Msg("New mongo session successfull...")
 	return &UrlSrv{
@@ -129,6 +154,7 @@ func MakeUrlSrv() *UrlSrv {
 		cachec:       cachec,
 		mongoCo:      collection,
 		cCounter:     tracing.MakeCounter("Compose-Url"),
+		gCounter:     tracing.MakeCounter("Get-Url"),
 	}
 }
 
@@ -190,21 +216,27 @@ func (urlsrv *UrlSrv) ComposeUrls(
 	}
 	res.Shorturls = make([]string, nUrls)
 	for idx, extendedurl := range req.Extendedurls {
-		shorturl := RandStringRunes(URL_LENGTH)
-		url := &Url{Extendedurl: extendedurl, Shorturl: shorturl}
-		if _, err := urlsrv.mongoCo.InsertOne(context.TODO(), url); err != nil {
-			log.Error().Msg(err.Error())
+		shorturl, err := urlsrv.getShortUrl(ctx, extendedurl)
+		if err != nil {
 			return nil, err
 		}
+		if shorturl == "" {
+			if shorturl, err = urlsrv.makeShortUrl(ctx, extendedurl); err != nil {
+				log.Error().Msg(err.Error())
+				return nil, err
+			}
+		}
 		res.Shorturls[idx] = URL_HOSTNAME + shorturl
 	} 
-	
+
 	res.Ok = URL_QUERY_OK
 	return res, nil
 }
 
 func (urlsrv *UrlSrv) GetUrls(
 		ctx context.Context, req *proto.GetUrlsRequest) (*proto.GetUrlsResponse, error) {
+	t0 := time.Now()
+	defer urlsrv.gCounter.AddTimeSince(t0)
 	log.Debug().Msgf("Received get request %v", req)
 	res := &proto.GetUrlsResponse{}
 	res.Ok = "No."
@@ -220,6 +252,9 @@ func (urlsrv *UrlSrv) GetUrls(
 			res.Ok = res.Ok + fmt.Sprintf(" Missing %v.", shorturl)
 		} else {
 			extendedurls[idx] = extendedurl	
+			if req.Click {
+				urlsrv.countClick(shorturl)
+			}
 		}
 	}
 	res.Extendedurls = extendedurls
@@ -229,6 +264,87 @@ func (urlsrv *UrlSrv) GetUrls(
 	return res, nil
 }
 
+// GetUrlStats reads click counts from the DB; cached urls do not track them.
+func (urlsrv *UrlSrv) GetUrlStats(
+		ctx context.Context, req *proto.GetUrlsRequest) (*proto.GetUrlStatsResponse, error) {
+	res := &proto.GetUrlStatsResponse{Ok: "No."}
+	res.Extendedurls = make([]string, len(req.Shorturls))
+	res.Clicks = make([]int64, len(req.Shorturls))
+	missing := false
+	for idx, shorturl := range req.Shorturls {
+		url := &Url{}
+		err := urlsrv.mongoCo.FindOne(
+			context.TODO(), &bson.M{"shorturl": strings.TrimPrefix(shorturl, URL_HOSTNAME)}).Decode(url)
+		if err != nil {
+			if err != mongo.ErrNoDocuments {
+				return nil, err
+			}
+			missing = true
+			res.Ok = res.Ok + fmt.Sprintf(" Missing %v.", shorturl)
+			continue
+		}
+		res.Extendedurls[idx] = url.Extendedurl
+		res.Clicks[idx] = url.Clicks
+	}
+	if !missing {
+		res.Ok = URL_QUERY_OK
+	}
+	return res, nil
+}
+
+// makeShortUrl stores a new code for extendedurl. A code that is already taken
+// is redrawn; if another compose stored the same url first, its code is used.
+func (urlsrv *UrlSrv) makeShortUrl(ctx context.Context, extendedurl string) (string, error) {
+	for try := 0; try < URL_MAX_TRIES; try++ {
+		url := &Url{Extendedurl: extendedurl, Shorturl: RandStringRunes(URL_LENGTH)}
+		_, err := urlsrv.mongoCo.InsertOne(context.TODO(), url)
+		if err == nil {
+			return url.Shorturl, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return "", err
+		}
+		shorturl, err := urlsrv.getShortUrl(ctx, extendedurl)
+		if err != nil || shorturl != "" {
+			return shorturl, err
+		}
+		log.Debug().Msgf("Short url %v taken, retrying", url.Shorturl)
+	}
+	return "", fmt.Errorf("no free short url for %v after %v tries", extendedurl, URL_MAX_TRIES)
+}
+
+// getShortUrl returns the code already assigned to extendedurl, if any. Urls
+// can be longer than a cache key may be, so the cache is keyed by their hash.
+func (urlsrv *UrlSrv) getShortUrl(ctx context.Context, extendedurl string) (string, error) {
+	sum := sha256.Sum256([]byte(extendedurl))
+	key := URL_REV_CACHE_PREFIX + hex.EncodeToString(sum[:])
+	if urlItem, err := urlsrv.cachec.Get(ctx, key); err != nil {
+		if err != memcache.ErrCacheMiss {
+			return "", err
+		}
+		url := &Url{}
+		err = urlsrv.mongoCo.FindOne(context.TODO(), &bson.M{"extendedurl": extendedurl}).Decode(url)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return "", nil
+			}
+			return "", err
+		}
+		urlsrv.cachec.Set(ctx, &memcache.Item{Key: key, Value: []byte(url.Shorturl)})
+		return url.Shorturl, nil
+	} else {
+		return string(urlItem.Value), nil
+	}
+}
+
+func (urlsrv *UrlSrv) countClick(shortUrl string) {
+	_, err := urlsrv.mongoCo.UpdateOne(
+		context.TODO(), &bson.M{"shorturl": shortUrl[urlPrefixL:]}, &bson.M{"$inc": bson.M{"clicks": 1}})
+	if err != nil {
+		log.Error().Msgf("cannot count click on %v: %v", shortUrl, err)
+	}
+}
+
 func (urlsrv *UrlSrv) getExtendedUrl(ctx context.Context, shortUrl string) (string, error) {
 	if !strings.HasPrefix(shortUrl, URL_HOSTNAME) {
 		log.Warn().Msgf("Url %v does not start with %v!", shortUrl, URL_HOSTNAME)
@@ -265,6 +381,7 @@ func (urlsrv *UrlSrv) getExtendedUrl(ctx context.Context, shortUrl string) (stri
 
 
 type Url struct {
-	Shorturl string    `bson:shorturl`
-	Extendedurl string `bson:extendedurl`
+	Shorturl string    `bson:"shorturl"`
+	Extendedurl string `bson:"extendedurl"`
+	Clicks int64       `bson:"clicks" json:"-"`
 }
@@ -2,27 +2,29 @@ package user
 
 import (
 	"encoding/json"
-	"crypto/sha256"
 	"flag"
 	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 	"fmt"
-	"math/rand"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"net"
-	"sync"
 	"net/http"
 	"net/http/pprof"
 	"github.com/google/uuid"
 	//"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"socialnetworkk8/registry"
+	"socialnetworkk8/snowflake"
+	"socialnetworkk8/dialer"
 	"socialnetworkk8/tune"
 	"socialnetworkk8/services/user/proto"
 	"socialnetworkk8/services/cacheclnt"
+	"socialnetworkk8/services/profile"
+	profilepb "socialnetworkk8/services/profile/proto"
 	"socialnetworkk8/tls"
 	opentracing "github.com/opentracing/opentracing-go"
 	"socialnetworkk8/tracing"
@@ -45,15 +47,14 @@ type UserSrv struct {
 	proto.UnimplementedUserServer
 	uuid   		 string
 	cachec       *cacheclnt.CacheClnt
+	profilec     profilepb.ProfileClient
 	mclnt        *mongo.Client
 	mongoCo      *mongo.Collection
 	Registry     *registry.Client
 	Tracer       opentracing.Tracer
 	Port         int
 	IpAddr       string
-	sid          int32 // sid is a random number between 0 and 2^30
-	ucount       int32 //This server may overflow with over 2^31 users
-    mu           sync.Mutex
+	idgen        *snowflake.Generator
 	dbCounter    *tracing.Counter
 	cacheCounter *tracing.Counter
 	loginCounter *tracing.Counter
@@ -137,8 +138,20 @@ func (usrv *UserSrv) Run() error {
 	if usrv.Port == 0 {
 		return fmt.Errorf("server port must be set")
 	}
+	conn, err := dialer.Dial(
+		profile.PROFILE_SRV_NAME,
+		usrv.Registry.Client,
+		dialer.WithTracer(usrv.Tracer))
+	if err != nil {
+		return fmt.Errorf("dialer error: %v", err)
+	}
+	usrv.profilec = profilepb.NewProfileClient(conn)
+
 	usrv.uuid = uuid.New().String()
-	usrv.sid = rand.Int31n(536870912) // 2^29
+	usrv.idgen, err = snowflake.MakeLeasedGenerator(usrv.Registry)
+	if err != nil {
+		return fmt.Errorf("cannot lease snowflake worker id: %v", err)
+	}
 	opts := []grpc.ServerOption{
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			Timeout: 120 * time.Second,
@@ -183,13 +196,14 @@ func (usrv *UserSrv) Run() error {
 func (usrv *UserSrv) Shutdown() {
 	usrv.mclnt.Disconnect(context.Background())
 	usrv.Registry.Deregister(usrv.uuid)
+	usrv.idgen.Close()
 }
 
 func (usrv *UserSrv) CheckUser(
 		ctx context.Context, req *proto.CheckUserRequest) (*proto.CheckUserResponse, error) {
 	t0 := time.Now()
 	defer usrv.checkCounter.AddTimeSince(t0)
-	log.Debug().Msgf("Checking user at %v: %v", usrv.sid, req.Usernames)
+	log.Debug().Msgf("Checking user at %v: %v", usrv.uuid, req.Usernames)
 	userids := make([]int64, len(req.Usernames))
 	res := &proto.CheckUserResponse{}
 	res.Ok = "No"
@@ -215,7 +229,7 @@ func (usrv *UserSrv) CheckUser(
 
 func (usrv *UserSrv) RegisterUser(
 		ctx context.Context, req *proto.RegisterUserRequest) (*proto.UserResponse, error) {
-	log.Debug().Msgf("Register user at %v: %v", usrv.sid, req)
+	log.Debug().Msgf("Register user at %v: %v", usrv.uuid, req)
 	res := &proto.UserResponse{}
 	res.Ok = "No"
 	user, err := usrv.getUserbyUname(ctx, req.Username)
@@ -226,8 +240,14 @@ func (usrv *UserSrv) RegisterUser(
 		res.Ok = fmt.Sprintf("Username %v already exist", req.Username)
 		return res, nil
 	}
-	pswd_hashed := fmt.Sprintf("%x", sha256.Sum256([]byte(req.Password)))
-	userid := usrv.getNextUserId()
+	pswd_hashed, err := HashPassword(req.Password)
+	if err != nil {
+		return res, err
+	}
+	userid, err := usrv.getNextUserId()
+	if err != nil {
+		return res, err
+	}
 	newUser := User{
 		Userid: userid,
 		Username: req.Username,
@@ -238,6 +258,19 @@ func (usrv *UserSrv) RegisterUser(
 		log.Error().Msg(err.Error())
 		return res, err
 	}
+	displayname := strings.TrimSpace(req.Firstname + " " + req.Lastname)
+	if displayname == "" {
+		displayname = req.Username
+	}
+	// the account exists either way, and failing here would make a retry
+	// fail as a duplicate; a missing profile only loses the display name
+	profileRes, err := usrv.profilec.CreateProfile(ctx, &profilepb.CreateProfileRequest{
+		Userid: userid, Username: req.Username, Displayname: displayname})
+	if err != nil {
+		log.Error().Msgf("Cannot create profile of %v: %v", userid, err)
+	} else if profileRes.Ok != profile.PROFILE_QUERY_OK {
+		log.Error().Msgf("Cannot create profile of %v: %v", userid, profileRes.Ok)
+	}
 	res.Ok = USER_QUERY_OK
 	res.Userid = userid
 	return res, nil
@@ -246,21 +279,49 @@ func (usrv *UserSrv) RegisterUser(
 func (usrv *UserSrv) Login(
 		ctx context.Context, req *proto.LoginRequest) (*proto.UserResponse, error) {
 	t0 := time.Now()
-	log.Debug().Msgf("User login with %v: %v", usrv.sid, req)
+	log.Debug().Msgf("User login with %v: %v", usrv.uuid, req)
 	res := &proto.UserResponse{}
 	res.Ok = "Login Failure."
 	user, err := usrv.getUserbyUname(ctx, req.Username)
 	if err != nil {
 		return res, err
 	}
-	if user != nil && fmt.Sprintf("%x", sha256.Sum256([]byte(req.Password))) == user.Password {
-		res.Ok = USER_QUERY_OK
-		res.Userid = user.Userid
+	if user != nil {
+		ok, rehash := VerifyPassword(req.Password, user.Password)
+		if ok {
+			res.Ok = USER_QUERY_OK
+			res.Userid = user.Userid
+			if rehash {
+				usrv.rehashPassword(ctx, user, req.Password)
+			}
+		}
 	}
 	usrv.loginCounter.AddTimeSince(t0)
 	return res, nil
 }
 
+// rehashPassword upgrades a stored password hash to the current format. The
+// login already succeeded, so failures are only logged and retried on the
+// next login.
+func (usrv *UserSrv) rehashPassword(ctx context.Context, user *User, password string) {
+	pswd_hashed, err := HashPassword(password)
+	if err != nil {
+		log.Error().Msgf("Cannot rehash password of %v: %v", user.Username, err)
+		return
+	}
+	// only replace the hash that was verified, in case the password changed meanwhile
+	_, err = usrv.mongoCo.UpdateOne(context.TODO(),
+		&bson.M{"username": user.Username, "password": user.Password},
+		&bson.M{"$set": bson.M{"password": pswd_hashed}})
+	if err != nil {
+		log.Error().Msgf("Cannot rehash password of %v: %v", user.Username, err)
+		return
+	}
+	if !usrv.cachec.Delete(ctx, USER_CACHE_PREFIX+user.Username) {
+		log.Error().Msgf("cannot delete user %v from cache", user.Username)
+	}
+}
+
 func (usrv *UserSrv) getUserbyUname(ctx context.Context, username string) (*User, error) {
 	key := USER_CACHE_PREFIX + username
 	user := &User{}
@@ -303,14 +364,7 @@ type User struct {
 	Password  string `bson:password`
 }
 
-func (usrv *UserSrv) incCountSafe() int32 {
-	usrv.mu.Lock()
-	defer usrv.mu.Unlock()
-	usrv.ucount++
-	return usrv.ucount
-}
-
-func (usrv *UserSrv) getNextUserId() int64 {
-	return int64(usrv.sid)*1e10 + int64(usrv.incCountSafe())
+func (usrv *UserSrv) getNextUserId() (int64, error) {
+	return usrv.idgen.Next()
 }
 
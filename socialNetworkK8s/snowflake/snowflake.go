@@ -0,0 +1,116 @@
+package snowflake
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"socialnetworkk8/registry"
+)
+
+// Ids are laid out as 1 unused sign bit, 41 bits of milliseconds since
+// EPOCH_MS (good for ~69 years), 10 bits of worker id and 12 bits of
+// sequence, so they sort by creation time and no two workers can make the
+// same id.
+const (
+	EPOCH_MS = 1577836800000 // 2020-01-01T00:00:00Z
+	WORKER_BITS = 10
+	SEQUENCE_BITS = 12
+	MAX_WORKERS = 1 << WORKER_BITS
+	MAX_SEQUENCE = 1<<SEQUENCE_BITS - 1
+	// how far ahead of the clock a generator may run, after the clock went
+	// back or a millisecond's sequence ran out; must stay below LEASE_TTL
+	MAX_DRIFT_MS = 1000
+	LEASE_PREFIX = "snowflake/workers"
+	LEASE_TTL = 15 * time.Second
+)
+
+// Generator makes unique, increasing ids for one worker id. It is safe for
+// concurrent use.
+type Generator struct {
+	mu       sync.Mutex
+	workerid int64
+	lastms   int64
+	seq      int64
+	lease    *registry.Lease
+	now      func() int64
+}
+
+// MakeGenerator returns a generator for a fixed worker id. The caller must
+// make sure no other generator uses the same worker id at the same time.
+func MakeGenerator(workerid int64) (*Generator, error) {
+	if workerid < 0 || workerid >= MAX_WORKERS {
+		return nil, fmt.Errorf("snowflake: worker id %v not in [0, %v)", workerid, MAX_WORKERS)
+	}
+	return &Generator{workerid: workerid, lastms: -1, now: nowMs}, nil
+}
+
+// MakeLeasedGenerator returns a generator whose worker id is leased through
+// the registry for as long as the process runs.
+func MakeLeasedGenerator(reg *registry.Client) (*Generator, error) {
+	lease, err := reg.LeaseId(LEASE_PREFIX, MAX_WORKERS, LEASE_TTL)
+	if err != nil {
+		return nil, err
+	}
+	g, err := MakeGenerator(lease.Id)
+	if err != nil {
+		lease.Release()
+		return nil, err
+	}
+	g.lease = lease
+	return g, nil
+}
+
+func nowMs() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+// Next returns a new id, greater than all ids this generator returned
+// before. It fails if the worker id lease was lost or the clock went back
+// by more than MAX_DRIFT_MS.
+func (g *Generator) Next() (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.lease != nil && !g.lease.Held() {
+		return 0, fmt.Errorf("snowflake: lease of worker id %v lost", g.workerid)
+	}
+	now := g.now() - EPOCH_MS
+	if now < g.lastms {
+		// keep counting from where we were rather than block the caller
+		if g.lastms-now > MAX_DRIFT_MS {
+			return 0, fmt.Errorf("snowflake: clock is %vms behind the last id", g.lastms-now)
+		}
+		now = g.lastms
+	}
+	if now == g.lastms {
+		g.seq++
+		if g.seq > MAX_SEQUENCE {
+			if now+1-(g.now()-EPOCH_MS) > MAX_DRIFT_MS {
+				return 0, fmt.Errorf("snowflake: too many ids ahead of the clock")
+			}
+			now++
+			g.seq = 0
+		}
+	} else {
+		g.seq = 0
+	}
+	g.lastms = now
+	return now<<(WORKER_BITS+SEQUENCE_BITS) | g.workerid<<SEQUENCE_BITS | g.seq, nil
+}
+
+// Workerid returns the worker id the generator makes ids for.
+func (g *Generator) Workerid() int64 {
+	return g.workerid
+}
+
+// Close gives back a leased worker id; the generator must not be used after.
+func (g *Generator) Close() {
+	if g.lease != nil {
+		g.lease.Release()
+	}
+}
+
+// Decompose splits an id into its creation time, worker id and sequence.
+func Decompose(id int64) (created time.Time, workerid int64, seq int64) {
+	ms := id>>(WORKER_BITS+SEQUENCE_BITS) + EPOCH_MS
+	return time.Unix(0, ms*int64(time.Millisecond)), id >> SEQUENCE_BITS & (MAX_WORKERS - 1), id & MAX_SEQUENCE
+}
@@ -0,0 +1,127 @@
+package snowflake
+
+import (
+	"sync"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	NGENERATORS = 8
+	NROUTINES = 4
+	NIDS = 20000
+)
+
+func TestWorkerRange(t *testing.T) {
+	_, err := MakeGenerator(-1)
+	assert.NotNil(t, err)
+	_, err = MakeGenerator(MAX_WORKERS)
+	assert.NotNil(t, err)
+	g, err := MakeGenerator(MAX_WORKERS - 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(MAX_WORKERS-1), g.Workerid())
+}
+
+func TestMonotonic(t *testing.T) {
+	g, err := MakeGenerator(1)
+	assert.Nil(t, err)
+	last := int64(-1)
+	for i := 0; i < NIDS*NROUTINES; i++ {
+		id, err := g.Next()
+		assert.Nil(t, err)
+		if id <= last {
+			t.Fatalf("id %v after %v", id, last)
+		}
+		last = id
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	g, err := MakeGenerator(42)
+	assert.Nil(t, err)
+	before := time.Now().Add(-time.Millisecond)
+	id, err := g.Next()
+	assert.Nil(t, err)
+	created, workerid, seq := Decompose(id)
+	assert.Equal(t, int64(42), workerid)
+	assert.Equal(t, int64(0), seq)
+	assert.False(t, created.Before(before))
+	assert.False(t, created.After(time.Now()))
+}
+
+func TestSequenceOverflow(t *testing.T) {
+	g, err := MakeGenerator(3)
+	assert.Nil(t, err)
+	g.now = func() int64 { return EPOCH_MS + 1000 }
+	last := int64(-1)
+	for i := 0; i <= 2*MAX_SEQUENCE+1; i++ {
+		id, err := g.Next()
+		assert.Nil(t, err)
+		assert.Greater(t, id, last)
+		last = id
+	}
+	// the stuck clock let the generator borrow one millisecond
+	created, _, seq := Decompose(last)
+	assert.Equal(t, int64(EPOCH_MS+1001), created.UnixNano()/int64(time.Millisecond))
+	assert.Equal(t, int64(MAX_SEQUENCE), seq)
+}
+
+func TestClockBackwards(t *testing.T) {
+	g, err := MakeGenerator(5)
+	assert.Nil(t, err)
+	clock := int64(EPOCH_MS + 5000)
+	g.now = func() int64 { return clock }
+	first, err := g.Next()
+	assert.Nil(t, err)
+	clock -= 10
+	second, err := g.Next()
+	assert.Nil(t, err)
+	assert.Greater(t, second, first)
+	clock -= MAX_DRIFT_MS
+	_, err = g.Next()
+	assert.NotNil(t, err)
+	clock += MAX_DRIFT_MS + 20
+	third, err := g.Next()
+	assert.Nil(t, err)
+	assert.Greater(t, third, second)
+}
+
+func TestUniqueConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[int64]bool, NGENERATORS*NROUTINES*NIDS)
+	for w := 0; w < NGENERATORS; w++ {
+		g, err := MakeGenerator(int64(w))
+		assert.Nil(t, err)
+		for r := 0; r < NROUTINES; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ids := make([]int64, NIDS)
+				for i := range ids {
+					id, err := g.Next()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					// each caller sees its own ids increase too
+					if i > 0 && id <= ids[i-1] {
+						t.Errorf("id %v after %v", id, ids[i-1])
+					}
+					ids[i] = id
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, id := range ids {
+					if seen[id] {
+						t.Errorf("duplicate id %v", id)
+					}
+					seen[id] = true
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, NGENERATORS*NROUTINES*NIDS, len(seen))
+}
@@ -8,11 +8,18 @@ import (
 	"context"
 	"strings"
 	urlpb "socialnetworkk8/services/url/proto"
+	"socialnetworkk8/services/text"
 	textpb "socialnetworkk8/services/text/proto"
 	composepb "socialnetworkk8/services/compose/proto"
 	tlpb "socialnetworkk8/services/timeline/proto"
 	homepb "socialnetworkk8/services/home/proto"
 	postpb "socialnetworkk8/services/post/proto"
+	dmpb "socialnetworkk8/services/dm/proto"
+	hashtagpb "socialnetworkk8/services/hashtag/proto"
+	notifpb "socialnetworkk8/services/notification/proto"
+	mediapb "socialnetworkk8/services/media/proto"
+	idgenpb "socialnetworkk8/services/idgen/proto"
+	"socialnetworkk8/snowflake"
 )
 
 func TestUrl(t *testing.T) {
@@ -45,6 +52,31 @@ func TestUrl(t *testing.T) {
 	assert.Equal(t, url1, res_get.Extendedurls[0])
 	assert.Equal(t, url2, res_get.Extendedurls[1])
 
+	// the same url keeps its short url
+	arg_url = &urlpb.ComposeUrlsRequest{Extendedurls: []string{url2, url2}}
+	res_url, err = urlClient.ComposeUrls(context.Background(), arg_url)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_url.Ok)
+	assert.Equal(t, []string{shortUrl2, shortUrl2}, res_url.Shorturls)
+
+	// only clicks are counted
+	arg_get = &urlpb.GetUrlsRequest{Shorturls: []string{shortUrl2}, Click: true}
+	for i := 0; i < 3; i++ {
+		res_get, err = urlClient.GetUrls(context.Background(), arg_get)
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_get.Ok)
+	}
+	arg_stats := &urlpb.GetUrlsRequest{Shorturls: []string{shortUrl1, shortUrl2}}
+	res_stats, err := urlClient.GetUrlStats(context.Background(), arg_stats)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_stats.Ok)
+	assert.Equal(t, []string{url1, url2}, res_stats.Extendedurls)
+	assert.Equal(t, []int64{0, 3}, res_stats.Clicks)
+	arg_stats.Shorturls = []string{"http://short-url/nosuchcode"}
+	res_stats, err = urlClient.GetUrlStats(context.Background(), arg_stats)
+	assert.Nil(t, err)
+	assert.Equal(t, "No. Missing http://short-url/nosuchcode.", res_stats.Ok)
+
 	// Stop fowarding
 	assert.Nil(t, fcmd.Process.Kill())
 }
@@ -88,6 +120,43 @@ func TestText(t *testing.T) {
 	expectedText := fmt.Sprintf("First post! @user_1@user_2 %v @user_4 %v Over!", sUrl1, sUrl2)
 	assert.Equal(t, expectedText, res_text.Text)
 
+	// hashtags are lower-cased and de-duplicated; url fragments and C#x are not tags
+	arg_text.Text = "#Go and #go_lang in C#x http://www.google.com/#frag #GO"
+	res_text, err = textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_text.Ok)
+	assert.Equal(t, []string{"go", "go_lang"}, res_text.Hashtags)
+
+	// urls leave closing punctuation to the sentence, emails are no mentions,
+	// and entities are located in the rewritten text
+	arg_text.Text = "Café (see http://www.google.com/a). Ask bob@example.com, @José or @user_3!"
+	res_text, err = textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_text.Ok)
+	assert.Equal(t, []int64{3}, res_text.Usermentions)
+	assert.Equal(t, 1, len(res_text.Urls))
+	sUrl := res_text.Urls[0]
+	expectedText = fmt.Sprintf("Café (see %v). Ask bob@example.com, @José or @user_3!", sUrl)
+	assert.Equal(t, expectedText, res_text.Text)
+	assert.Equal(t, 3, len(res_text.Entities))
+	urlEntity := res_text.Entities[0]
+	assert.Equal(t, textpb.ENTITY_TYPE_URL, urlEntity.Type)
+	assert.Equal(t, sUrl, urlEntity.Value)
+	assert.Equal(t, "http://www.google.com/a", urlEntity.Expandedurl)
+	assert.Equal(t, int32(11), urlEntity.Start)
+	assert.Equal(t, int32(10), urlEntity.Runestart)
+	assert.Equal(t, sUrl, res_text.Text[urlEntity.Start:urlEntity.End])
+	assert.Equal(t, urlEntity.Runeend - urlEntity.Runestart, urlEntity.End - urlEntity.Start)
+	for idx, username := range []string{"José", "user_3"} {
+		mention := res_text.Entities[idx+1]
+		assert.Equal(t, textpb.ENTITY_TYPE_MENTION, mention.Type)
+		assert.Equal(t, username, mention.Value)
+		assert.Equal(t, "@" + username, res_text.Text[mention.Start:mention.End])
+		assert.Equal(t, int32(len([]rune(res_text.Text[:mention.Start]))), mention.Runestart)
+	}
+	assert.Equal(t, int64(-1), res_text.Entities[1].Userid)
+	assert.Equal(t, int64(3), res_text.Entities[2].Userid)
+
 	// check urls
 	urlTestPort := "9001"
 	ufcmd, err := StartFowarding("url", urlTestPort, "8087")
@@ -110,6 +179,81 @@ func TestText(t *testing.T) {
 	assert.Nil(t, ufcmd.Process.Kill())
 }
 
+// TestTextModeration relies on the rules in moderation.example.json, which
+// the text service must be started with as its ModerationConfig.
+func TestTextModeration(t *testing.T) {
+	// start forwarding
+	testPort, composeTestPort := "9000", "9001"
+	fcmd, err := StartFowarding("text", testPort, "8088")
+	assert.Nil(t, err)
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	conn, err := dialer.Dial("localhost:" + testPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	textClient := textpb.NewTextClient(conn)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+
+	// masked terms are found through case, leetspeak, accents and look-alikes
+	arg_text := &textpb.ProcessTextRequest{Text: "Oh DARN, h3ck, dárn and dаrn (cyrillic a), not darned"}
+	res_text, err := textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_text.Ok)
+	assert.Equal(t, textpb.MODERATION_ACTION_MASK, res_text.Moderation)
+	assert.Equal(t, []string{"mask: term list profanity"}, res_text.Moderationreasons)
+	assert.Equal(t, "Oh ****, ****, **** and **** (cyrillic a), not darned", res_text.Text)
+
+	// flagged text goes through untouched
+	arg_text.Text = "Big giveaway today"
+	res_text, err = textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_text.Ok)
+	assert.Equal(t, textpb.MODERATION_ACTION_FLAG, res_text.Moderation)
+	assert.Equal(t, "Big giveaway today", res_text.Text)
+
+	// rejections win over other rules, and blocked domains include subdomains
+	arg_text.Text = "Heck, see http://login.phishing.test/x"
+	res_text, err = textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, text.TEXT_QUERY_REJECTED, res_text.Ok)
+	assert.Equal(t, textpb.MODERATION_ACTION_REJECT, res_text.Moderation)
+	assert.Equal(t, []string{"mask: term list profanity", "reject: blocked domain phishing.test"},
+		res_text.Moderationreasons)
+	arg_text.Text = strings.Repeat("a", 5001)
+	res_text, err = textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, textpb.MODERATION_ACTION_REJECT, res_text.Moderation)
+	assert.Equal(t, []string{"reject: length 5001 over 5000"}, res_text.Moderationreasons)
+
+	// compose refuses rejected posts and keeps the flags of the others
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(3), Username: "user_3", Posttype: postpb.POST_TYPE_POST, Text: "Buy F0LLOWERS here"}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "No Moderation: reject: term list spam", res_compose.Ok)
+	arg_compose.Text = "Giveaway, darn it"
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	postFcmd, err := StartFowarding("post", "9002", "8086")
+	assert.Nil(t, err)
+	postConn, err := dialer.Dial("localhost:9002", nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	postClient := postpb.NewPostStorageClient(postConn)
+	res_read, err := postClient.ReadPosts(
+		context.Background(), &postpb.ReadPostsRequest{Postids: []int64{res_compose.Postid}})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, "Giveaway, **** it", res_read.Posts[0].Text)
+	assert.Equal(t, []string{"mask: term list profanity", "flag: term list review"}, res_read.Posts[0].Flags)
+
+	// Stop forwarding
+	assert.Nil(t, fcmd.Process.Kill())
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, postFcmd.Process.Kill())
+}
+
 func TestCompose(t *testing.T) {
 	// start forwarding
 	composeTestPort, tlTestPort, homeTestPort := "9000", "9001", "9002"
@@ -194,3 +338,614 @@ func TestCompose(t *testing.T) {
 	assert.Nil(t, tfcmd.Process.Kill())
 	assert.Nil(t, hfcmd.Process.Kill())
 }
+
+func TestComposeReply(t *testing.T) {
+	// start forwarding
+	composeTestPort, tlTestPort := "9000", "9001"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	tfcmd, err := StartFowarding("timeline", tlTestPort, "8089")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	assert.NotNil(t, composeClient)
+	tlConn, err := dialer.Dial("localhost:" + tlTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	tlClient := tlpb.NewTimelineClient(tlConn)
+	assert.NotNil(t, tlClient)
+
+	// replying to a post that does not exist fails
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(5), Posttype: postpb.POST_TYPE_REPLY, Text: "Reply to nothing", Parentid: 123456789}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(res_compose.Ok, "No Thread Error: "))
+
+	// post, then reply to it and reply to the reply
+	arg_compose = &composepb.ComposePostRequest{
+		Userid: int64(5), Posttype: postpb.POST_TYPE_POST, Text: "Thread root"}
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	arg_tl := &tlpb.ReadTimelineRequest{Userid: int64(5), Start: int32(0), Stop: int32(1)}
+	res_tl, err := tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_tl.Ok)
+	rootid := res_tl.Posts[0].Postid
+
+	arg_compose.Posttype, arg_compose.Text, arg_compose.Parentid =
+		postpb.POST_TYPE_REPLY, "First reply", rootid
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	res_tl, err = tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	reply := res_tl.Posts[0]
+	assert.Equal(t, rootid, reply.Parentid)
+	assert.Equal(t, rootid, reply.Rootid)
+
+	arg_compose.Text, arg_compose.Parentid = "Second reply", reply.Postid
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	res_tl, err = tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, reply.Postid, res_tl.Posts[0].Parentid)
+	assert.Equal(t, rootid, res_tl.Posts[0].Rootid)
+
+	// a mismatched root is rejected
+	arg_compose.Rootid = reply.Postid
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(res_compose.Ok, "No Thread Error: "))
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, tfcmd.Process.Kill())
+}
+
+func TestComposeDm(t *testing.T) {
+	// start forwarding
+	composeTestPort, tlTestPort, dmTestPort := "9000", "9001", "9002"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	tfcmd, err := StartFowarding("timeline", tlTestPort, "8089")
+	assert.Nil(t, err)
+	dfcmd, err := StartFowarding("dm", dmTestPort, "8092")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	assert.NotNil(t, composeClient)
+	tlConn, err := dialer.Dial("localhost:" + tlTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	tlClient := tlpb.NewTimelineClient(tlConn)
+	assert.NotNil(t, tlClient)
+	dmConn, err := dialer.Dial("localhost:" + dmTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	dmClient := dmpb.NewDmClient(dmConn)
+	assert.NotNil(t, dmClient)
+
+	// a DM needs a recipient
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(6), Username: "user_6", Posttype: postpb.POST_TYPE_DM, Text: "Hello nobody"}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(res_compose.Ok, "No DM Error: "))
+
+	// two DMs from user_6 to user_7
+	arg_compose.Text = "Secret one @user_7"
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	arg_compose.Text = "Secret two @user_7"
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+
+	// DMs never reach the sender's timeline
+	arg_tl := &tlpb.ReadTimelineRequest{Userid: int64(6), Start: int32(0), Stop: int32(10)}
+	res_tl, err := tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	for _, post := range res_tl.Posts {
+		assert.False(t, strings.HasPrefix(post.Text, "Secret"))
+	}
+
+	// both participants see the conversation
+	arg_list := &dmpb.ListConversationsRequest{Userid: int64(7), Start: int32(0), Stop: int32(10)}
+	res_list, err := dmClient.ListConversations(context.Background(), arg_list)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_list.Ok)
+	assert.Equal(t, 1, len(res_list.Conversations))
+	conv := res_list.Conversations[0]
+	assert.Equal(t, "6-7", conv.Conversationid)
+	assert.Equal(t, int64(2), conv.Nmessages)
+	assert.True(t, strings.HasPrefix(conv.Lastpreview, "Secret two"))
+	arg_list.Userid = int64(6)
+	res_list, err = dmClient.ListConversations(context.Background(), arg_list)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_list.Ok)
+	assert.Equal(t, 1, len(res_list.Conversations))
+	arg_list.Start = -1
+	res_list, err = dmClient.ListConversations(context.Background(), arg_list)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_list.Ok)
+	assert.Equal(t, 0, len(res_list.Conversations))
+	arg_list.Start = 0
+
+	// messages are returned newest first, only to participants
+	arg_read := &dmpb.ReadConversationRequest{
+		Userid: int64(7), Conversationid: conv.Conversationid, Start: int32(0), Stop: int32(10)}
+	res_read, err := dmClient.ReadConversation(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, 2, len(res_read.Messages))
+	assert.True(t, strings.HasPrefix(res_read.Messages[0].Text, "Secret two"))
+	assert.True(t, strings.HasPrefix(res_read.Messages[1].Text, "Secret one"))
+	assert.Equal(t, int64(6), res_read.Messages[0].Senderid)
+	arg_read.Userid = int64(8)
+	res_read, err = dmClient.ReadConversation(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_read.Ok)
+	assert.Equal(t, 0, len(res_read.Messages))
+
+	// previews are cut between characters
+	arg_compose.Text = "@user_7 " + strings.Repeat("é", 100)
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	res_list, err = dmClient.ListConversations(context.Background(), arg_list)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_list.Ok)
+	assert.Equal(t, "@user_7 " + strings.Repeat("é", 56), res_list.Conversations[0].Lastpreview)
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, tfcmd.Process.Kill())
+	assert.Nil(t, dfcmd.Process.Kill())
+}
+
+func TestComposeIdempotent(t *testing.T) {
+	// start forwarding
+	composeTestPort, tlTestPort := "9000", "9001"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	tfcmd, err := StartFowarding("timeline", tlTestPort, "8089")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	tlConn, err := dialer.Dial("localhost:" + tlTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	tlClient := tlpb.NewTimelineClient(tlConn)
+
+	arg_tl := &tlpb.ReadTimelineRequest{Userid: int64(9), Start: int32(0), Stop: int32(100)}
+	res_tl, err := tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	nposts := len(res_tl.Posts)
+
+	// a retried request returns the first result and composes nothing
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(9), Posttype: postpb.POST_TYPE_POST, Text: "Posted once", Idempotencykey: "retry-1"}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	assert.False(t, res_compose.Replayed)
+	postid := res_compose.Postid
+	assert.NotEqual(t, int64(0), postid)
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	assert.True(t, res_compose.Replayed)
+	assert.Equal(t, postid, res_compose.Postid)
+	res_tl, err = tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, nposts+1, len(res_tl.Posts))
+	assert.Equal(t, postid, res_tl.Posts[0].Postid)
+
+	// the key cannot be reused for another request
+	arg_compose.Text = "Posted twice"
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "No Idempotency key was used for a different request.", res_compose.Ok)
+
+	// keys are per user, and requests without a key are never deduplicated
+	arg_compose.Userid = int64(8)
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	assert.False(t, res_compose.Replayed)
+	assert.NotEqual(t, postid, res_compose.Postid)
+	arg_compose.Userid = int64(9)
+	arg_compose.Idempotencykey = ""
+	for i := 0; i < 2; i++ {
+		// the same text twice would be refused as a duplicate
+		arg_compose.Text = fmt.Sprintf("Posted without key %v", i)
+		res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_compose.Ok)
+	}
+	res_tl, err = tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, nposts+3, len(res_tl.Posts))
+
+	// Stop fowarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, tfcmd.Process.Kill())
+}
+
+func TestComposeOutbox(t *testing.T) {
+	// start forwarding
+	composeTestPort, tlTestPort, hashtagTestPort := "9000", "9001", "9002"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	tfcmd, err := StartFowarding("timeline", tlTestPort, "8089")
+	assert.Nil(t, err)
+	hfcmd, err := StartFowarding("hashtag", hashtagTestPort, "8094")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	tlConn, err := dialer.Dial("localhost:" + tlTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	tlClient := tlpb.NewTimelineClient(tlConn)
+	hashtagConn, err := dialer.Dial("localhost:" + hashtagTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	hashtagClient := hashtagpb.NewHashtagClient(hashtagConn)
+
+	// a compose whose steps all succeed is not left pending
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(9), Username: "user_9", Posttype: postpb.POST_TYPE_POST, Text: "Once #outbox"}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	assert.False(t, res_compose.Pending)
+	postid := res_compose.Postid
+	arg_tl := &tlpb.ReadTimelineRequest{Userid: int64(9), Start: int32(0), Stop: int32(100)}
+	res_tl, err := tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	nposts := len(res_tl.Posts)
+	assert.Equal(t, postid, res_tl.Posts[0].Postid)
+
+	// steps are retried, so repeating them must not write the post twice
+	arg_write := &tlpb.WriteTimelineRequest{
+		Userid: int64(9), Postid: postid, Timestamp: res_tl.Posts[0].Timestamp}
+	res_write, err := tlClient.WriteTimeline(context.Background(), arg_write)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_write.Ok)
+	res_tl, err = tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, nposts, len(res_tl.Posts))
+	arg_tag := &hashtagpb.WriteHashtagsRequest{
+		Hashtags: []string{"outbox"}, Postid: postid, Timestamp: res_tl.Posts[0].Timestamp}
+	res_tag, err := hashtagClient.WriteHashtags(context.Background(), arg_tag)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_tag.Ok)
+	arg_read := &hashtagpb.ReadHashtagTimelineRequest{Hashtag: "outbox", Start: 0, Stop: 10}
+	res_read, err := hashtagClient.ReadHashtagTimeline(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, int32(1), res_read.Nitems)
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, tfcmd.Process.Kill())
+	assert.Nil(t, hfcmd.Process.Kill())
+}
+
+func TestComposeRateLimit(t *testing.T) {
+	// start forwarding
+	composeTestPort := "9000"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+
+	// repeating a recent post is refused, whatever its case and spacing
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(7), Username: "user_7", Posttype: postpb.POST_TYPE_POST, Text: "Buy now"}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	assert.False(t, res_compose.Ratelimited)
+	postid := res_compose.Postid
+	arg_compose.Text = "  buy   NOW "
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.True(t, res_compose.Ratelimited)
+	assert.Equal(t, fmt.Sprintf("No Rate limit: Duplicate of post %v.", postid), res_compose.Ok)
+	assert.True(t, res_compose.Retryafter > 0 && res_compose.Retryafter <= 60)
+
+	// a refused request is not replayed; its retry is checked again
+	arg_compose.Idempotencykey = "dup-1"
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.True(t, res_compose.Ratelimited)
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.True(t, res_compose.Ratelimited)
+	assert.False(t, res_compose.Replayed)
+	arg_compose.Text, arg_compose.Idempotencykey = "Buy later", "dup-2"
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	assert.False(t, res_compose.Replayed)
+
+	// other users may say the same thing
+	arg_compose.Userid, arg_compose.Username, arg_compose.Text = int64(6), "user_6", "Buy now"
+	arg_compose.Idempotencykey = ""
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+}
+
+func TestComposeHashtag(t *testing.T) {
+	// start forwarding
+	composeTestPort, tlTestPort, hashtagTestPort := "9000", "9001", "9002"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	tfcmd, err := StartFowarding("timeline", tlTestPort, "8089")
+	assert.Nil(t, err)
+	hfcmd, err := StartFowarding("hashtag", hashtagTestPort, "8094")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	assert.NotNil(t, composeClient)
+	tlConn, err := dialer.Dial("localhost:" + tlTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	tlClient := tlpb.NewTimelineClient(tlConn)
+	assert.NotNil(t, tlClient)
+	hashtagConn, err := dialer.Dial("localhost:" + hashtagTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	hashtagClient := hashtagpb.NewHashtagClient(hashtagConn)
+	assert.NotNil(t, hashtagClient)
+
+	// three tagged posts by user_8
+	for _, text := range []string{"One #tagone", "Two #tagone #tagtwo", "Three #TagOne"} {
+		arg_compose := &composepb.ComposePostRequest{
+			Userid: int64(8), Username: "user_8", Posttype: postpb.POST_TYPE_POST, Text: text}
+		res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_compose.Ok)
+	}
+	arg_tl := &tlpb.ReadTimelineRequest{Userid: int64(8), Start: int32(0), Stop: int32(3)}
+	res_tl, err := tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_tl.Ok)
+	assert.Equal(t, []string{"tagone", "tagtwo"}, res_tl.Posts[1].Hashtags)
+
+	// newest first, paginated
+	arg_read := &hashtagpb.ReadHashtagTimelineRequest{Hashtag: "tagone", Start: 0, Stop: 2}
+	res_read, err := hashtagClient.ReadHashtagTimeline(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, int32(3), res_read.Nitems)
+	assert.Equal(t, 2, len(res_read.Posts))
+	assert.Equal(t, "Three #TagOne", res_read.Posts[0].Text)
+	assert.Equal(t, "Two #tagone #tagtwo", res_read.Posts[1].Text)
+	arg_read.Start, arg_read.Stop = 2, 4
+	res_read, err = hashtagClient.ReadHashtagTimeline(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, 1, len(res_read.Posts))
+	assert.Equal(t, "One #tagone", res_read.Posts[0].Text)
+
+	// editing moves a post between tags, deleting drops it
+	arg_edit := &composepb.EditPostRequest{Userid: 8, Postid: res_tl.Posts[1].Postid, Text: "Two #tagtwo"}
+	res_edit, err := composeClient.EditPost(context.Background(), arg_edit)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_edit.Ok)
+	arg_delete := &composepb.DeletePostRequest{Userid: 8, Postid: res_tl.Posts[0].Postid}
+	res_delete, err := composeClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_delete.Ok)
+	res_delete, err = composeClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("No Post Error: No. Missing %v.", arg_delete.Postid), res_delete.Ok)
+	arg_read.Start, arg_read.Stop = 0, 10
+	res_read, err = hashtagClient.ReadHashtagTimeline(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, int32(1), res_read.Nitems)
+	assert.Equal(t, "One #tagone", res_read.Posts[0].Text)
+	arg_read.Hashtag = "tagtwo"
+	res_read, err = hashtagClient.ReadHashtagTimeline(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, 1, len(res_read.Posts))
+	assert.Equal(t, "Two #tagtwo", res_read.Posts[0].Text)
+
+	// tags longer than cache keys may be are still cached and read back
+	longtag := strings.Repeat("long", 100)
+	arg_tag := &hashtagpb.WriteHashtagsRequest{
+		Postid: res_tl.Posts[1].Postid, Timestamp: res_tl.Posts[1].Timestamp, Hashtags: []string{longtag}}
+	res_tag, err := hashtagClient.WriteHashtags(context.Background(), arg_tag)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_tag.Ok)
+	arg_read.Hashtag = longtag
+	for i := 0; i < 2; i++ {
+		res_read, err = hashtagClient.ReadHashtagTimeline(context.Background(), arg_read)
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_read.Ok)
+		assert.Equal(t, int32(1), res_read.Nitems)
+		assert.Equal(t, arg_tag.Postid, res_read.Posts[0].Postid)
+	}
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, tfcmd.Process.Kill())
+	assert.Nil(t, hfcmd.Process.Kill())
+}
+
+func TestNotification(t *testing.T) {
+	// start forwarding
+	composeTestPort, notifTestPort := "9000", "9001"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	nfcmd, err := StartFowarding("notification", notifTestPort, "8096")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	assert.NotNil(t, composeClient)
+	notifConn, err := dialer.Dial("localhost:" + notifTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	notifClient := notifpb.NewNotificationClient(notifConn)
+	assert.NotNil(t, notifClient)
+
+	// user_9 mentions user_5, who replies
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(9), Username: "user_9", Posttype: postpb.POST_TYPE_POST, Text: "Hi @user_5"}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	arg_read := &notifpb.ReadNotificationsRequest{Userid: int64(5), Start: 0, Stop: 10}
+	res_read, err := notifClient.ReadNotifications(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, int64(1), res_read.Nunread)
+	assert.Equal(t, 1, len(res_read.Notifications))
+	mention := res_read.Notifications[0]
+	assert.Equal(t, notifpb.NOTIFICATION_TYPE_MENTION, mention.Notificationtype)
+	assert.Equal(t, int64(9), mention.Actorid)
+	assert.Equal(t, "user_9", mention.Actoruname)
+	assert.False(t, mention.Read)
+
+	arg_compose = &composepb.ComposePostRequest{
+		Userid: int64(5), Username: "user_5", Posttype: postpb.POST_TYPE_REPLY,
+		Text: "Hello back", Parentid: mention.Postid}
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+	arg_read.Userid = int64(9)
+	res_read, err = notifClient.ReadNotifications(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, 1, len(res_read.Notifications))
+	assert.Equal(t, notifpb.NOTIFICATION_TYPE_REPLY, res_read.Notifications[0].Notificationtype)
+	assert.Equal(t, int64(5), res_read.Notifications[0].Actorid)
+
+	// mark read by id, then nothing is left unread
+	arg_mark := &notifpb.MarkReadRequest{Userid: int64(9)}
+	res_mark, err := notifClient.MarkRead(context.Background(), arg_mark)
+	assert.Nil(t, err)
+	assert.Equal(t, "No notifications to mark.", res_mark.Ok)
+	arg_mark.Notificationids = []int64{res_read.Notifications[0].Notificationid}
+	res_mark, err = notifClient.MarkRead(context.Background(), arg_mark)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_mark.Ok)
+	res_count, err := notifClient.GetUnreadCount(
+		context.Background(), &notifpb.GetUnreadCountRequest{Userid: int64(9)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_count.Ok)
+	assert.Equal(t, int64(0), res_count.Nunread)
+	arg_read.Unreadonly = true
+	res_read, err = notifClient.ReadNotifications(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, 0, len(res_read.Notifications))
+
+	// mark all read
+	arg_mark = &notifpb.MarkReadRequest{Userid: int64(5), All: true}
+	res_mark, err = notifClient.MarkRead(context.Background(), arg_mark)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_mark.Ok)
+	res_count, err = notifClient.GetUnreadCount(
+		context.Background(), &notifpb.GetUnreadCountRequest{Userid: int64(5)})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res_count.Nunread)
+
+	// an edit only notifies the users it newly mentions
+	arg_edit := &composepb.EditPostRequest{Userid: 9, Postid: mention.Postid, Text: "Hi @user_5 and @user_6"}
+	res_edit, err := composeClient.EditPost(context.Background(), arg_edit)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_edit.Ok)
+	res_count, err = notifClient.GetUnreadCount(
+		context.Background(), &notifpb.GetUnreadCountRequest{Userid: int64(5)})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res_count.Nunread)
+	arg_read = &notifpb.ReadNotificationsRequest{Userid: int64(6), Start: 0, Stop: 10}
+	res_read, err = notifClient.ReadNotifications(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, notifpb.NOTIFICATION_TYPE_MENTION, res_read.Notifications[0].Notificationtype)
+	assert.Equal(t, mention.Postid, res_read.Notifications[0].Postid)
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, nfcmd.Process.Kill())
+}
+
+func TestComposeMedia(t *testing.T) {
+	// start forwarding
+	composeTestPort, mediaTestPort := "9000", "9001"
+	cfcmd, err := StartFowarding("compose", composeTestPort, "8081")
+	assert.Nil(t, err)
+	mfcmd, err := StartFowarding("media", mediaTestPort, "8082")
+	assert.Nil(t, err)
+	composeConn, err := dialer.Dial("localhost:" + composeTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	composeClient := composepb.NewComposeClient(composeConn)
+	assert.NotNil(t, composeClient)
+	mediaConn, err := dialer.Dial("localhost:" + mediaTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	mediaClient := mediapb.NewMediaStorageClient(mediaConn)
+	assert.NotNil(t, mediaClient)
+
+	// media must exist before a post can attach it
+	arg_compose := &composepb.ComposePostRequest{
+		Userid: int64(4), Username: "user_4", Posttype: postpb.POST_TYPE_POST,
+		Text: "Look at this", Mediaids: []int64{int64(777)}}
+	res_compose, err := composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "No Media Error: No Missing 777.", res_compose.Ok)
+
+	arg_store := &mediapb.StoreMediaRequest{Mediadata: makeImage(8, 8, encodePNG)}
+	res_store, err := mediaClient.StoreMedia(context.Background(), arg_store)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_store.Ok)
+	arg_compose.Mediaids = []int64{res_store.Mediaid}
+	res_compose, err = composeClient.ComposePost(context.Background(), arg_compose)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_compose.Ok)
+
+	// Stop forwarding
+	assert.Nil(t, cfcmd.Process.Kill())
+	assert.Nil(t, mfcmd.Process.Kill())
+}
+
+func TestIdgen(t *testing.T) {
+	// start k8s port forwarding and set up client connection.
+	testPort := "9000"
+	fcmd, err := StartFowarding("idgen", testPort, "8098")
+	assert.Nil(t, err)
+	conn, err := dialer.Dial("localhost:" + testPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	idgenClient := idgenpb.NewIdgenClient(conn)
+
+	res, err := idgenClient.NextIds(context.Background(), &idgenpb.NextIdsRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res.Ok)
+	assert.Equal(t, 1, len(res.Ids))
+	last := res.Ids[0]
+	res, err = idgenClient.NextIds(context.Background(), &idgenpb.NextIdsRequest{Count: 5000})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res.Ok)
+	assert.Equal(t, 1000, len(res.Ids))
+	_, workerid, _ := snowflake.Decompose(last)
+	for _, id := range res.Ids {
+		assert.Greater(t, id, last)
+		_, idWorker, _ := snowflake.Decompose(id)
+		assert.Equal(t, workerid, idWorker)
+		last = id
+	}
+
+	// Stop fowarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
@@ -0,0 +1,370 @@
+package test
+
+import (
+	"testing"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"io"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	FRONTEND_TEST_PORT = "9500"
+)
+
+// frontendRequest calls a frontend route with an optional session token and
+// returns the status code and the decoded JSON reply, if any.
+func frontendRequest(
+		t *testing.T, method, path string, params url.Values, token string) (int, map[string]interface{}) {
+	target := "http://localhost:" + FRONTEND_TEST_PORT + path
+	var req *http.Request
+	var err error
+	if method == http.MethodPost {
+		req, err = http.NewRequest(method, target, strings.NewReader(params.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req, err = http.NewRequest(method, target+"?"+params.Encode(), nil)
+	}
+	assert.Nil(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	reply := make(map[string]interface{})
+	json.NewDecoder(resp.Body).Decode(&reply)
+	return resp.StatusCode, reply
+}
+
+// apiRequest calls a JSON API route with an optional body and session
+// token and returns the status code and the decoded JSON reply, if any.
+func apiRequest(
+		t *testing.T, method, path string, body interface{}, token string) (int, map[string]interface{}) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		assert.Nil(t, err)
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequest(method, "http://localhost:"+FRONTEND_TEST_PORT+"/api/v1"+path, reader)
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	reply := make(map[string]interface{})
+	json.NewDecoder(resp.Body).Decode(&reply)
+	return resp.StatusCode, reply
+}
+
+func TestSession(t *testing.T) {
+	// start k8s port forwarding
+	fcmd, err := StartFowarding("frontend", FRONTEND_TEST_PORT, "5000")
+	assert.Nil(t, err)
+
+	// sign up and log in
+	signup := url.Values{
+		"username": {"session_user"}, "password": {"s3cret&pass"},
+		"firstname": {"Sam"}, "lastname": {"Session"}}
+	code, reply := frontendRequest(t, http.MethodPost, "/signup", signup, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Signup successfully!", reply["message"])
+	assert.NotEmpty(t, reply["token"])
+	userid := int64(reply["userid"].(float64))
+	code, _ = frontendRequest(t, http.MethodPost, "/signup", signup, "")
+	assert.Equal(t, http.StatusConflict, code)
+
+	login := url.Values{"username": {"session_user"}, "password": {"wrong"}}
+	code, _ = frontendRequest(t, http.MethodPost, "/login", login, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	login.Set("password", "s3cret&pass")
+	code, reply = frontendRequest(t, http.MethodPost, "/login", login, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Login successfully!", reply["message"])
+	assert.Equal(t, float64(userid), reply["userid"])
+	token := reply["token"].(string)
+
+	// acting routes need a valid session
+	compose := url.Values{"text": {"posting with a session"}, "posttype": {"post"}}
+	code, _ = frontendRequest(t, http.MethodGet, "/compose", compose, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = frontendRequest(t, http.MethodGet, "/compose", compose, token+"x")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = frontendRequest(t, http.MethodGet, "/home", url.Values{}, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// the session user is the default acting user
+	code, reply = frontendRequest(t, http.MethodGet, "/compose", compose, token)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Compose successfully!", reply["message"])
+	code, reply = frontendRequest(
+		t, http.MethodGet, "/timeline", url.Values{"stop": {"1"}}, token)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "posting with a session; ", reply["contents"])
+	assert.Equal(t, "Sam Session; ", reply["authors"])
+
+	// acting as someone else is refused, reading their timeline is not
+	compose.Set("userid", "0")
+	code, _ = frontendRequest(t, http.MethodGet, "/compose", compose, token)
+	assert.Equal(t, http.StatusForbidden, code)
+	compose.Del("userid")
+	compose.Set("username", "user_0")
+	code, _ = frontendRequest(t, http.MethodGet, "/compose", compose, token)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = frontendRequest(t, http.MethodGet, "/home", url.Values{"userid": {"0"}}, token)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = frontendRequest(
+		t, http.MethodPost, "/notifications/read", url.Values{"userid": {"0"}, "all": {"true"}}, token)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = frontendRequest(t, http.MethodGet, "/timeline", url.Values{"userid": {"0"}}, token)
+	assert.Equal(t, http.StatusOK, code)
+
+	// the session cookie only changes state with POST
+	compose = url.Values{"text": {"posting with a cookie"}, "posttype": {"post"}}
+	cookie := &http.Cookie{Name: "session", Value: token}
+	req, err := http.NewRequest(
+		http.MethodGet, "http://localhost:"+FRONTEND_TEST_PORT+"/compose?"+compose.Encode(), nil)
+	assert.Nil(t, err)
+	req.AddCookie(cookie)
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	req, err = http.NewRequest(http.MethodPost,
+		"http://localhost:"+FRONTEND_TEST_PORT+"/compose", strings.NewReader(compose.Encode()))
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	reply = make(map[string]interface{})
+	json.NewDecoder(resp.Body).Decode(&reply)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Compose successfully!", reply["message"])
+
+	// Stop forwarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
+
+func TestApi(t *testing.T) {
+	// start k8s port forwarding
+	fcmd, err := StartFowarding("frontend", FRONTEND_TEST_PORT, "5000")
+	assert.Nil(t, err)
+
+	// sign up and log in; failures come in an error envelope
+	signup := map[string]string{
+		"username": "api_user", "password": "api&pass", "firstname": "Ada", "lastname": "Api"}
+	code, reply := apiRequest(t, http.MethodPost, "/signup", signup, "")
+	assert.Equal(t, http.StatusCreated, code)
+	userid := reply["userid"].(string)
+	code, reply = apiRequest(t, http.MethodPost, "/signup", signup, "")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, float64(http.StatusConflict), reply["error"].(map[string]interface{})["status"])
+	code, _ = apiRequest(t, http.MethodPost, "/login",
+		map[string]string{"username": "api_user", "password": "wrong"}, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, reply = apiRequest(t, http.MethodPost, "/login",
+		map[string]string{"username": "api_user", "password": "api&pass"}, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, userid, reply["userid"])
+	token := reply["token"].(string)
+
+	// writes need a session, a JSON body and POST
+	post := map[string]interface{}{"text": "hello #API from @user_1"}
+	code, _ = apiRequest(t, http.MethodPost, "/posts", post, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = apiRequest(t, http.MethodGet, "/posts", nil, token)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+	code, _ = apiRequest(t, http.MethodPost, "/posts", map[string]string{"txt": "typo"}, token)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, reply = apiRequest(t, http.MethodPost, "/posts", post, token)
+	assert.Equal(t, http.StatusCreated, code)
+	postid := reply["id"].(string)
+
+	// reads return post objects
+	code, reply = apiRequest(t, http.MethodGet, "/posts/"+postid, nil, "")
+	assert.Equal(t, http.StatusOK, code)
+	read := reply["post"].(map[string]interface{})
+	assert.Equal(t, postid, read["id"])
+	assert.Equal(t, "post", read["type"])
+	assert.Equal(t, "hello #API from @user_1", read["text"])
+	assert.Equal(t, []interface{}{"api"}, read["hashtags"])
+	assert.Equal(t, []interface{}{"1"}, read["mentions"])
+	assert.Equal(t, []interface{}{}, read["media"])
+	author := read["author"].(map[string]interface{})
+	assert.Equal(t, userid, author["userid"])
+	assert.Equal(t, "api_user", author["username"])
+	assert.Equal(t, "Ada Api", author["displayname"])
+	code, reply = apiRequest(t, http.MethodGet, "/users/"+userid+"/timeline", nil, "")
+	assert.Equal(t, http.StatusOK, code)
+	posts := reply["posts"].([]interface{})
+	assert.Equal(t, 1, len(posts))
+	assert.Equal(t, postid, posts[0].(map[string]interface{})["id"])
+	code, reply = apiRequest(t, http.MethodGet, "/users/"+userid+"/timeline?start=5&stop=10", nil, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []interface{}{}, reply["posts"])
+	code, _ = apiRequest(t, http.MethodGet, "/users/"+userid+"/timeline?start=x", nil, "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = apiRequest(t, http.MethodGet, fmt.Sprintf("/posts/%v", int64(1)<<60), nil, "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// react and unreact
+	code, _ = apiRequest(
+		t, http.MethodPost, "/posts/"+postid+"/reactions", map[string]string{"reaction": "love"}, token)
+	assert.Equal(t, http.StatusNoContent, code)
+	_, reply = apiRequest(t, http.MethodGet, "/posts/"+postid, nil, "")
+	reactions := reply["post"].(map[string]interface{})["reactions"].(map[string]interface{})
+	assert.Equal(t, float64(1), reactions["love"])
+	code, _ = apiRequest(t, http.MethodDelete, "/posts/"+postid+"/reactions", nil, token)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	// Stop forwarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
+
+func TestFollow(t *testing.T) {
+	// start k8s port forwarding
+	fcmd, err := StartFowarding("frontend", FRONTEND_TEST_PORT, "5000")
+	assert.Nil(t, err)
+
+	signup := url.Values{"username": {"follow_user"}, "password": {"f0llow"}}
+	code, reply := frontendRequest(t, http.MethodPost, "/signup", signup, "")
+	assert.Equal(t, http.StatusOK, code)
+	token := reply["token"].(string)
+	userid := fmt.Sprintf("%v", int64(reply["userid"].(float64)))
+
+	// follow by name and by id on the query string routes
+	code, _ = frontendRequest(t, http.MethodPost, "/follow", url.Values{"followeename": {"user_1"}}, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, reply = frontendRequest(
+		t, http.MethodPost, "/follow", url.Values{"followeename": {"user_1"}}, token)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Follow successfully!", reply["message"])
+	code, reply = frontendRequest(t, http.MethodPost, "/follow", url.Values{"followeeid": {userid}}, token)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Follow Failed!Cannot follow self.", reply["message"])
+	code, reply = frontendRequest(
+		t, http.MethodGet, "/followees", url.Values{"username": {"follow_user"}}, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "1; ", reply["userids"])
+	assert.Equal(t, "user_1; ", reply["usernames"])
+	assert.Equal(t, "", reply["nextcursor"])
+	code, reply = frontendRequest(t, http.MethodPost, "/unfollow", url.Values{"followeeid": {"1"}}, token)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Unfollow successfully!", reply["message"])
+	_, reply = frontendRequest(t, http.MethodGet, "/followees", url.Values{"userid": {userid}}, "")
+	assert.Equal(t, "", reply["userids"])
+
+	// and on the JSON API
+	code, _ = apiRequest(t, http.MethodPost, "/users/2/follow", nil, token)
+	assert.Equal(t, http.StatusNoContent, code)
+	code, reply = apiRequest(t, http.MethodGet, "/users/"+userid+"/followees", nil, "")
+	assert.Equal(t, http.StatusOK, code)
+	users := reply["users"].([]interface{})
+	assert.Equal(t, 1, len(users))
+	assert.Equal(t, "2", users[0].(map[string]interface{})["userid"])
+	assert.Equal(t, "user_2", users[0].(map[string]interface{})["username"])
+	code, reply = apiRequest(t, http.MethodGet, "/users/2/followers?limit=1000", nil, "")
+	assert.Equal(t, http.StatusOK, code)
+	found := false
+	for _, follower := range reply["users"].([]interface{}) {
+		found = found || follower.(map[string]interface{})["userid"] == userid
+	}
+	assert.True(t, found)
+	code, _ = apiRequest(t, http.MethodGet, "/users/2/followers?cursor=nope", nil, "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = apiRequest(t, http.MethodDelete, "/users/2/follow", nil, token)
+	assert.Equal(t, http.StatusNoContent, code)
+	_, reply = apiRequest(t, http.MethodGet, "/users/"+userid+"/followees", nil, "")
+	assert.Equal(t, []interface{}{}, reply["users"])
+
+	// Stop forwarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
+
+func TestHomeStream(t *testing.T) {
+	// start k8s port forwarding
+	fcmd, err := StartFowarding("frontend", FRONTEND_TEST_PORT, "5000")
+	assert.Nil(t, err)
+
+	tokens := make([]string, 2)
+	for idx, username := range []string{"stream_reader", "stream_writer"} {
+		code, reply := frontendRequest(t, http.MethodPost, "/signup",
+			url.Values{"username": {username}, "password": {"str3am"}}, "")
+		assert.Equal(t, http.StatusOK, code)
+		tokens[idx] = reply["token"].(string)
+	}
+	code, _ := frontendRequest(
+		t, http.MethodGet, "/follow", url.Values{"followeename": {"stream_writer"}}, tokens[0])
+	assert.Equal(t, http.StatusOK, code)
+
+	// streams need a session
+	code, _ = frontendRequest(t, http.MethodGet, "/home/stream", url.Values{}, "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, "http://localhost:"+FRONTEND_TEST_PORT+"/home/stream", nil)
+	assert.Nil(t, err)
+	req.Header.Set("Authorization", "Bearer "+tokens[0])
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	lines := bufio.NewScanner(resp.Body)
+	nextEvent := func() (string, string) {
+		var event, data string
+		for lines.Scan() {
+			line := lines.Text()
+			if line == "" && event != "" {
+				break
+			}
+			if strings.HasPrefix(line, "event: ") {
+				event = strings.TrimPrefix(line, "event: ")
+			} else if strings.HasPrefix(line, "data: ") {
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		return event, data
+	}
+	event, _ := nextEvent()
+	assert.Equal(t, "ready", event)
+
+	// a post of a followee is pushed
+	code, reply := frontendRequest(
+		t, http.MethodGet, "/compose", url.Values{"text": {"live from the stream"}, "posttype": {"post"}}, tokens[1])
+	assert.Equal(t, http.StatusOK, code)
+	event, data := nextEvent()
+	assert.Equal(t, "post", event)
+	pushed := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal([]byte(data), &pushed))
+	assert.Equal(t, fmt.Sprintf("%v", int64(reply["postid"].(float64))), pushed["id"])
+	assert.Equal(t, "live from the stream", pushed["text"])
+	assert.Equal(t, "stream_writer", pushed["author"].(map[string]interface{})["username"])
+
+	// connections are counted
+	metricsResp, err := http.Get("http://localhost:" + FRONTEND_TEST_PORT + "/debug/vars")
+	assert.Nil(t, err)
+	metrics := make(map[string]interface{})
+	json.NewDecoder(metricsResp.Body).Decode(&metrics)
+	metricsResp.Body.Close()
+	assert.GreaterOrEqual(t, metrics["frontend_home_streams"], float64(1))
+
+	// Stop forwarding
+	cancel()
+	assert.Nil(t, fcmd.Process.Kill())
+}
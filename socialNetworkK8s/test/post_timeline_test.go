@@ -6,10 +6,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"socialnetworkk8/dialer"
 	"context"
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math/rand"
 	postpb "socialnetworkk8/services/post/proto"
 	mediapb "socialnetworkk8/services/media/proto"
 	tlpb "socialnetworkk8/services/timeline/proto"
 	homepb "socialnetworkk8/services/home/proto"
+	reactionpb "socialnetworkk8/services/reaction/proto"
+	searchpb "socialnetworkk8/services/search/proto"
 )
 
 func IsPostEqual(a, b *postpb.Post) bool {
@@ -245,6 +255,27 @@ func TestHome(t *testing.T) {
 	assert.Nil(t, hfcmd.Process.Kill())
 }
 
+// makeImage returns a w by h gradient encoded with encode.
+func makeImage(w, h int, encode func(*bytes.Buffer, image.Image) error) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	encode(&buf, img)
+	return buf.Bytes()
+}
+
+func encodePNG(buf *bytes.Buffer, img image.Image) error {
+	return png.Encode(buf, img)
+}
+
+func encodeGIF(buf *bytes.Buffer, img image.Image) error {
+	return gif.Encode(buf, img, nil)
+}
+
 func TestMedia(t *testing.T) {
 	// start k8s port forwarding and set up client connection.
 	testPort := "9000"
@@ -255,20 +286,27 @@ func TestMedia(t *testing.T) {
 	mediaClient := mediapb.NewMediaStorageClient(conn)
 	assert.NotNil(t, mediaClient)
 
-	// store two media
-	mdata1 := []byte{1, 3, 5, 7, 9, 11, 13, 15}
-	mdata2 := []byte{2, 3, 5, 7, 11, 13, 17, 19}
+	// store two media; the stored type comes from the content
+	mdata1 := makeImage(300, 200, encodePNG)
+	mdata2 := makeImage(16, 16, encodeGIF)
 	arg_store := &mediapb.StoreMediaRequest{Mediatype: "File", Mediadata: mdata1}
 	res_store, err := mediaClient.StoreMedia(context.Background(), arg_store)
 	assert.Nil(t, err)
 	assert.Equal(t, "OK", res_store.Ok)
+	assert.Equal(t, "image/png", res_store.Mediatype)
 	mId1 := res_store.Mediaid
-	arg_store = &mediapb.StoreMediaRequest{Mediatype: "Video", Mediadata: mdata2}
+	arg_store = &mediapb.StoreMediaRequest{Mediatype: "image/gif", Mediadata: mdata2}
 	res_store, err = mediaClient.StoreMedia(context.Background(), arg_store)
 	assert.Nil(t, err)
 	assert.Equal(t, "OK", res_store.Ok)
 	mId2 := res_store.Mediaid
 
+	// content that is not media is rejected
+	arg_store = &mediapb.StoreMediaRequest{Mediatype: "Video", Mediadata: []byte{2, 3, 5, 7, 11, 13}}
+	res_store, err = mediaClient.StoreMedia(context.Background(), arg_store)
+	assert.Nil(t, err)
+	assert.Equal(t, "Unsupported media type application/octet-stream.", res_store.Ok)
+
 	// read the medias
 	arg_read := &mediapb.ReadMediaRequest{Mediaids: []int64{mId1, mId2}}
 	res_read, err := mediaClient.ReadMedia(context.Background(), arg_read)
@@ -276,13 +314,486 @@ func TestMedia(t *testing.T) {
 	assert.Equal(t, "OK", res_read.Ok)
 	assert.Equal(t, 2, len(res_read.Mediatypes))
 	assert.Equal(t, 2, len(res_read.Mediadatas))
-	assert.Equal(t, "File", res_read.Mediatypes[0])
-	assert.Equal(t, "Video", res_read.Mediatypes[1])
+	assert.Equal(t, "image/png", res_read.Mediatypes[0])
+	assert.Equal(t, "image/gif", res_read.Mediatypes[1])
 	assert.Equal(t, mdata1, res_read.Mediadatas[0])
 	assert.Equal(t, mdata2, res_read.Mediadatas[1])
 
+	// thumbnails fit in 128x128 and keep the aspect ratio
+	arg_read.Thumbnail = true
+	res_read, err = mediaClient.ReadMedia(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, "image/jpeg", res_read.Mediatypes[0])
+	thumb, err := jpeg.DecodeConfig(bytes.NewReader(res_read.Mediadatas[0]))
+	assert.Nil(t, err)
+	assert.Equal(t, 128, thumb.Width)
+	assert.Equal(t, 85, thumb.Height)
+	thumb, err = jpeg.DecodeConfig(bytes.NewReader(res_read.Mediadatas[1]))
+	assert.Nil(t, err)
+	assert.Equal(t, 16, thumb.Width)
+
+	// stream a larger image in small chunks
+	mdata3 := makeImage(1000, 1000, encodePNG)
+	stream, err := mediaClient.StoreMediaStream(context.Background())
+	assert.Nil(t, err)
+	for start := 0; start < len(mdata3); start += 4096 {
+		stop := start + 4096
+		if stop > len(mdata3) {
+			stop = len(mdata3)
+		}
+		assert.Nil(t, stream.Send(&mediapb.StoreMediaRequest{Mediadata: mdata3[start:stop]}))
+	}
+	res_store, err = stream.CloseAndRecv()
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_store.Ok)
+	arg_read = &mediapb.ReadMediaRequest{Mediaids: []int64{res_store.Mediaid}}
+	res_read, err = mediaClient.ReadMedia(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, mdata3, res_read.Mediadatas[0])
+
+	// check media ids without reading them
+	arg_check := &mediapb.CheckMediaRequest{Mediaids: []int64{mId1, int64(777), mId2}}
+	res_check, err := mediaClient.CheckMedia(context.Background(), arg_check)
+	assert.Nil(t, err)
+	assert.Equal(t, "No Missing 777.", res_check.Ok)
+	assert.Equal(t, []int64{int64(777)}, res_check.Missing)
+
+	// Stop forwarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
+
+func TestMediaBlob(t *testing.T) {
+	// start k8s port forwarding and set up client connection.
+	testPort := "9000"
+	fcmd, err := StartFowarding("media", testPort, "8082")
+	assert.Nil(t, err)
+	conn, err := dialer.Dial("localhost:" + testPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	mediaClient := mediapb.NewMediaStorageClient(conn)
+	assert.NotNil(t, mediaClient)
+
+	// noise does not compress, so the image spans several chunks
+	rnd := rand.New(rand.NewSource(1))
+	noise := image.NewRGBA(image.Rect(0, 0, 400, 400))
+	rnd.Read(noise.Pix)
+	var buf bytes.Buffer
+	assert.Nil(t, png.Encode(&buf, noise))
+	mdata := buf.Bytes()
+	assert.True(t, len(mdata) > 3*255*1024)
+
+	// identical uploads get their own ids but share a blob
+	arg_store := &mediapb.StoreMediaRequest{Mediadata: mdata}
+	res_store1, err := mediaClient.StoreMedia(context.Background(), arg_store)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_store1.Ok)
+	res_store2, err := mediaClient.StoreMedia(context.Background(), arg_store)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_store2.Ok)
+	assert.NotEqual(t, res_store1.Mediaid, res_store2.Mediaid)
+	assert.Equal(t, res_store1.Hash, res_store2.Hash)
+
+	// unary reads stop short of the message size limit
+	ids := []int64{res_store1.Mediaid, res_store2.Mediaid, res_store1.Mediaid, res_store2.Mediaid, res_store1.Mediaid}
+	res_big, err := mediaClient.ReadMedia(context.Background(), &mediapb.ReadMediaRequest{Mediaids: ids})
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("No Media %v too large, read it by range.", res_store1.Mediaid), res_big.Ok)
+	assert.Equal(t, mdata, res_big.Mediadatas[3])
+	assert.Equal(t, 0, len(res_big.Mediadatas[4]))
+
+	// read a range across a chunk boundary
+	offset, length := int64(255*1024-10), int64(300)
+	arg_range := &mediapb.ReadMediaRangeRequest{Mediaid: res_store2.Mediaid, Offset: offset, Length: length}
+	stream, err := mediaClient.ReadMediaRange(context.Background(), arg_range)
+	assert.Nil(t, err)
+	var got []byte
+	for {
+		res_range, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_range.Ok)
+		assert.Equal(t, offset+int64(len(got)), res_range.Offset)
+		got = append(got, res_range.Data...)
+	}
+	assert.Equal(t, mdata[offset:offset+length], got)
+
+	// a range past the end is refused
+	arg_range.Offset = int64(len(mdata) + 1)
+	stream, err = mediaClient.ReadMediaRange(context.Background(), arg_range)
+	assert.Nil(t, err)
+	res_range, err := stream.Recv()
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_range.Ok)
+
+	// the blob outlives the first delete but not the second
+	arg_delete := &mediapb.DeleteMediaRequest{Mediaid: res_store1.Mediaid}
+	res_delete, err := mediaClient.DeleteMedia(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_delete.Ok)
+	arg_read := &mediapb.ReadMediaRequest{Mediaids: []int64{res_store2.Mediaid}}
+	res_read, err := mediaClient.ReadMedia(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, mdata, res_read.Mediadatas[0])
+	arg_delete.Mediaid = res_store2.Mediaid
+	res_delete, err = mediaClient.DeleteMedia(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_delete.Ok)
+	res_read, err = mediaClient.ReadMedia(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("No Missing %v.", res_store2.Mediaid), res_read.Ok)
+	res_delete, err = mediaClient.DeleteMedia(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("No Missing %v.", res_store2.Mediaid), res_delete.Ok)
+
+	// Stop forwarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
+
+func TestPostDeleteEdit(t *testing.T) {
+	// start forwarding
+	postTestPort, tlTestPort := "9000", "9001"
+	pfcmd, err := StartFowarding("post", postTestPort, "8086")
+	assert.Nil(t, err)
+	tfcmd, err := StartFowarding("timeline", tlTestPort, "8089")
+	assert.Nil(t, err)
+	postConn, err := dialer.Dial("localhost:" + postTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	postClient := postpb.NewPostStorageClient(postConn)
+	assert.NotNil(t, postClient)
+	tlConn, err := dialer.Dial("localhost:" + tlTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	tlClient := tlpb.NewTimelineClient(tlConn)
+	assert.NotNil(t, tlClient)
+
+	// create 3 posts and put them on the timeline
+	NPOST, userid := 3, int64(300)
+	posts := createNPosts(t, postClient, NPOST, userid, 300)
+	for i := 0; i < NPOST; i++ {
+		writeTimeline(t, tlClient, posts[i], userid)
+	}
+
+	// only the creator can edit
+	arg_edit := &postpb.EditPostRequest{
+		Postid: posts[1].Postid, Userid: userid+1, Text: "Edited post", Urls: []string{"zzzzz"}}
+	res_edit, err := postClient.EditPost(context.Background(), arg_edit)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_edit.Ok)
+	arg_edit.Userid = userid
+	res_edit, err = postClient.EditPost(context.Background(), arg_edit)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_edit.Ok)
+	assert.Equal(t, "Edited post", res_edit.Post.Text)
+	assert.NotEqual(t, int64(0), res_edit.Post.Edittimestamp)
+
+	arg_read := &postpb.ReadPostsRequest{Postids: []int64{posts[1].Postid}}
+	res_read, err := postClient.ReadPosts(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, "Edited post", res_read.Posts[0].Text)
+
+	arg_hist := &postpb.ReadPostHistoryRequest{Postid: posts[1].Postid}
+	res_hist, err := postClient.ReadPostHistory(context.Background(), arg_hist)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_hist.Ok)
+	assert.Equal(t, 1, len(res_hist.Edits))
+	assert.Equal(t, posts[1].Text, res_hist.Edits[0].Text)
+	assert.Equal(t, posts[1].Timestamp, res_hist.Edits[0].Timestamp)
+
+	// delete the middle post; a stale timeline skips it instead of failing
+	arg_delete := &postpb.DeletePostRequest{Postid: posts[1].Postid, Userid: userid+1}
+	res_delete, err := postClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_delete.Ok)
+	arg_delete.Userid = userid
+	res_delete, err = postClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_delete.Ok)
+	res_delete, err = postClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("No. Missing %v.", posts[1].Postid), res_delete.Ok)
+
+	arg_tl := &tlpb.ReadTimelineRequest{Userid: userid, Start: int32(0), Stop: int32(NPOST)}
+	res_tl, err := tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_tl.Ok)
+	assert.Equal(t, NPOST-1, len(res_tl.Posts))
+	assert.True(t, IsPostEqual(posts[2], res_tl.Posts[0]))
+	assert.True(t, IsPostEqual(posts[0], res_tl.Posts[1]))
+
+	// remove it from the timeline as well
+	arg_remove := &tlpb.RemoveTimelineRequest{Userid: userid, Postid: posts[1].Postid}
+	res_remove, err := tlClient.RemoveTimeline(context.Background(), arg_remove)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_remove.Ok)
+	arg_tl.Stop = int32(2)
+	res_tl, err = tlClient.ReadTimeline(context.Background(), arg_tl)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_tl.Ok)
+	assert.Equal(t, 2, len(res_tl.Posts))
+	assert.True(t, IsPostEqual(posts[0], res_tl.Posts[1]))
+
+	// Stop forwarding
+	assert.Nil(t, pfcmd.Process.Kill())
+	assert.Nil(t, tfcmd.Process.Kill())
+}
+
+func TestThread(t *testing.T) {
+	// start k8s port forwarding and set up client connection.
+	testPort := "9000"
+	fcmd, err := StartFowarding("post", testPort, "8086")
+	assert.Nil(t, err)
+	conn, err := dialer.Dial("localhost:" + testPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	postClient := postpb.NewPostStorageClient(conn)
+	assert.NotNil(t, postClient)
+
+	// root <- reply1 <- reply11, root <- reply2, root <- repost
+	root := &postpb.Post{Postid: 401, Posttype: postpb.POST_TYPE_POST, Timestamp: 40100, Creator: 4,
+		Text: "Root"}
+	reply1 := &postpb.Post{Postid: 402, Posttype: postpb.POST_TYPE_REPLY, Timestamp: 40200, Creator: 5,
+		Text: "Reply 1", Parentid: 401, Rootid: 401}
+	reply2 := &postpb.Post{Postid: 403, Posttype: postpb.POST_TYPE_REPLY, Timestamp: 40300, Creator: 6,
+		Text: "Reply 2", Parentid: 401, Rootid: 401}
+	reply11 := &postpb.Post{Postid: 404, Posttype: postpb.POST_TYPE_REPLY, Timestamp: 40400, Creator: 4,
+		Text: "Reply 1.1", Parentid: 402, Rootid: 401}
+	repost := &postpb.Post{Postid: 405, Posttype: postpb.POST_TYPE_REPOST, Timestamp: 40500, Creator: 7,
+		Text: "Repost", Parentid: 401, Rootid: 401}
+	for _, p := range []*postpb.Post{root, reply1, reply2, reply11, repost} {
+		res_store, err := postClient.StorePost(context.Background(), &postpb.StorePostRequest{Post: p})
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_store.Ok)
+	}
+
+	// counters on the parents
+	arg_read := &postpb.ReadPostsRequest{Postids: []int64{401, 402}}
+	res_read, err := postClient.ReadPosts(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, int32(2), res_read.Posts[0].Nreplies)
+	assert.Equal(t, int32(1), res_read.Posts[0].Nreposts)
+	assert.Equal(t, int32(1), res_read.Posts[1].Nreplies)
+
+	// the whole tree can be read starting from any of its posts
+	arg_thread := &postpb.GetThreadRequest{Postid: 404, Start: 0, Stop: 10}
+	res_thread, err := postClient.GetThread(context.Background(), arg_thread)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_thread.Ok)
+	assert.Equal(t, int64(401), res_thread.Rootid)
+	assert.Equal(t, int32(5), res_thread.Nitems)
+	expected := []int64{401, 402, 404, 403, 405}
+	depths := []int32{0, 1, 2, 1, 1}
+	for i, item := range res_thread.Items {
+		assert.Equal(t, expected[i], item.Post.Postid)
+		assert.Equal(t, depths[i], item.Depth)
+	}
+
+	// pagination
+	arg_thread.Start, arg_thread.Stop = 2, 4
+	res_thread, err = postClient.GetThread(context.Background(), arg_thread)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_thread.Ok)
+	assert.Equal(t, 2, len(res_thread.Items))
+	assert.Equal(t, int64(404), res_thread.Items[0].Post.Postid)
+	assert.Equal(t, int64(403), res_thread.Items[1].Post.Postid)
+	arg_thread.Start, arg_thread.Stop = -1, 4
+	res_thread, err = postClient.GetThread(context.Background(), arg_thread)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_thread.Ok)
+	assert.Equal(t, 0, len(res_thread.Items))
+
+	// deleting a reply drops it from the tree but keeps its replies
+	arg_delete := &postpb.DeletePostRequest{Postid: 402, Userid: 5}
+	res_delete, err := postClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_delete.Ok)
+	arg_thread.Start, arg_thread.Stop = 0, 10
+	res_thread, err = postClient.GetThread(context.Background(), arg_thread)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_thread.Ok)
+	assert.Equal(t, int32(4), res_thread.Nitems)
+	assert.Equal(t, int32(1), res_thread.Items[0].Post.Nreplies)
+	assert.Equal(t, int64(404), res_thread.Items[1].Post.Postid)
+	assert.Equal(t, int32(2), res_thread.Items[1].Depth)
+
 	// Stop forwarding
 	assert.Nil(t, fcmd.Process.Kill())
 }
 
+func TestReaction(t *testing.T) {
+	// start forwarding
+	postTestPort, reactionTestPort := "9000", "9001"
+	pfcmd, err := StartFowarding("post", postTestPort, "8086")
+	assert.Nil(t, err)
+	rfcmd, err := StartFowarding("reaction", reactionTestPort, "8093")
+	assert.Nil(t, err)
+	postConn, err := dialer.Dial("localhost:" + postTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	postClient := postpb.NewPostStorageClient(postConn)
+	assert.NotNil(t, postClient)
+	reactionConn, err := dialer.Dial("localhost:" + reactionTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	reactionClient := reactionpb.NewReactionClient(reactionConn)
+	assert.NotNil(t, reactionClient)
+
+	post := &postpb.Post{Postid: 501, Posttype: postpb.POST_TYPE_POST, Timestamp: 50100, Creator: 1,
+		Text: "React to me"}
+	res_store, err := postClient.StorePost(context.Background(), &postpb.StorePostRequest{Post: post})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_store.Ok)
+
+	// ANY is not a reaction
+	arg_react := &reactionpb.ReactRequest{Userid: 2, Postid: 501, Reactiontype: reactionpb.REACTION_TYPE_ANY}
+	res_react, err := reactionClient.React(context.Background(), arg_react)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_react.Ok)
 
+	// user 2 and 3 like, then user 2 switches to love; liking twice counts once
+	for _, arg := range []*reactionpb.ReactRequest{
+			{Userid: 2, Postid: 501, Reactiontype: reactionpb.REACTION_TYPE_LIKE},
+			{Userid: 3, Postid: 501, Reactiontype: reactionpb.REACTION_TYPE_LIKE},
+			{Userid: 3, Postid: 501, Reactiontype: reactionpb.REACTION_TYPE_LIKE},
+			{Userid: 2, Postid: 501, Reactiontype: reactionpb.REACTION_TYPE_LOVE}} {
+		res_react, err = reactionClient.React(context.Background(), arg)
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_react.Ok)
+	}
+
+	// counts show up in posts
+	arg_read := &postpb.ReadPostsRequest{Postids: []int64{501}}
+	res_read, err := postClient.ReadPosts(context.Background(), arg_read)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_read.Ok)
+	assert.Equal(t, int64(2), res_read.Posts[0].Nreactions)
+	assert.Equal(t, 2, len(res_read.Posts[0].Reactions))
+	assert.Equal(t, reactionpb.REACTION_TYPE_LIKE, res_read.Posts[0].Reactions[0].Reactiontype)
+	assert.Equal(t, int64(1), res_read.Posts[0].Reactions[0].Count)
+	assert.Equal(t, reactionpb.REACTION_TYPE_LOVE, res_read.Posts[0].Reactions[1].Reactiontype)
+	assert.Equal(t, int64(1), res_read.Posts[0].Reactions[1].Count)
+
+	// who reacted, newest first
+	arg_list := &reactionpb.ListReactorsRequest{Postid: 501, Start: 0, Stop: 10}
+	res_list, err := reactionClient.ListReactors(context.Background(), arg_list)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_list.Ok)
+	assert.Equal(t, []int64{2, 3}, res_list.Userids)
+	arg_list.Reactiontype = reactionpb.REACTION_TYPE_LIKE
+	res_list, err = reactionClient.ListReactors(context.Background(), arg_list)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_list.Ok)
+	assert.Equal(t, []int64{3}, res_list.Userids)
+
+	// unlike
+	arg_unreact := &reactionpb.UnreactRequest{Userid: 3, Postid: 501}
+	res_unreact, err := reactionClient.Unreact(context.Background(), arg_unreact)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_unreact.Ok)
+	res_unreact, err = reactionClient.Unreact(context.Background(), arg_unreact)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_unreact.Ok)
+	arg_get := &reactionpb.GetReactionsRequest{Postids: []int64{501}}
+	res_get, err := reactionClient.GetReactions(context.Background(), arg_get)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_get.Ok)
+	assert.Equal(t, int64(1), res_get.Reactions[0].Total)
+	assert.Equal(t, reactionpb.REACTION_TYPE_LOVE, res_get.Reactions[0].Counts[0].Reactiontype)
+
+	// Stop forwarding
+	assert.Nil(t, pfcmd.Process.Kill())
+	assert.Nil(t, rfcmd.Process.Kill())
+}
+
+func TestSearch(t *testing.T) {
+	// start forwarding
+	postTestPort, searchTestPort := "9000", "9001"
+	pfcmd, err := StartFowarding("post", postTestPort, "8086")
+	assert.Nil(t, err)
+	sfcmd, err := StartFowarding("search", searchTestPort, "8095")
+	assert.Nil(t, err)
+	postConn, err := dialer.Dial("localhost:" + postTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	postClient := postpb.NewPostStorageClient(postConn)
+	assert.NotNil(t, postClient)
+	searchConn, err := dialer.Dial("localhost:" + searchTestPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	searchClient := searchpb.NewSearchClient(searchConn)
+	assert.NotNil(t, searchClient)
+
+	// posts are indexed as they are stored
+	posts := []*postpb.Post{
+		{Postid: 601, Posttype: postpb.POST_TYPE_POST, Timestamp: 60100, Creator: 1,
+			Text: "The Zebra crossed the xylophone street"},
+		{Postid: 602, Posttype: postpb.POST_TYPE_POST, Timestamp: 60200, Creator: 2,
+			Text: "zebra zebra zebra"},
+		{Postid: 603, Posttype: postpb.POST_TYPE_POST, Timestamp: 60300, Creator: 1,
+			Text: "A street crossed by a zebra, with a long tail and many other words after it"},
+	}
+	for _, p := range posts {
+		res_store, err := postClient.StorePost(context.Background(), &postpb.StorePostRequest{Post: p})
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_store.Ok)
+	}
+
+	// stopwords alone are not a query
+	arg_search := &searchpb.SearchPostsRequest{Query: "the of and", Start: 0, Stop: 10}
+	res_search, err := searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "OK", res_search.Ok)
+
+	// ranked keyword search, case-insensitive
+	arg_search.Query = "ZEBRA"
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_search.Ok)
+	assert.Equal(t, int32(3), res_search.Nhits)
+	assert.Equal(t, []int64{602, 601, 603}, res_search.Postids)
+
+	// phrases must match in order; stopwords inside a phrase keep their place
+	arg_search.Query = "\"zebra crossed the xylophone\""
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_search.Ok)
+	assert.Equal(t, []int64{601}, res_search.Postids)
+
+	// author and time filters, pagination
+	arg_search.Query = "zebra street"
+	arg_search.Creators = []int64{1}
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_search.Ok)
+	assert.Equal(t, int32(2), res_search.Nhits)
+	arg_search.Since = 60200
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{603}, res_search.Postids)
+	arg_search.Creators, arg_search.Since, arg_search.Start, arg_search.Stop = nil, 0, 1, 2
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), res_search.Nhits)
+	assert.Equal(t, 1, len(res_search.Postids))
+
+	// edits re-index and deletes drop posts
+	arg_edit := &postpb.EditPostRequest{Postid: 602, Userid: 2, Text: "no stripes here"}
+	res_edit, err := postClient.EditPost(context.Background(), arg_edit)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_edit.Ok)
+	arg_delete := &postpb.DeletePostRequest{Postid: 603, Userid: 1}
+	res_delete, err := postClient.DeletePost(context.Background(), arg_delete)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_delete.Ok)
+	arg_search = &searchpb.SearchPostsRequest{Query: "zebra", Start: 0, Stop: 10}
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{601}, res_search.Postids)
+	arg_search.Query = "stripes"
+	res_search, err = searchClient.SearchPosts(context.Background(), arg_search)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{602}, res_search.Postids)
+
+	// Stop forwarding
+	assert.Nil(t, pfcmd.Process.Kill())
+	assert.Nil(t, sfcmd.Process.Kill())
+}
@@ -10,6 +10,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"socialnetworkk8/services/cacheclnt"
 	"socialnetworkk8/services/user"
+	"socialnetworkk8/services/profile"
+	"socialnetworkk8/services/graph"
+	"socialnetworkk8/services/compose"
 	"socialnetworkk8/tune"
 	"os/exec"
 	"time"
@@ -62,26 +65,106 @@ func makeTestUtil() (*TestUtil, error) {
 func (tu *TestUtil) clearDB() error {
 	log.Info().Msg("Removing mongo DB contents ...")
 	tu.mclnt.Database("socialnetwork").Collection("post").DeleteMany(context.TODO(), &bson.M{})
-	tu.mclnt.Database("socialnetwork").Collection("graph-follower").DeleteMany(context.TODO(), &bson.M{})
-	tu.mclnt.Database("socialnetwork").Collection("graph-followee").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("graph-edge").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("graph-count").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("graph-block").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("graph-blockedby").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("graph-mute").DeleteMany(context.TODO(), &bson.M{})
 	tu.mclnt.Database("socialnetwork").Collection("timeline").DeleteMany(context.TODO(), &bson.M{})
 	tu.mclnt.Database("socialnetwork").Collection("url").DeleteMany(context.TODO(), &bson.M{})
 	tu.mclnt.Database("socialnetwork").Collection("media").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("media-blob").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("media-chunk").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("dm-conversation").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("dm-message").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("reaction").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("reaction-count").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("hashtag-entry").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("hashtag-count").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("search-term").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("search-doc").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("notification").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("profile").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("compose-request").DeleteMany(context.TODO(), &bson.M{})
+	tu.mclnt.Database("socialnetwork").Collection("compose-outbox").DeleteMany(context.TODO(), &bson.M{})
 	log.Info().Msg("Re-ensuring mongo DB indexes ...")
 	tu.mclnt.Database("socialnetwork").Collection("user").Indexes().CreateOne(
 		context.TODO(), mongo.IndexModel{Keys: bson.D{{"username", 1}}})
 	tu.mclnt.Database("socialnetwork").Collection("post").Indexes().CreateOne(
 		context.TODO(), mongo.IndexModel{Keys: bson.D{{"postid", 1}}})
-	tu.mclnt.Database("socialnetwork").Collection("graph-follower").Indexes().CreateOne(
-		context.TODO(), mongo.IndexModel{Keys: bson.D{{"userid", 1}}})
-	tu.mclnt.Database("socialnetwork").Collection("graph-followee").Indexes().CreateOne(
-		context.TODO(), mongo.IndexModel{Keys: bson.D{{"userid", 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("graph-edge").Indexes().CreateMany(
+		context.TODO(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "followerid", Value: 1}, {Key: "followeeid", Value: 1}},
+				Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "followerid", Value: 1}, {Key: "_id", Value: 1}}},
+			{Keys: bson.D{{Key: "followeeid", Value: 1}, {Key: "_id", Value: 1}}}})
+	tu.mclnt.Database("socialnetwork").Collection("graph-count").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "userid", Value: 1}}, Options: options.Index().SetUnique(true)})
+	for _, co := range []string{"graph-block", "graph-blockedby", "graph-mute"} {
+		tu.mclnt.Database("socialnetwork").Collection(co).Indexes().CreateOne(
+			context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "userid", Value: 1}}})
+	}
+	tu.mclnt.Database("socialnetwork").Collection("url").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "shorturl", Value: 1}}, Options: options.Index().SetUnique(true)})
 	tu.mclnt.Database("socialnetwork").Collection("url").Indexes().CreateOne(
-		context.TODO(), mongo.IndexModel{Keys: bson.D{{"shorturl", 1}}})
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "extendedurl", Value: 1}}, Options: options.Index().SetUnique(true)})
 	tu.mclnt.Database("socialnetwork").Collection("timeline").Indexes().CreateOne(
-		context.TODO(), mongo.IndexModel{Keys: bson.D{{"userid", 1}}})
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "userid", Value: 1}}, Options: options.Index().SetUnique(true)})
 	tu.mclnt.Database("socialnetwork").Collection("media").Indexes().CreateOne(
 		context.TODO(), mongo.IndexModel{Keys: bson.D{{"mediaid", 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("media-blob").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "hash", Value: 1}}, Options: options.Index().SetUnique(true)})
+	tu.mclnt.Database("socialnetwork").Collection("media-chunk").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "hash", Value: 1}, {Key: "n", Value: 1}}, Options: options.Index().SetUnique(true)})
+	tu.mclnt.Database("socialnetwork").Collection("dm-conversation").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "conversationid", Value: 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("dm-conversation").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "participants", Value: 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("dm-message").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "conversationid", Value: 1}, {Key: "timestamp", Value: -1}}})
+	tu.mclnt.Database("socialnetwork").Collection("reaction").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "postid", Value: 1}, {Key: "userid", Value: 1}},
+			Options: options.Index().SetUnique(true)})
+	tu.mclnt.Database("socialnetwork").Collection("reaction-count").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "postid", Value: 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("hashtag-entry").Indexes().CreateMany(
+		context.TODO(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "hashtag", Value: 1}, {Key: "postid", Value: 1}},
+				Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "hashtag", Value: 1}, {Key: "timestamp", Value: -1}, {Key: "postid", Value: -1}}}})
+	tu.mclnt.Database("socialnetwork").Collection("hashtag-count").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "hashtag", Value: 1}}, Options: options.Index().SetUnique(true)})
+	tu.mclnt.Database("socialnetwork").Collection("search-term").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "term", Value: 1}, {Key: "postid", Value: -1}}})
+	tu.mclnt.Database("socialnetwork").Collection("search-term").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "postid", Value: 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("search-doc").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "postid", Value: 1}}})
+	tu.mclnt.Database("socialnetwork").Collection("notification").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{Keys: bson.D{{Key: "userid", Value: 1}, {Key: "timestamp", Value: -1}}})
+	tu.mclnt.Database("socialnetwork").Collection("profile").Indexes().CreateOne(
+		context.TODO(), mongo.IndexModel{
+			Keys: bson.D{{Key: "userid", Value: 1}}, Options: options.Index().SetUnique(true)})
+	tu.mclnt.Database("socialnetwork").Collection("compose-request").Indexes().CreateMany(
+		context.TODO(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "createdat", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32(compose.IDEMPOTENCY_TTL / time.Second))}})
+	tu.mclnt.Database("socialnetwork").Collection("compose-outbox").Indexes().CreateMany(
+		context.TODO(), []mongo.IndexModel{
+			{Keys: bson.D{{Key: "postid", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "state", Value: 1}, {Key: "nextattempt", Value: 1}}},
+			{Keys: bson.D{{Key: "state", Value: 1}, {Key: "audited", Value: 1}, {Key: "finishedat", Value: 1}}},
+			{Keys: bson.D{{Key: "finishedat", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(int32(compose.OUTBOX_TTL / time.Second))}})
 	return nil
 }
 
@@ -89,18 +172,38 @@ func (tu *TestUtil) initUsers() error {
 	// create NUSER test users
 	for i := 0; i < NUSER; i++ {
 		suffix := strconv.Itoa(i)
+		password, err := user.HashPassword("p_user_" + suffix)
+		if i == 0 {
+			// an unsalted sha256 record, as stored before passwords were salted
+			password = fmt.Sprintf("%x", sha256.Sum256([]byte("p_user_" + suffix)))
+		}
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+			return err
+		}
 		newUser := user.User{
 			Userid: int64(i),
 			Username: "user_" + suffix,
 			Lastname: "Lastname" + suffix,
 			Firstname: "Firstname" + suffix,
-			Password: fmt.Sprintf("%x", sha256.Sum256([]byte("p_user_" + suffix)))}
-		_, err := tu.mclnt.Database("socialnetwork").Collection("user").InsertOne(
+			Password: password}
+		_, err = tu.mclnt.Database("socialnetwork").Collection("user").InsertOne(
 			context.TODO(), &newUser)
 		if err != nil {
 			log.Fatal().Msg(err.Error())
 			return err
 		}
+		newProfile := profile.ProfileBson{
+			Userid: int64(i),
+			Username: "user_" + suffix,
+			Displayname: "Firstname" + suffix + " Lastname" + suffix,
+			Createdat: time.Now().UnixNano()}
+		_, err = tu.mclnt.Database("socialnetwork").Collection("profile").InsertOne(
+			context.TODO(), &newProfile)
+		if err != nil {
+			log.Fatal().Msg(err.Error())
+			return err
+		}
 	}
 	return nil
 }
@@ -108,14 +211,17 @@ func (tu *TestUtil) initUsers() error {
 func (tu *TestUtil) initGraphs() error {
 	//user i follows user i+1
 	for i := 0; i < NUSER-1; i++ {
-		_, err1 := tu.mclnt.Database("socialnetwork").Collection("graph-follower").UpdateOne(
-			context.TODO(), &bson.M{"userid": int64(i+1)},
-			&bson.M{"$addToSet": bson.M{"edges": int64(i)}}, options.Update().SetUpsert(true))
-		_, err2 := tu.mclnt.Database("socialnetwork").Collection("graph-followee").UpdateOne(
+		_, err1 := tu.mclnt.Database("socialnetwork").Collection("graph-edge").InsertOne(
+			context.TODO(), &graph.Edge{
+				Followerid: int64(i), Followeeid: int64(i+1), Createdat: time.Now().UnixNano()})
+		_, err2 := tu.mclnt.Database("socialnetwork").Collection("graph-count").UpdateOne(
 			context.TODO(), &bson.M{"userid": int64(i)},
-			&bson.M{"$addToSet": bson.M{"edges": int64(i+1)}}, options.Update().SetUpsert(true))
-		if err1 != nil || err2 != nil {
-			err := fmt.Errorf("error updating graph %v %v", err1, err2)
+			&bson.M{"$inc": bson.M{"followees": 1}}, options.Update().SetUpsert(true))
+		_, err3 := tu.mclnt.Database("socialnetwork").Collection("graph-count").UpdateOne(
+			context.TODO(), &bson.M{"userid": int64(i+1)},
+			&bson.M{"$inc": bson.M{"followers": 1}}, options.Update().SetUpsert(true))
+		if err1 != nil || err2 != nil || err3 != nil {
+			err := fmt.Errorf("error updating graph %v %v %v", err1, err2, err3)
 			log.Fatal().Msg(err.Error())
 			return err
 		}
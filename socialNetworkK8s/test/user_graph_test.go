@@ -3,11 +3,15 @@ package test
 import (
 	"testing"
 	"fmt"
+	"math"
+	"strings"
 	"github.com/stretchr/testify/assert"
 	"socialnetworkk8/dialer"
 	"context"
 	userpb "socialnetworkk8/services/user/proto"
 	graphpb "socialnetworkk8/services/graph/proto"
+	profilepb "socialnetworkk8/services/profile/proto"
+	textpb "socialnetworkk8/services/text/proto"
 )
 
 func TestUser(t *testing.T) {
@@ -60,10 +64,131 @@ func TestUser(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "OK", res_login.Ok)
 
+	// legacy sha256 records still log in, and keep doing so once re-hashed
+	arg_login = &userpb.LoginRequest{Username: "user_0", Password: "p_user_1"}
+	res_login, err = userClient.Login(context.Background(), arg_login)
+	assert.Nil(t, err)
+	assert.Equal(t, "Login Failure.", res_login.Ok)
+	arg_login.Password = "p_user_0"
+	for i := 0; i < 2; i++ {
+		res_login, err = userClient.Login(context.Background(), arg_login)
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_login.Ok)
+		assert.Equal(t, int64(0), res_login.Userid)
+	}
+	arg_login = &userpb.LoginRequest{Username: "user_1", Password: "p_user_1"}
+	res_login, err = userClient.Login(context.Background(), arg_login)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_login.Ok)
+
 	// Stop fowarding
 	assert.Nil(t, fcmd.Process.Kill())
 }
 
+func TestProfile(t *testing.T) {
+	// start k8s port forwarding and set up client connection.
+	testPortUser := "9000"
+	testPortProfile := "9001"
+	fcmdu, err := StartFowarding("user", testPortUser, "8084")
+	assert.Nil(t, err)
+	fcmdp, err := StartFowarding("profile", testPortProfile, "8097")
+	assert.Nil(t, err)
+	connu, err := dialer.Dial("localhost:" + testPortUser, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	userClient := userpb.NewUserClient(connu)
+	connp, err := dialer.Dial("localhost:" + testPortProfile, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	profileClient := profilepb.NewProfileClient(connp)
+	assert.NotNil(t, profileClient)
+
+	// seeded users have profiles; unknown ones are reported missing
+	arg_get := &profilepb.GetUsersRequest{Userids: []int64{int64(1), int64(12345)}}
+	res_get, err := profileClient.GetUsers(context.Background(), arg_get)
+	assert.Nil(t, err)
+	assert.Equal(t, "No. Missing 12345.", res_get.Ok)
+	assert.Equal(t, 2, len(res_get.Profiles))
+	assert.Equal(t, "user_1", res_get.Profiles[0].Username)
+	assert.Equal(t, "Firstname1 Lastname1", res_get.Profiles[0].Displayname)
+	assert.Equal(t, int64(12345), res_get.Profiles[1].Userid)
+	assert.Equal(t, "", res_get.Profiles[1].Displayname)
+
+	// registering a user creates its profile
+	arg_reg := &userpb.RegisterUserRequest{
+		Firstname: "Alice", Lastname: "Test", Username: "profile_user", Password: "xxyyzz"}
+	res_reg, err := userClient.RegisterUser(context.Background(), arg_reg)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_reg.Ok)
+	userid := res_reg.Userid
+	arg_get.Userids = []int64{userid}
+	res_get, err = profileClient.GetUsers(context.Background(), arg_get)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_get.Ok)
+	assert.Equal(t, "profile_user", res_get.Profiles[0].Username)
+	assert.Equal(t, "Alice Test", res_get.Profiles[0].Displayname)
+	assert.True(t, res_get.Profiles[0].Createdat > 0)
+
+	arg_create := &profilepb.CreateProfileRequest{
+		Userid: userid, Username: "profile_user", Displayname: "Alice"}
+	res_create, err := profileClient.CreateProfile(context.Background(), arg_create)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("Profile of %v already exists.", userid), res_create.Ok)
+
+	// update only the fields asked for
+	arg_update := &profilepb.UpdateProfileRequest{
+		Userid: userid, Displayname: "Alice T.", Bio: "Hello there", Fields: []string{"bio"}}
+	res_update, err := profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_update.Ok)
+	res_get, err = profileClient.GetUsers(context.Background(), arg_get)
+	assert.Nil(t, err)
+	assert.Equal(t, "Alice Test", res_get.Profiles[0].Displayname)
+	assert.Equal(t, "Hello there", res_get.Profiles[0].Bio)
+
+	arg_update.Fields = []string{"displayname"}
+	res_update, err = profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_update.Ok)
+	res_get, err = profileClient.GetUsers(context.Background(), arg_get)
+	assert.Nil(t, err)
+	assert.Equal(t, "Alice T.", res_get.Profiles[0].Displayname)
+
+	// invalid updates leave the profile alone
+	arg_update.Displayname = "   "
+	res_update, err = profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "Display name cannot be empty.", res_update.Ok)
+	arg_update.Bio = strings.Repeat("b", 161)
+	arg_update.Fields = []string{"bio"}
+	res_update, err = profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bio is longer than 160 characters.", res_update.Ok)
+	arg_update.Avatarmediaid = int64(424242)
+	arg_update.Fields = []string{"avatarmediaid"}
+	res_update, err = profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "Avatar Error: No Missing 424242.", res_update.Ok)
+	arg_update.Fields = []string{"email"}
+	res_update, err = profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "Unknown profile field email.", res_update.Ok)
+	res_get, err = profileClient.GetUsers(context.Background(), arg_get)
+	assert.Nil(t, err)
+	assert.Equal(t, "Alice T.", res_get.Profiles[0].Displayname)
+	assert.Equal(t, "Hello there", res_get.Profiles[0].Bio)
+	assert.Equal(t, int64(0), res_get.Profiles[0].Avatarmediaid)
+
+	arg_update.Userid = int64(12345)
+	arg_update.Bio = "nobody"
+	arg_update.Fields = []string{"bio"}
+	res_update, err = profileClient.UpdateProfile(context.Background(), arg_update)
+	assert.Nil(t, err)
+	assert.Equal(t, "No profile for user 12345.", res_update.Ok)
+
+	// Stop fowarding
+	assert.Nil(t, fcmdu.Process.Kill())
+	assert.Nil(t, fcmdp.Process.Kill())
+}
+
 func TestGraph(t *testing.T) {
 	// start k8s port forwarding and set up client connection.
 	testPort := "9000"
@@ -107,6 +232,38 @@ func TestGraph(t *testing.T) {
 	assert.Equal(t, int64(2), res_get.Userids[0]) // user 1 has two followees user 0 & 2
 	assert.Equal(t, int64(0), res_get.Userids[1])
 
+	// page through the followees, oldest follow first
+	arg_page := graphpb.GetFolloweesRequest{Followerid: int64(1), Limit: 1}
+	res_get, err = graphClient.GetFollowees(context.Background(), &arg_page)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_get.Ok)
+	assert.Equal(t, []int64{int64(2)}, res_get.Userids)
+	assert.NotEqual(t, "", res_get.Nextcursor)
+	arg_page.Cursor = res_get.Nextcursor
+	res_get, err = graphClient.GetFollowees(context.Background(), &arg_page)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_get.Ok)
+	assert.Equal(t, []int64{int64(0)}, res_get.Userids)
+	assert.Equal(t, "", res_get.Nextcursor)
+	arg_page.Cursor = "nonsense"
+	res_get, err = graphClient.GetFollowees(context.Background(), &arg_page)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bad cursor.", res_get.Ok)
+
+	arg_counts := graphpb.GetCountsRequest{Userids: []int64{int64(0), int64(1)}}
+	res_counts, err := graphClient.GetCounts(context.Background(), &arg_counts)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_counts.Ok)
+	assert.Equal(t, []int64{int64(1), int64(1)}, res_counts.Followers)
+	assert.Equal(t, []int64{int64(1), int64(2)}, res_counts.Followees)
+
+	arg_isflw := graphpb.IsFollowingRequest{
+		Followerid: int64(1), Followeeids: []int64{int64(0), int64(2), int64(3)}}
+	res_isflw, err := graphClient.IsFollowing(context.Background(), &arg_isflw)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_isflw.Ok)
+	assert.Equal(t, []bool{true, true, false}, res_isflw.Following)
+
 	// Unfollow
 	arg_unfollow := graphpb.UnfollowRequest{Followerid: int64(1), Followeeid: int64(0)}
 	res_unfollow, err := graphClient.Unfollow(context.Background(), &arg_unfollow)
@@ -117,6 +274,11 @@ func TestGraph(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "OK", res_get.Ok)
 	assert.Equal(t, 0, len(res_get.Userids)) // user 0 has no follower
+
+	res_counts, err = graphClient.GetCounts(context.Background(), &arg_counts)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(0), int64(1)}, res_counts.Followers)
+	assert.Equal(t, []int64{int64(1), int64(1)}, res_counts.Followees)
 	
 	res_get, err = graphClient.GetFollowees(context.Background(), &arg_get_flwEE)
 	assert.Nil(t, err)
@@ -128,6 +290,186 @@ func TestGraph(t *testing.T) {
 	assert.Nil(t, fcmd.Process.Kill())
 }
 
+func TestRecommendFollows(t *testing.T) {
+	// start k8s port forwarding and set up client connection.
+	testPort := "9000"
+	fcmd, err := StartFowarding("graph", testPort, "8085")
+	assert.Nil(t, err)
+	conn, err := dialer.Dial("localhost:" + testPort, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	graphClient := graphpb.NewGraphClient(conn)
+
+	// user 2 follows 3, who follows 4 and 6
+	res_follow, err := graphClient.Follow(
+		context.Background(), &graphpb.FollowRequest{Followerid: int64(3), Followeeid: int64(6)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_follow.Ok)
+	arg_rec := &graphpb.RecommendFollowsRequest{Userid: int64(2)}
+	res_rec, err := graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rec.Ok)
+	assert.Equal(t, []int64{int64(4), int64(6)}, res_rec.Userids)
+	assert.Equal(t, []float64{1, 1}, res_rec.Scores)
+
+	// following 5, who follows 6 too, refreshes user 2's recommendations
+	res_follow, err = graphClient.Follow(
+		context.Background(), &graphpb.FollowRequest{Followerid: int64(2), Followeeid: int64(5)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_follow.Ok)
+	res_rec, err = graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6), int64(4)}, res_rec.Userids)
+	assert.Equal(t, []float64{2, 1}, res_rec.Scores)
+	arg_rec.Limit = 1
+	res_rec, err = graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6)}, res_rec.Userids)
+
+	arg_rec.Limit = 0
+	arg_rec.Scoring = graphpb.RECOMMEND_SCORING_JACCARD
+	res_rec, err = graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6), int64(4)}, res_rec.Userids)
+	assert.Equal(t, []float64{1, 0.5}, res_rec.Scores)
+	arg_rec.Scoring = graphpb.RECOMMEND_SCORING_ADAMIC_ADAR
+	res_rec, err = graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6), int64(4)}, res_rec.Userids)
+	assert.InDelta(t, 1/math.Log(3)+1/math.Log(2), res_rec.Scores[0], 1e-9)
+	assert.InDelta(t, 1/math.Log(3), res_rec.Scores[1], 1e-9)
+
+	// followees and blocked users are never recommended
+	arg_rel := &graphpb.RelationRequest{Userid: int64(2), Targetid: int64(6)}
+	res_rel, err := graphClient.Block(context.Background(), arg_rel)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rel.Ok)
+	res_rec, err = graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(4)}, res_rec.Userids)
+	res_rel, err = graphClient.Unblock(context.Background(), arg_rel)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rel.Ok)
+	res_follow, err = graphClient.Follow(
+		context.Background(), &graphpb.FollowRequest{Followerid: int64(2), Followeeid: int64(4)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_follow.Ok)
+	arg_rec.Scoring = graphpb.RECOMMEND_SCORING_OVERLAP
+	res_rec, err = graphClient.RecommendFollows(context.Background(), arg_rec)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6)}, res_rec.Userids)
+	assert.Equal(t, []float64{2}, res_rec.Scores)
+
+	// restore the graph
+	for _, pair := range [][2]int64{{2, 4}, {2, 5}, {3, 6}} {
+		res_unfollow, err := graphClient.Unfollow(context.Background(),
+			&graphpb.UnfollowRequest{Followerid: pair[0], Followeeid: pair[1]})
+		assert.Nil(t, err)
+		assert.Equal(t, "OK", res_unfollow.Ok)
+	}
+
+	// Stop fowarding
+	assert.Nil(t, fcmd.Process.Kill())
+}
+
+func TestBlockMute(t *testing.T) {
+	// start k8s port forwarding and set up client connection.
+	testPortGraph := "9000"
+	testPortText := "9001"
+	fcmdg, err := StartFowarding("graph", testPortGraph, "8085")
+	assert.Nil(t, err)
+	fcmdt, err := StartFowarding("text", testPortText, "8088")
+	assert.Nil(t, err)
+	conng, err := dialer.Dial("localhost:" + testPortGraph, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	graphClient := graphpb.NewGraphClient(conng)
+	connt, err := dialer.Dial("localhost:" + testPortText, nil)
+	assert.Nil(t, err, fmt.Sprintf("dialer error: %v", err))
+	textClient := textpb.NewTextClient(connt)
+
+	// user 7 blocks user 6, who follows them
+	arg_rel := &graphpb.RelationRequest{Userid: int64(7), Targetid: int64(7)}
+	res_rel, err := graphClient.Block(context.Background(), arg_rel)
+	assert.Nil(t, err)
+	assert.Equal(t, "Cannot block self.", res_rel.Ok)
+	arg_rel.Targetid = int64(6)
+	res_rel, err = graphClient.Block(context.Background(), arg_rel)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rel.Ok)
+	res_get, err := graphClient.GetFollowees(
+		context.Background(), &graphpb.GetFolloweesRequest{Followerid: int64(6)})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(res_get.Userids))
+	res_get, err = graphClient.GetBlocked(context.Background(), &graphpb.GetRelationsRequest{Userid: int64(7)})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6)}, res_get.Userids)
+
+	// neither can follow the other
+	res_follow, err := graphClient.Follow(
+		context.Background(), &graphpb.FollowRequest{Followerid: int64(6), Followeeid: int64(7)})
+	assert.Nil(t, err)
+	assert.Equal(t, "Cannot follow 7: blocked.", res_follow.Ok)
+	res_follow, err = graphClient.Follow(
+		context.Background(), &graphpb.FollowRequest{Followerid: int64(7), Followeeid: int64(6)})
+	assert.Nil(t, err)
+	assert.Equal(t, "Cannot follow 6: blocked.", res_follow.Ok)
+
+	// user 8 mutes user 7, who still follows them
+	res_rel, err = graphClient.Mute(
+		context.Background(), &graphpb.RelationRequest{Userid: int64(8), Targetid: int64(7)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rel.Ok)
+	res_get, err = graphClient.GetMuted(context.Background(), &graphpb.GetRelationsRequest{Userid: int64(8)})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(7)}, res_get.Userids)
+	res_get, err = graphClient.GetFollowers(
+		context.Background(), &graphpb.GetFollowersRequest{Followeeid: int64(8)})
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(7)}, res_get.Userids)
+
+	arg_hidden := &graphpb.GetHiddenRequest{Userids: []int64{int64(6), int64(7), int64(8), int64(9)}}
+	res_hidden, err := graphClient.GetHidden(context.Background(), arg_hidden)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_hidden.Ok)
+	assert.Equal(t, []int64{int64(7)}, res_hidden.Hidden[0].Userids)
+	assert.Equal(t, []int64{int64(6)}, res_hidden.Hidden[1].Userids)
+	assert.Equal(t, []int64{int64(7)}, res_hidden.Hidden[2].Userids)
+	assert.Equal(t, 0, len(res_hidden.Hidden[3].Userids))
+
+	// mentions of users who block, are blocked by or mute the author are dropped
+	arg_text := &textpb.ProcessTextRequest{Text: "Hi @user_6 and @user_8", Userid: int64(7)}
+	res_text, err := textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_text.Ok)
+	assert.Equal(t, 0, len(res_text.Usermentions))
+	assert.Equal(t, "Hi @user_6 and @user_8", res_text.Text)
+	arg_text.Userid = int64(9)
+	res_text, err = textClient.ProcessText(context.Background(), arg_text)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{int64(6), int64(8)}, res_text.Usermentions)
+
+	// lift the block and mute, and restore the follow
+	res_rel, err = graphClient.Unblock(context.Background(), arg_rel)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rel.Ok)
+	res_rel, err = graphClient.Unmute(
+		context.Background(), &graphpb.RelationRequest{Userid: int64(8), Targetid: int64(7)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_rel.Ok)
+	res_hidden, err = graphClient.GetHidden(context.Background(), arg_hidden)
+	assert.Nil(t, err)
+	for _, hidden := range res_hidden.Hidden {
+		assert.Equal(t, 0, len(hidden.Userids))
+	}
+	res_follow, err = graphClient.Follow(
+		context.Background(), &graphpb.FollowRequest{Followerid: int64(6), Followeeid: int64(7)})
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", res_follow.Ok)
+
+	// Stop fowarding
+	assert.Nil(t, fcmdg.Process.Kill())
+	assert.Nil(t, fcmdt.Process.Kill())
+}
+
 func TestUserAndGraph(t *testing.T) {
 	// start k8s port forwarding and set up client connection.
 	testPortGraph := "9000"